@@ -0,0 +1,60 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AbortCondition stops the chaos as soon as it trips. Exactly one of its
+// members is set.
+// +kubebuilder:validation:XValidation:rule="[has(self.prometheus), has(self.httpErrorRate), has(self.minReadyPods)].filter(x, x).size() == 1",message="an abort condition needs exactly one of prometheus, httpErrorRate or minReadyPods"
+type AbortCondition struct {
+	// Name identifies the condition in the status once it has fired
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+	// Prometheus trips when the query result compares true against the
+	// threshold, e.g. an error rate > 0.1
+	// +optional
+	Prometheus *PrometheusProbe `json:"prometheus,omitempty"`
+	// HTTPErrorRate trips when too many runs of an HTTP steady-state probe
+	// have failed during chaos
+	// +optional
+	HTTPErrorRate *HTTPErrorRateCondition `json:"httpErrorRate,omitempty"`
+	// MinReadyPods trips when fewer pods are ready
+	// +optional
+	MinReadyPods *MinReadyPodsCondition `json:"minReadyPods,omitempty"`
+}
+
+// HTTPErrorRateCondition watches the samples of an HTTP probe in spec.steadyState
+type HTTPErrorRateCondition struct {
+	// Probe names an http probe of spec.steadyState
+	Probe string `json:"probe"`
+	// MaxPercent is the highest share of failed samples during chaos tolerated
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=99
+	MaxPercent int `json:"maxPercent"`
+	// MinSamples is how many samples must have been taken during chaos before
+	// the rate is evaluated
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=3
+	// +optional
+	MinSamples int `json:"minSamples,omitempty"`
+}
+
+// MinReadyPodsCondition counts the ready pods of the app
+type MinReadyPodsCondition struct {
+	// Count is the fewest ready pods tolerated
+	// +kubebuilder:validation:Minimum=1
+	Count int `json:"count"`
+	// Selector overrides spec.appSelector for the pods counted
+	// +optional
+	Selector map[string]string `json:"selector,omitempty"`
+}
+
+// AbortStatus records the abort condition that stopped a test
+type AbortStatus struct {
+	// Condition is the name of the abort condition that fired
+	Condition string      `json:"condition"`
+	Message   string      `json:"message"`
+	Time      metav1.Time `json:"time"`
+}
@@ -1,36 +1,260 @@
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // ChaosDRTestSpec defines the desired state of ChaosDRTest
+// +kubebuilder:validation:XValidation:rule="has(self.chaos) || has(self.chaosType) || has(self.scenario)",message="one of chaos, chaosType or scenario is required"
+// +kubebuilder:validation:XValidation:rule="!has(self.scenario) || !(has(self.chaos) || has(self.chaosType))",message="scenario replaces chaos and chaosType"
 type ChaosDRTestSpec struct {
 	// AppSelector selects the K8s app to test (e.g., app=redis)
 	AppSelector map[string]string `json:"appSelector"`
-	// ChaosType specifies the chaos experiment (fixed to pod-delete for prototype)
-	ChaosType string `json:"chaosType"` // e.g., "pod-delete", "network-delay", "cpu-stress"
+	// Chaos is the chaos injected between backup and restore
+	// +optional
+	Chaos *ChaosSpec `json:"chaos,omitempty"`
+	// ChaosType specifies the chaos experiment.
+	// Deprecated: use chaos.type; ignored when chaos is set.
+	// +optional
+	ChaosType string `json:"chaosType,omitempty"` // e.g., "pod-delete", "network-delay", "cpu-stress", "memory-stress"
+	// Scenario runs an ordered list of chaos steps instead of a single chaos
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	Scenario []ScenarioStep `json:"scenario,omitempty"`
 	// ValidationScript runs post-restore (e.g., curl healthz)
+	// +optional
 	ValidationScript string `json:"validationScript"`
-	// Add parameters for new chaos types, e.g.:
-	ChaosParameters  map[string]string `json:"chaosParameters,omitempty"` // e.g., {"delay": "100ms", "jitter": "10ms"}
-	ValidationConfig ValidationConfig  `json:"validationConfig"`
+	// ChaosParameters are the parameters of chaosType, converted into the matching chaos member.
+	// Deprecated: use the typed members of chaos.
+	ChaosParameters map[string]string `json:"chaosParameters,omitempty"` // e.g., {"delay": "100ms", "jitter": "10ms"} or {"workers": "2", "load": "80"}
+	// ChaosDuration is how long the chaos experiment runs before Chaos Mesh
+	// recovers its targets and the restore starts
+	// +kubebuilder:default="30s"
+	// +optional
+	ChaosDuration *metav1.Duration `json:"chaosDuration,omitempty"`
+	// +optional
+	ValidationConfig ValidationConfig `json:"validationConfig"`
+	// Backup configures how the app is backed up and restored
+	// +optional
+	Backup *BackupSpec `json:"backup,omitempty"`
+	// CleanupPolicy decides when the chaos object and sandbox namespace are
+	// removed once the test has finished. Resources kept by OnSuccess are
+	// still removed when the ChaosDRTest is deleted; Never keeps them for good.
+	// +kubebuilder:validation:Enum=Always;OnSuccess;Never
+	// +kubebuilder:default=Always
+	// +optional
+	CleanupPolicy CleanupPolicy `json:"cleanupPolicy,omitempty"`
+	// DeleteBackup also removes the backup, its stored data and the restore during cleanup
+	// +optional
+	DeleteBackup bool `json:"deleteBackup,omitempty"`
+	// SteadyState probes confirm the app is healthy before the backup and
+	// track its health while chaos is injected
+	// +optional
+	SteadyState *SteadyStateSpec `json:"steadyState,omitempty"`
+	// AbortConditions are evaluated on every requeue while chaos is injected.
+	// When one trips the chaos is stopped, the restore skipped and the test
+	// ends in phase Aborted.
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	AbortConditions []AbortCondition `json:"abortConditions,omitempty"`
+	// RPOProbe measures the data lost by the restore with markers written
+	// before and during the backup and chaos
+	// +optional
+	RPOProbe *RPOProbeSpec `json:"rpoProbe,omitempty"`
+}
+
+// CleanupPolicy controls the removal of the resources a test leaves behind
+type CleanupPolicy string
+
+const (
+	// CleanupAlways cleans up as soon as the test finishes
+	CleanupAlways CleanupPolicy = "Always"
+	// CleanupOnSuccess cleans up finished tests that succeeded and keeps failed
+	// and aborted ones for inspection until the ChaosDRTest is deleted
+	CleanupOnSuccess CleanupPolicy = "OnSuccess"
+	// CleanupNever leaves everything in place, even when the ChaosDRTest is deleted
+	CleanupNever CleanupPolicy = "Never"
+)
+
+// BackupSpec selects and configures the backup provider
+type BackupSpec struct {
+	// Provider names a registered backup provider (e.g. velero, restic).
+	// Defaults to the operator's --default-backup-provider.
+	// +optional
+	Provider string `json:"provider,omitempty"`
+	// Restic configures the restic provider; required when it is selected
+	// +optional
+	Restic *ResticSpec `json:"restic,omitempty"`
+}
+
+// ResticSpec configures restic backups of the app's PVC data
+type ResticSpec struct {
+	// Repository is the restic repository, e.g. s3:http://minio:9000/backups/restic
+	Repository string `json:"repository"`
+	// CredentialsSecretRef names a Secret in the test's namespace whose keys are
+	// passed to restic as environment variables (RESTIC_PASSWORD, AWS_ACCESS_KEY_ID, ...)
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+	// Image is the restic container image used by backup and restore Jobs
+	// +kubebuilder:default="restic/restic:0.16.4"
+	// +optional
+	Image string `json:"image,omitempty"`
+	// StorageClassName overrides the storage class of the PVCs created for the restore
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+}
+
+// ChaosDRTestPhase is the stage a ChaosDRTest has reached in its lifecycle
+type ChaosDRTestPhase string
+
+const (
+	PhasePending        ChaosDRTestPhase = "Pending"
+	PhaseBackingUp      ChaosDRTestPhase = "BackingUp"
+	PhaseInjectingChaos ChaosDRTestPhase = "InjectingChaos"
+	PhaseRestoring      ChaosDRTestPhase = "Restoring"
+	PhaseValidating     ChaosDRTestPhase = "Validating"
+	PhaseStoringProof   ChaosDRTestPhase = "StoringProof"
+	PhaseSucceeded      ChaosDRTestPhase = "Succeeded"
+	PhaseFailed         ChaosDRTestPhase = "Failed"
+	PhaseAborted        ChaosDRTestPhase = "Aborted"
+)
+
+// IsTerminal reports whether no further work is scheduled for the phase
+func (p ChaosDRTestPhase) IsTerminal() bool {
+	return p == PhaseSucceeded || p == PhaseFailed || p == PhaseAborted
+}
+
+// Condition types reported on ChaosDRTestStatus.Conditions
+const (
+	ConditionBackupCompleted  = "BackupCompleted"
+	ConditionChaosInjected    = "ChaosInjected"
+	ConditionRestoreCompleted = "RestoreCompleted"
+	ConditionValidated        = "Validated"
+	ConditionProofStored      = "ProofStored"
+	// ConditionCleanedUp is True once the resources left by the test are removed
+	ConditionCleanedUp = "CleanedUp"
+	// ConditionReady is True once the whole test has succeeded
+	ConditionReady = "Ready"
+)
+
+// PhaseTiming records when a phase was entered and left
+type PhaseTiming struct {
+	Phase     ChaosDRTestPhase `json:"phase"`
+	StartTime metav1.Time      `json:"startTime"`
+	// FinishTime is unset while the phase is still running
+	FinishTime *metav1.Time `json:"finishTime,omitempty"`
+}
+
+// VolumeSnapshot identifies the snapshot a provider took of one volume
+type VolumeSnapshot struct {
+	// Volume is the name of the PVC that was backed up
+	Volume string `json:"volume"`
+	ID     string `json:"id"`
+}
+
+// OperationStatus reports a backup or restore as seen by the backup backend
+type OperationStatus struct {
+	Name string `json:"name"`
+	// Namespace is where the backend keeps the operation's object, if it has one
+	Namespace string `json:"namespace,omitempty"`
+	Phase     string `json:"phase,omitempty"`
+	// TotalItems and ItemsCompleted track how many objects have been processed
+	TotalItems     int `json:"totalItems,omitempty"`
+	ItemsCompleted int `json:"itemsCompleted,omitempty"`
+	// Warnings and Errors are the counts reported by the backend
+	Warnings int `json:"warnings,omitempty"`
+	Errors   int `json:"errors,omitempty"`
+	// SizeBytes is the amount of volume data transferred
+	SizeBytes int64 `json:"sizeBytes,omitempty"`
+	// Snapshots lists the per-volume snapshots taken by the backup
+	Snapshots []VolumeSnapshot `json:"snapshots,omitempty"`
+	// Messages holds validation errors and the failure reason, if any
+	Messages       []string     `json:"messages,omitempty"`
+	StartTime      *metav1.Time `json:"startTime,omitempty"`
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// ChaosExperimentStatus reports the injected chaos as seen by the chaos framework
+type ChaosExperimentStatus struct {
+	// State is Pending, Injected, Recovered or Failed
+	State string `json:"state,omitempty"`
+	// InjectedTargets counts the targets currently under chaos, out of TotalTargets
+	InjectedTargets int    `json:"injectedTargets,omitempty"`
+	TotalTargets    int    `json:"totalTargets,omitempty"`
+	Message         string `json:"message,omitempty"`
 }
 
 // ChaosDRTestStatus defines the observed state of ChaosDRTest
 type ChaosDRTestStatus struct {
-	Success         bool    `json:"success"`
-	ErrorMessage    string  `json:"errorMessage,omitempty"`
-	BackupName      string  `json:"backupName,omitempty"`
-	RestoreName     string  `json:"restoreName,omitempty"`
-	BackupDuration  float64 `json:"backupDuration,omitempty"`
-	RestoreDuration float64 `json:"restoreDuration,omitempty"`
+	// ObservedGeneration is the spec generation the status was computed from
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions track the outcome of each step plus the overall Ready state
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Phase is the current step of the test; the reconciler resumes from here
+	// +kubebuilder:validation:Enum=Pending;BackingUp;InjectingChaos;Restoring;Validating;StoringProof;Succeeded;Failed;Aborted
+	Phase ChaosDRTestPhase `json:"phase,omitempty"`
+	// PhaseStartTime is when the current phase was entered
+	PhaseStartTime *metav1.Time `json:"phaseStartTime,omitempty"`
+	// StartTime is when the test left Pending
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is when the test reached Succeeded, Failed or Aborted
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// PhaseTimings lists the start and finish time of every phase entered so far
+	// +optional
+	PhaseTimings []PhaseTiming `json:"phaseTimings,omitempty"`
+
+	// BackupProvider is the provider resolved when the test started
+	BackupProvider string `json:"backupProvider,omitempty"`
+	// ChaosProvider is the chaos provider resolved when the test started
+	ChaosProvider string `json:"chaosProvider,omitempty"`
+	// ChaosType is the chaos type resolved when the test started, or scenario
+	// for tests running spec.scenario
+	ChaosType string `json:"chaosType,omitempty"`
+
+	Success          bool    `json:"success"`
+	ErrorMessage     string  `json:"errorMessage,omitempty"`
+	BackupName       string  `json:"backupName,omitempty"`
+	ChaosName        string  `json:"chaosName,omitempty"`
+	RestoreName      string  `json:"restoreName,omitempty"`
+	SandboxNamespace string  `json:"sandboxNamespace,omitempty"`
+	BackupDuration   float64 `json:"backupDuration,omitempty"`
+	RestoreDuration  float64 `json:"restoreDuration,omitempty"`
+	// Backup and Restore mirror the backend's view of each operation
+	Backup  *OperationStatus `json:"backup,omitempty"`
+	Restore *OperationStatus `json:"restore,omitempty"`
+	// Chaos mirrors the experiment records of the injected chaos
+	Chaos *ChaosExperimentStatus `json:"chaos,omitempty"`
+	// Scenario tracks each step of spec.scenario
+	// +optional
+	Scenario []ScenarioStepStatus `json:"scenario,omitempty"`
+	// SteadyState holds the samples of each spec.steadyState probe
+	// +optional
+	SteadyState []ProbeStatus `json:"steadyState,omitempty"`
+	// Abort records the abort condition that stopped the chaos, if any
+	// +optional
+	Abort *AbortStatus `json:"abort,omitempty"`
+	// RPO reports the markers of spec.rpoProbe and the data-loss window
+	// +optional
+	RPO *RPOStatus `json:"rpo,omitempty"`
+	// Validation holds the result of every validation check
+	// +optional
+	Validation []ValidationResult `json:"validation,omitempty"`
 }
 
-//+kubebuilder:object:root=true
-//+kubebuilder:subresource:status
-//+kubebuilder:resource:path=chaodrtests,scope=Namespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=chaodrtests,singular=chaodrtest,scope=Namespaced
+// +kubebuilder:printcolumn:name="Chaos",type=string,JSONPath=`.status.chaosType`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Backup",type=number,JSONPath=`.status.backupDuration`,priority=1
+// +kubebuilder:printcolumn:name="Restore",type=number,JSONPath=`.status.restoreDuration`,priority=1
+// +kubebuilder:printcolumn:name="Data Loss",type=string,JSONPath=`.status.rpo.dataLoss`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // ChaosDRTest is the Schema for the chaodrtests API
 type ChaosDRTest struct {
@@ -41,7 +265,7 @@ type ChaosDRTest struct {
 	Status ChaosDRTestStatus `json:"status,omitempty"`
 }
 
-//+kubebuilder:object:root=true
+// +kubebuilder:object:root=true
 
 // ChaosDRTestList contains a list of ChaosDRTest
 type ChaosDRTestList struct {
@@ -50,35 +274,6 @@ type ChaosDRTestList struct {
 	Items           []ChaosDRTest `json:"items"`
 }
 
-type ValidationConfig struct {
-	Script             string         `json:"script,omitempty"`
-	APIEndpoint        string         `json:"apiEndpoint,omitempty"`
-	ExpectedStatusCode int            `json:"expectedStatusCode,omitempty"`
-	DatabaseQuery      *DatabaseQuery `json:"databaseQuery,omitempty"`
-}
-
-type DatabaseQuery struct {
-	ConnectionString string `json:"connectionString"`
-	Query            string `json:"query"`
-	ExpectedRows     int    `json:"expectedRows"`
-}
-
-// DeepCopyObject implements runtime.Object for ChaosDRTest
-func (in *ChaosDRTest) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
-// DeepCopyObject implements runtime.Object for ChaosDRTestList
-func (in *ChaosDRTestList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 func init() {
 	SchemeBuilder.Register(&ChaosDRTest{}, &ChaosDRTestList{})
 }
@@ -0,0 +1,347 @@
+package v1
+
+// Chaos types accepted by ChaosSpec.Type and the legacy spec.chaosType
+const (
+	ChaosTypePodDelete        = "pod-delete"
+	ChaosTypeNetworkDelay     = "network-delay"
+	ChaosTypeNetworkLoss      = "network-loss"
+	ChaosTypeNetworkPartition = "network-partition"
+	ChaosTypeNetworkCorrupt   = "network-corrupt"
+	ChaosTypeNetworkDuplicate = "network-duplicate"
+	ChaosTypeNetworkBandwidth = "network-bandwidth"
+	ChaosTypeCPUStress        = "cpu-stress"
+	ChaosTypeMemoryStress     = "memory-stress"
+	ChaosTypeIOLatency        = "io-latency"
+	ChaosTypeIOFault          = "io-fault"
+	ChaosTypeIOAttrOverride   = "io-attr-override"
+	ChaosTypeTimeSkew         = "time-skew"
+	ChaosTypeDNSError         = "dns-error"
+	ChaosTypeDNSRandom        = "dns-random"
+	ChaosTypeScaleToZero      = "scale-to-zero"
+	ChaosTypeNodeDrain        = "node-drain"
+	ChaosTypePVCDelete        = "pvc-delete"
+)
+
+// Modes selecting which of the app's pods a chaos targets
+const (
+	ChaosModeOne              = "one"
+	ChaosModeAll              = "all"
+	ChaosModeFixed            = "fixed"
+	ChaosModeFixedPercent     = "fixed-percent"
+	ChaosModeRandomMaxPercent = "random-max-percent"
+)
+
+// ChaosSpec describes the chaos injected into the app. Type selects the
+// action and the member of the same name holds its parameters; the two
+// stress types share the stress member, the three io types the io member and
+// the two dns types the dns member. scale-to-zero and pvc-delete take no
+// parameters; they and node-drain are only run by the native provider.
+// Mode and Value pick which of the app's pods are targeted.
+// +kubebuilder:validation:XValidation:rule="self.type != 'network-delay' || has(self.networkDelay)",message="networkDelay is required for type network-delay"
+// +kubebuilder:validation:XValidation:rule="self.type != 'network-loss' || has(self.networkLoss)",message="networkLoss is required for type network-loss"
+// +kubebuilder:validation:XValidation:rule="self.type != 'network-partition' || has(self.networkPartition)",message="networkPartition is required for type network-partition"
+// +kubebuilder:validation:XValidation:rule="self.type != 'network-corrupt' || has(self.networkCorrupt)",message="networkCorrupt is required for type network-corrupt"
+// +kubebuilder:validation:XValidation:rule="self.type != 'network-duplicate' || has(self.networkDuplicate)",message="networkDuplicate is required for type network-duplicate"
+// +kubebuilder:validation:XValidation:rule="self.type != 'network-bandwidth' || has(self.networkBandwidth)",message="networkBandwidth is required for type network-bandwidth"
+// +kubebuilder:validation:XValidation:rule="self.type != 'io-latency' || (has(self.io) && has(self.io.delay))",message="io.delay is required for type io-latency"
+// +kubebuilder:validation:XValidation:rule="self.type != 'io-fault' || (has(self.io) && has(self.io.errno))",message="io.errno is required for type io-fault"
+// +kubebuilder:validation:XValidation:rule="self.type != 'io-attr-override' || (has(self.io) && has(self.io.attr))",message="io.attr is required for type io-attr-override"
+// +kubebuilder:validation:XValidation:rule="self.type != 'time-skew' || has(self.timeSkew)",message="timeSkew is required for type time-skew"
+// +kubebuilder:validation:XValidation:rule="!has(self.podDelete) || self.type == 'pod-delete'",message="podDelete is only allowed for type pod-delete"
+// +kubebuilder:validation:XValidation:rule="!has(self.networkDelay) || self.type == 'network-delay'",message="networkDelay is only allowed for type network-delay"
+// +kubebuilder:validation:XValidation:rule="!has(self.networkLoss) || self.type == 'network-loss'",message="networkLoss is only allowed for type network-loss"
+// +kubebuilder:validation:XValidation:rule="!has(self.networkPartition) || self.type == 'network-partition'",message="networkPartition is only allowed for type network-partition"
+// +kubebuilder:validation:XValidation:rule="!has(self.networkCorrupt) || self.type == 'network-corrupt'",message="networkCorrupt is only allowed for type network-corrupt"
+// +kubebuilder:validation:XValidation:rule="!has(self.networkDuplicate) || self.type == 'network-duplicate'",message="networkDuplicate is only allowed for type network-duplicate"
+// +kubebuilder:validation:XValidation:rule="!has(self.networkBandwidth) || self.type == 'network-bandwidth'",message="networkBandwidth is only allowed for type network-bandwidth"
+// +kubebuilder:validation:XValidation:rule="!has(self.stress) || self.type in ['cpu-stress', 'memory-stress']",message="stress is only allowed for types cpu-stress and memory-stress"
+// +kubebuilder:validation:XValidation:rule="!has(self.io) || self.type in ['io-latency', 'io-fault', 'io-attr-override']",message="io is only allowed for types io-latency, io-fault and io-attr-override"
+// +kubebuilder:validation:XValidation:rule="!has(self.timeSkew) || self.type == 'time-skew'",message="timeSkew is only allowed for type time-skew"
+// +kubebuilder:validation:XValidation:rule="!has(self.dns) || self.type in ['dns-error', 'dns-random']",message="dns is only allowed for types dns-error and dns-random"
+// +kubebuilder:validation:XValidation:rule="!has(self.nodeDrain) || self.type == 'node-drain'",message="nodeDrain is only allowed for type node-drain"
+// +kubebuilder:validation:XValidation:rule="!has(self.mode) || !(self.mode in ['fixed', 'fixed-percent', 'random-max-percent']) || has(self.value)",message="value is required for modes fixed, fixed-percent and random-max-percent"
+// +kubebuilder:validation:XValidation:rule="!has(self.value) || (has(self.mode) && self.mode in ['fixed', 'fixed-percent', 'random-max-percent'])",message="value is only allowed for modes fixed, fixed-percent and random-max-percent"
+// +kubebuilder:validation:XValidation:rule="!has(self.mode) || self.type != 'scale-to-zero'",message="mode is not allowed for type scale-to-zero"
+type ChaosSpec struct {
+	// Type is the chaos action to inject
+	// +kubebuilder:validation:Enum=pod-delete;network-delay;network-loss;network-partition;network-corrupt;network-duplicate;network-bandwidth;cpu-stress;memory-stress;io-latency;io-fault;io-attr-override;time-skew;dns-error;dns-random;scale-to-zero;node-drain;pvc-delete
+	Type string `json:"type"`
+	// Provider names a registered chaos provider (e.g. chaos-mesh, litmus).
+	// Defaults to the operator's --default-chaos-provider.
+	// +optional
+	Provider string `json:"provider,omitempty"`
+	// Mode selects which of the app's pods are targeted: one random pod, all
+	// of them, a fixed number, a fixed percentage, or a random percentage up
+	// to Value. Defaults to one for pod-delete and all for the other types.
+	// +kubebuilder:validation:Enum=one;all;fixed;fixed-percent;random-max-percent
+	// +optional
+	Mode string `json:"mode,omitempty"`
+	// Value is the pod count for mode fixed, or the percentage for modes
+	// fixed-percent and random-max-percent
+	// +kubebuilder:validation:Pattern=`^[0-9]+$`
+	// +optional
+	Value string `json:"value,omitempty"`
+	// MaxAffectedPods caps the blast radius: the test fails before injecting
+	// chaos if the mode would target more of the app's pods than this. For
+	// node-drain it caps every pod evicted from the drained nodes, including
+	// those of other apps
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxAffectedPods int `json:"maxAffectedPods,omitempty"`
+	// PodDelete configures pod-delete
+	// +optional
+	PodDelete *PodDeleteSpec `json:"podDelete,omitempty"`
+	// NetworkDelay configures network-delay
+	// +optional
+	NetworkDelay *NetworkDelaySpec `json:"networkDelay,omitempty"`
+	// NetworkLoss configures network-loss
+	// +optional
+	NetworkLoss *NetworkLossSpec `json:"networkLoss,omitempty"`
+	// NetworkPartition configures network-partition
+	// +optional
+	NetworkPartition *NetworkPartitionSpec `json:"networkPartition,omitempty"`
+	// NetworkCorrupt configures network-corrupt
+	// +optional
+	NetworkCorrupt *NetworkCorruptSpec `json:"networkCorrupt,omitempty"`
+	// NetworkDuplicate configures network-duplicate
+	// +optional
+	NetworkDuplicate *NetworkDuplicateSpec `json:"networkDuplicate,omitempty"`
+	// NetworkBandwidth configures network-bandwidth
+	// +optional
+	NetworkBandwidth *NetworkBandwidthSpec `json:"networkBandwidth,omitempty"`
+	// Stress configures cpu-stress and memory-stress
+	// +optional
+	Stress *StressSpec `json:"stress,omitempty"`
+	// IO configures io-latency, io-fault and io-attr-override
+	// +optional
+	IO *IOSpec `json:"io,omitempty"`
+	// TimeSkew configures time-skew
+	// +optional
+	TimeSkew *TimeSkewSpec `json:"timeSkew,omitempty"`
+	// DNS configures dns-error and dns-random
+	// +optional
+	DNS *DNSSpec `json:"dns,omitempty"`
+	// NodeDrain configures node-drain
+	// +optional
+	NodeDrain *NodeDrainSpec `json:"nodeDrain,omitempty"`
+}
+
+// PodDeleteSpec configures the deletion of a selected pod
+type PodDeleteSpec struct {
+	// GracePeriod is the pod's termination grace period in seconds; 0 kills it immediately
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	GracePeriod int64 `json:"gracePeriod,omitempty"`
+}
+
+// NetworkDelaySpec delays the traffic of the selected pods
+type NetworkDelaySpec struct {
+	// Latency is the delay added to every packet, e.g. 100ms
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?(ns|us|ms|s|m|h)$`
+	Latency string `json:"latency"`
+	// Jitter is the random variation of the latency, e.g. 10ms
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?(ns|us|ms|s|m|h)$`
+	// +optional
+	Jitter string `json:"jitter,omitempty"`
+	// Correlation is how much, in percent, each delay depends on the previous one
+	// +kubebuilder:validation:Pattern=`^(100|[0-9]{1,2})(\.[0-9]+)?$`
+	// +optional
+	Correlation string `json:"correlation,omitempty"`
+}
+
+// NetworkLossSpec drops part of the traffic of the selected pods
+type NetworkLossSpec struct {
+	// Loss is the percentage of packets dropped, e.g. 25
+	// +kubebuilder:validation:Pattern=`^(100|[0-9]{1,2})(\.[0-9]+)?$`
+	Loss string `json:"loss"`
+	// Correlation is how much, in percent, each drop depends on the previous one
+	// +kubebuilder:validation:Pattern=`^(100|[0-9]{1,2})(\.[0-9]+)?$`
+	// +optional
+	Correlation string `json:"correlation,omitempty"`
+}
+
+// NetworkPartitionSpec cuts the selected pods off from a target
+// +kubebuilder:validation:XValidation:rule="has(self.target) || has(self.externalTargets)",message="one of target or externalTargets is required"
+type NetworkPartitionSpec struct {
+	// Direction is the traffic that is blocked: to the target, from it, or both
+	// +kubebuilder:validation:Enum=to;from;both
+	// +kubebuilder:default=both
+	// +optional
+	Direction string `json:"direction,omitempty"`
+	// Target selects the pods the app is partitioned from
+	// +optional
+	Target *NetworkTarget `json:"target,omitempty"`
+	// ExternalTargets are hosts or CIDRs outside the cluster the app is partitioned from
+	// +optional
+	ExternalTargets []string `json:"externalTargets,omitempty"`
+}
+
+// NetworkTarget selects the pods on the other side of a network fault
+type NetworkTarget struct {
+	// Selector matches the labels of the target pods
+	// +kubebuilder:validation:MinProperties=1
+	Selector map[string]string `json:"selector"`
+	// Namespace of the target pods; defaults to the test's namespace
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// NetworkCorruptSpec corrupts part of the traffic of the selected pods
+type NetworkCorruptSpec struct {
+	// Corrupt is the percentage of packets corrupted, e.g. 10
+	// +kubebuilder:validation:Pattern=`^(100|[0-9]{1,2})(\.[0-9]+)?$`
+	Corrupt string `json:"corrupt"`
+	// Correlation is how much, in percent, each corruption depends on the previous one
+	// +kubebuilder:validation:Pattern=`^(100|[0-9]{1,2})(\.[0-9]+)?$`
+	// +optional
+	Correlation string `json:"correlation,omitempty"`
+}
+
+// NetworkDuplicateSpec duplicates part of the traffic of the selected pods
+type NetworkDuplicateSpec struct {
+	// Duplicate is the percentage of packets sent twice, e.g. 10
+	// +kubebuilder:validation:Pattern=`^(100|[0-9]{1,2})(\.[0-9]+)?$`
+	Duplicate string `json:"duplicate"`
+	// Correlation is how much, in percent, each duplication depends on the previous one
+	// +kubebuilder:validation:Pattern=`^(100|[0-9]{1,2})(\.[0-9]+)?$`
+	// +optional
+	Correlation string `json:"correlation,omitempty"`
+}
+
+// NetworkBandwidthSpec limits the bandwidth of the selected pods with a token bucket
+type NetworkBandwidthSpec struct {
+	// Rate is the bandwidth allowed, in bps, kbps, mbps, gbps or tbps (bytes per second)
+	// +kubebuilder:validation:Pattern=`^[0-9]+(bps|kbps|mbps|gbps|tbps)$`
+	Rate string `json:"rate"`
+	// Limit is the number of bytes that can be queued waiting for tokens
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=20971520
+	// +optional
+	Limit uint32 `json:"limit,omitempty"`
+	// Buffer is the most bytes that tokens can be available for at once
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=10000
+	// +optional
+	Buffer uint32 `json:"buffer,omitempty"`
+	// Peakrate is the maximum depletion rate of the bucket
+	// +optional
+	Peakrate *uint64 `json:"peakrate,omitempty"`
+	// Minburst is the size of the peakrate bucket, usually the interface MTU
+	// +optional
+	Minburst *uint32 `json:"minburst,omitempty"`
+}
+
+// StressSpec burns CPU or memory in the selected pods
+type StressSpec struct {
+	// Workers is the number of stressor processes per container
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=8192
+	// +kubebuilder:default=1
+	// +optional
+	Workers int `json:"workers,omitempty"`
+	// Load is the percentage each CPU worker keeps busy (cpu-stress only)
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Load *int `json:"load,omitempty"`
+	// MemorySize is the memory each worker allocates, e.g. 256MB or 50% of the
+	// available memory (memory-stress only)
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?(%|B|KB|KiB|MB|MiB|GB|GiB|TB|TiB)?$`
+	// +optional
+	MemorySize string `json:"memorySize,omitempty"`
+}
+
+// IOSpec injects faults into the filesystem calls the selected pods make on a
+// volume. The volume is resolved from the PVCs mounted by the selected pods.
+type IOSpec struct {
+	// Volume is the PVC whose mount is injected; required when the selected
+	// pods mount more than one PVC
+	// +optional
+	Volume string `json:"volume,omitempty"`
+	// VolumePath is the mount path to inject, overriding the path resolved from the PVCs
+	// +optional
+	VolumePath string `json:"volumePath,omitempty"`
+	// Path is a glob of the files injected under the volume, e.g. /data/**/*.rdb;
+	// every file when empty
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Methods limits the injection to these filesystem calls; every call when empty
+	// +kubebuilder:validation:items:Enum=lookup;forget;getattr;setattr;readlink;mknod;mkdir;unlink;rmdir;symlink;rename;link;open;read;write;flush;release;fsync;opendir;readdir;releasedir;fsyncdir;statfs;setxattr;getxattr;listxattr;removexattr;access;create;getlk;setlk;bmap
+	// +optional
+	Methods []string `json:"methods,omitempty"`
+	// Percent is the probability, in percent, that a matching call is injected
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=100
+	// +optional
+	Percent int `json:"percent,omitempty"`
+	// Delay is added to every injected call, e.g. 100ms (io-latency only)
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?(ns|us|ms|s|m|h)$`
+	// +optional
+	Delay string `json:"delay,omitempty"`
+	// Errno is returned by every injected call, e.g. 5 (EIO) or 28 (ENOSPC) (io-fault only)
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Errno uint32 `json:"errno,omitempty"`
+	// Attr replaces the attributes of the injected files (io-attr-override only)
+	// +optional
+	Attr *IOAttrOverrideSpec `json:"attr,omitempty"`
+}
+
+// IOAttrOverrideSpec lists the file attributes io-attr-override reports
+// instead of the real ones
+// +kubebuilder:validation:MinProperties=1
+type IOAttrOverrideSpec struct {
+	// Perm is the permission bits, e.g. 292 (0444) for read-only files
+	// +optional
+	Perm *uint16 `json:"perm,omitempty"`
+	// Size is the file size in bytes
+	// +optional
+	Size *uint64 `json:"size,omitempty"`
+	// Blocks is the number of blocks allocated to the file
+	// +optional
+	Blocks *uint64 `json:"blocks,omitempty"`
+	// Nlink is the number of hard links
+	// +optional
+	Nlink *uint32 `json:"nlink,omitempty"`
+	// UID is the owner's user ID
+	// +optional
+	UID *uint32 `json:"uid,omitempty"`
+	// GID is the owner's group ID
+	// +optional
+	GID *uint32 `json:"gid,omitempty"`
+	// Kind is the file type
+	// +kubebuilder:validation:Enum=namedPipe;charDevice;blockDevice;directory;regularFile;symlink;socket
+	// +optional
+	Kind string `json:"kind,omitempty"`
+}
+
+// TimeSkewSpec shifts the clocks the selected pods read
+type TimeSkewSpec struct {
+	// Offset is added to the clocks, e.g. -10m or 2h45m; negative offsets move them back
+	// +kubebuilder:validation:Pattern=`^-?([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+	Offset string `json:"offset"`
+	// ClockIDs are the clocks shifted; CLOCK_REALTIME when empty
+	// +kubebuilder:validation:items:Enum=CLOCK_REALTIME;CLOCK_MONOTONIC;CLOCK_PROCESS_CPUTIME_ID;CLOCK_THREAD_CPUTIME_ID;CLOCK_MONOTONIC_RAW;CLOCK_REALTIME_COARSE;CLOCK_MONOTONIC_COARSE;CLOCK_BOOTTIME;CLOCK_REALTIME_ALARM;CLOCK_BOOTTIME_ALARM
+	// +optional
+	ClockIDs []string `json:"clockIds,omitempty"`
+}
+
+// DNSSpec makes the DNS lookups of the selected pods fail (dns-error) or
+// return random addresses (dns-random). It needs Chaos Mesh's DNS server.
+type DNSSpec struct {
+	// Patterns are the domain names affected, e.g. postgres.*; ? matches one
+	// character and a trailing * any suffix. Every domain when empty
+	// +kubebuilder:validation:items:Pattern=`^[A-Za-z0-9?.-]*\*?$`
+	// +optional
+	Patterns []string `json:"patterns,omitempty"`
+}
+
+// NodeDrainSpec cordons and drains the nodes running the selected pods
+type NodeDrainSpec struct {
+	// MaxNodes is the most nodes drained at once
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	MaxNodes int `json:"maxNodes,omitempty"`
+}
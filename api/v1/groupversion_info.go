@@ -1,3 +1,6 @@
+// Package v1 contains API Schema definitions for the chaosdr v1 API group
+// +kubebuilder:object:generate=true
+// +groupName=chaosdr.io
 package v1
 
 import (
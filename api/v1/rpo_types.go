@@ -0,0 +1,99 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionRPOMet is True when the data-loss window measured by the RPO probe
+// is within its maxDataLoss
+const ConditionRPOMet = "RPOMet"
+
+// RPOProbeSpec writes a timestamped marker to the app at every interval from
+// the start of the test until the chaos ends. The chaos is the failure: after
+// the restore, the newest marker that survived measures the data-loss window,
+// the time between it and the newest marker written before the chaos was
+// injected. Exactly one target is set.
+// +kubebuilder:validation:XValidation:rule="[has(self.http), has(self.sql), has(self.redis)].filter(x, x).size() == 1",message="the rpo probe needs exactly one of http, sql or redis"
+type RPOProbeSpec struct {
+	// Interval between markers
+	// +kubebuilder:default="5s"
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// MaxDataLoss fails the test when the data-loss window is longer
+	// +optional
+	MaxDataLoss *metav1.Duration `json:"maxDataLoss,omitempty"`
+	// +optional
+	HTTP *RPOHTTPTarget `json:"http,omitempty"`
+	// +optional
+	SQL *RPOSQLTarget `json:"sql,omitempty"`
+	// +optional
+	Redis *RPORedisTarget `json:"redis,omitempty"`
+}
+
+// RPOHTTPTarget stores markers through the app's own API
+type RPOHTTPTarget struct {
+	// URL receives each marker as a POST of {"test": "<name>", "marker": "<RFC 3339 time>"}
+	URL string `json:"url"`
+	// ReadURL answers a GET with the stored markers. Its host is moved to the
+	// sandbox namespace as for the connection strings of database checks.
+	ReadURL string `json:"readURL"`
+	// JSONPath selects the RFC 3339 times of the markers from the ReadURL response
+	// +kubebuilder:default="{.marker}"
+	// +optional
+	JSONPath string `json:"jsonPath,omitempty"`
+	// Headers are added to both requests
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// RPOSQLTarget inserts markers into a table with the columns test and
+// written_at (Unix seconds), which is created if it does not exist. The
+// connection string is handled as for sql checks.
+type RPOSQLTarget struct {
+	// +kubebuilder:validation:Enum=mysql;postgres
+	// +kubebuilder:default=mysql
+	// +optional
+	Engine              string                   `json:"engine,omitempty"`
+	ConnectionSecretRef corev1.SecretKeySelector `json:"connectionSecretRef"`
+	// +kubebuilder:validation:Pattern=`^[A-Za-z_][A-Za-z0-9_$]*(\.[A-Za-z_][A-Za-z0-9_$]*)?$`
+	// +kubebuilder:default="chaosdr_rpo_markers"
+	// +optional
+	Table string `json:"table,omitempty"`
+}
+
+// RPORedisTarget sets a key to the time of the newest marker. The connection
+// string is handled as for redis checks.
+type RPORedisTarget struct {
+	ConnectionSecretRef corev1.SecretKeySelector `json:"connectionSecretRef"`
+	// Key defaults to chaosdr:rpo:<test name>
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// RPOStatus reports the markers of the RPO probe and the data-loss window
+// they measured
+type RPOStatus struct {
+	MarkersWritten int32 `json:"markersWritten"`
+	// FirstMarker and LastMarker are the times of the first and newest marker written
+	// +optional
+	FirstMarker *metav1.Time `json:"firstMarker,omitempty"`
+	// +optional
+	LastMarker *metav1.Time `json:"lastMarker,omitempty"`
+	// FailureMarker is the newest marker written before the chaos was injected
+	// +optional
+	FailureMarker *metav1.Time `json:"failureMarker,omitempty"`
+	// WriteErrors counts the markers that could not be written
+	// +optional
+	WriteErrors int32 `json:"writeErrors,omitempty"`
+	// LastError is the error of the last marker that could not be written
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+	// NewestRestored is the newest marker found in the restore
+	// +optional
+	NewestRestored *metav1.Time `json:"newestRestored,omitempty"`
+	// DataLoss is the time between FailureMarker and NewestRestored, or
+	// between FirstMarker and FailureMarker when no marker survived
+	// +optional
+	DataLoss *metav1.Duration `json:"dataLoss,omitempty"`
+}
@@ -0,0 +1,69 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ChaosTypeScenario is recorded as the status chaosType of tests running spec.scenario
+const ChaosTypeScenario = "scenario"
+
+// Phases of a scenario step
+const (
+	StepPending   = "Pending"
+	StepRunning   = "Running"
+	StepCompleted = "Completed"
+	StepFailed    = "Failed"
+)
+
+// ScenarioStep is one step of a chaos scenario. It injects one chaos, injects
+// a parallel group of chaos together, or waits.
+// +kubebuilder:validation:XValidation:rule="[has(self.chaos), has(self.parallel), has(self.wait)].filter(x, x).size() == 1",message="a step needs exactly one of chaos, parallel or wait"
+// +kubebuilder:validation:XValidation:rule="!has(self.wait) || !has(self.duration)",message="duration is not allowed on a wait step"
+type ScenarioStep struct {
+	// Name identifies the step in the status and in the names of the chaos
+	// objects it creates. Defaults to step-<n> for the n-th step.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=40
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Chaos is injected for the step's duration
+	// +optional
+	Chaos *ChaosSpec `json:"chaos,omitempty"`
+	// Parallel chaos are injected together; the step ends once all of them
+	// have run for its duration
+	// +kubebuilder:validation:MinItems=2
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	Parallel []ChaosSpec `json:"parallel,omitempty"`
+	// Wait pauses the scenario before the next step
+	// +optional
+	Wait *metav1.Duration `json:"wait,omitempty"`
+	// Duration is how long the chaos of the step runs. Defaults to spec.chaosDuration.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// ScenarioStepStatus tracks one step of spec.scenario
+type ScenarioStepStatus struct {
+	Name string `json:"name"`
+	// Phase is Pending, Running, Completed or Failed
+	// +kubebuilder:validation:Enum=Pending;Running;Completed;Failed
+	Phase          string       `json:"phase"`
+	StartTime      *metav1.Time `json:"startTime,omitempty"`
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Chaos lists the chaos the step injected
+	// +optional
+	Chaos   []ScenarioChaosStatus `json:"chaos,omitempty"`
+	Message string                `json:"message,omitempty"`
+}
+
+// ScenarioChaosStatus tracks one chaos injected by a scenario step
+type ScenarioChaosStatus struct {
+	// Name is the name of the chaos object
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Provider string `json:"provider"`
+	// Status mirrors the experiment records of the chaos
+	// +optional
+	Status *ChaosExperimentStatus `json:"status,omitempty"`
+}
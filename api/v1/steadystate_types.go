@@ -0,0 +1,129 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionSteadyState is True while the steady-state probes of a test pass
+const ConditionSteadyState = "SteadyState"
+
+// Comparators a Prometheus probe applies between its query result and threshold
+const (
+	ComparatorLess         = "<"
+	ComparatorLessEqual    = "<="
+	ComparatorGreater      = ">"
+	ComparatorGreaterEqual = ">="
+	ComparatorEqual        = "=="
+	ComparatorNotEqual     = "!="
+)
+
+// SteadyStateSpec describes the probes that define the app's healthy state.
+// They run once before the backup to confirm the baseline, and then every
+// interval while chaos is injected.
+type SteadyStateSpec struct {
+	// Probes all have to pass for the app to be in its steady state
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=20
+	Probes []ProbeSpec `json:"probes"`
+	// Interval is how often the probes run while chaos is injected
+	// +kubebuilder:default="10s"
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// MaxSamples caps the samples kept per probe in the status; the oldest
+	// samples are dropped first. Samples of every probe are kept in the
+	// object, so the cap is low enough for 20 probes to fit.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=60
+	// +optional
+	MaxSamples int `json:"maxSamples,omitempty"`
+}
+
+// ProbeSpec is one steady-state check. Exactly one of its members is set.
+// +kubebuilder:validation:XValidation:rule="[has(self.http), has(self.tcp), has(self.prometheus), has(self.command)].filter(x, x).size() == 1",message="a probe needs exactly one of http, tcp, prometheus or command"
+type ProbeSpec struct {
+	// Name identifies the probe in the status
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+	// Timeout bounds a single run of the probe
+	// +kubebuilder:default="5s"
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// HTTP passes when a request returns the expected status code
+	// +optional
+	HTTP *HTTPProbe `json:"http,omitempty"`
+	// TCP passes when a connection can be opened
+	// +optional
+	TCP *TCPProbe `json:"tcp,omitempty"`
+	// Prometheus passes when a query result compares true against a threshold
+	// +optional
+	Prometheus *PrometheusProbe `json:"prometheus,omitempty"`
+	// Command passes when a shell command run by the operator exits with 0
+	// +optional
+	Command *CommandProbe `json:"command,omitempty"`
+}
+
+// HTTPProbe sends a request to the app
+type HTTPProbe struct {
+	// URL is requested, e.g. http://redis-exporter.default:9121/health
+	URL string `json:"url"`
+	// +kubebuilder:validation:Enum=GET;HEAD;POST
+	// +kubebuilder:default=GET
+	// +optional
+	Method string `json:"method,omitempty"`
+	// +kubebuilder:validation:Minimum=100
+	// +kubebuilder:validation:Maximum=599
+	// +kubebuilder:default=200
+	// +optional
+	ExpectedStatus int `json:"expectedStatus,omitempty"`
+}
+
+// TCPProbe opens a connection to the app
+type TCPProbe struct {
+	// Address is the host:port dialed, e.g. redis.default:6379
+	Address string `json:"address"`
+}
+
+// PrometheusProbe evaluates an instant query
+type PrometheusProbe struct {
+	// URL is the Prometheus server, e.g. http://prometheus.monitoring:9090
+	URL string `json:"url"`
+	// Query must return a scalar or a vector; its first sample is compared
+	Query string `json:"query"`
+	// Comparator is applied as <result> <comparator> <threshold>
+	// +kubebuilder:validation:Enum="<";"<=";">";">=";"==";"!="
+	Comparator string `json:"comparator"`
+	// Threshold is a decimal number, e.g. 0.05
+	// +kubebuilder:validation:Pattern=`^-?[0-9]+(\.[0-9]+)?$`
+	Threshold string `json:"threshold"`
+}
+
+// CommandProbe runs a shell command in the operator's container, with the
+// operator's service account. It is refused unless the operator runs with
+// --allow-command-probes.
+type CommandProbe struct {
+	// Command is run with /bin/sh -c
+	Command string `json:"command"`
+}
+
+// ProbeStatus is the time series of one steady-state probe
+type ProbeStatus struct {
+	Name string `json:"name"`
+	// Samples are the probe's results, oldest first
+	// +optional
+	Samples []ProbeSample `json:"samples,omitempty"`
+}
+
+// ProbeSample is the result of one run of a probe
+type ProbeSample struct {
+	Time metav1.Time `json:"time"`
+	// Phase is the test phase the probe ran in: Pending for the baseline,
+	// InjectingChaos during chaos
+	Phase  ChaosDRTestPhase `json:"phase"`
+	Passed bool             `json:"passed"`
+	// Value is what the probe observed: the status code, the query result,
+	// the connect latency or the exit code
+	Value   string `json:"value,omitempty"`
+	Message string `json:"message,omitempty"`
+}
@@ -1,24 +1,106 @@
+//go:build !ignore_autogenerated
+
 // Code generated by controller-gen. DO NOT EDIT.
+
 package v1
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out.
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AbortCondition) DeepCopyInto(out *AbortCondition) {
+	*out = *in
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(PrometheusProbe)
+		**out = **in
+	}
+	if in.HTTPErrorRate != nil {
+		in, out := &in.HTTPErrorRate, &out.HTTPErrorRate
+		*out = new(HTTPErrorRateCondition)
+		**out = **in
+	}
+	if in.MinReadyPods != nil {
+		in, out := &in.MinReadyPods, &out.MinReadyPods
+		*out = new(MinReadyPodsCondition)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AbortCondition.
+func (in *AbortCondition) DeepCopy() *AbortCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(AbortCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AbortStatus) DeepCopyInto(out *AbortStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AbortStatus.
+func (in *AbortStatus) DeepCopy() *AbortStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AbortStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupSpec) DeepCopyInto(out *BackupSpec) {
+	*out = *in
+	if in.Restic != nil {
+		in, out := &in.Restic, &out.Restic
+		*out = new(ResticSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSpec.
+func (in *BackupSpec) DeepCopy() *BackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BodyAssertion) DeepCopyInto(out *BodyAssertion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BodyAssertion.
+func (in *BodyAssertion) DeepCopy() *BodyAssertion {
+	if in == nil {
+		return nil
+	}
+	out := new(BodyAssertion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChaosDRTest) DeepCopyInto(out *ChaosDRTest) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta) // Correctly copy ObjectMeta
-	if in.Spec.AppSelector != nil {
-		in, out := &in.Spec.AppSelector, &out.Spec.AppSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	out.Spec.ChaosType = in.Spec.ChaosType
-	out.Spec.ValidationScript = in.Spec.ValidationScript
-	out.Status = in.Status
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, creating a new ChaosDRTest.
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaosDRTest.
 func (in *ChaosDRTest) DeepCopy() *ChaosDRTest {
 	if in == nil {
 		return nil
@@ -28,11 +110,19 @@ func (in *ChaosDRTest) DeepCopy() *ChaosDRTest {
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out.
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ChaosDRTest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChaosDRTestList) DeepCopyInto(out *ChaosDRTestList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta) // Correctly copy ListMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
 		*out = make([]ChaosDRTest, len(*in))
@@ -42,7 +132,7 @@ func (in *ChaosDRTestList) DeepCopyInto(out *ChaosDRTestList) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, creating a new ChaosDRTestList.
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaosDRTestList.
 func (in *ChaosDRTestList) DeepCopy() *ChaosDRTestList {
 	if in == nil {
 		return nil
@@ -51,3 +141,1477 @@ func (in *ChaosDRTestList) DeepCopy() *ChaosDRTestList {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ChaosDRTestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChaosDRTestSpec) DeepCopyInto(out *ChaosDRTestSpec) {
+	*out = *in
+	if in.AppSelector != nil {
+		in, out := &in.AppSelector, &out.AppSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Chaos != nil {
+		in, out := &in.Chaos, &out.Chaos
+		*out = new(ChaosSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scenario != nil {
+		in, out := &in.Scenario, &out.Scenario
+		*out = make([]ScenarioStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ChaosParameters != nil {
+		in, out := &in.ChaosParameters, &out.ChaosParameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ChaosDuration != nil {
+		in, out := &in.ChaosDuration, &out.ChaosDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	in.ValidationConfig.DeepCopyInto(&out.ValidationConfig)
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(BackupSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SteadyState != nil {
+		in, out := &in.SteadyState, &out.SteadyState
+		*out = new(SteadyStateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AbortConditions != nil {
+		in, out := &in.AbortConditions, &out.AbortConditions
+		*out = make([]AbortCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RPOProbe != nil {
+		in, out := &in.RPOProbe, &out.RPOProbe
+		*out = new(RPOProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaosDRTestSpec.
+func (in *ChaosDRTestSpec) DeepCopy() *ChaosDRTestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ChaosDRTestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChaosDRTestStatus) DeepCopyInto(out *ChaosDRTestStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PhaseStartTime != nil {
+		in, out := &in.PhaseStartTime, &out.PhaseStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PhaseTimings != nil {
+		in, out := &in.PhaseTimings, &out.PhaseTimings
+		*out = make([]PhaseTiming, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(OperationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Restore != nil {
+		in, out := &in.Restore, &out.Restore
+		*out = new(OperationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Chaos != nil {
+		in, out := &in.Chaos, &out.Chaos
+		*out = new(ChaosExperimentStatus)
+		**out = **in
+	}
+	if in.Scenario != nil {
+		in, out := &in.Scenario, &out.Scenario
+		*out = make([]ScenarioStepStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SteadyState != nil {
+		in, out := &in.SteadyState, &out.SteadyState
+		*out = make([]ProbeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Abort != nil {
+		in, out := &in.Abort, &out.Abort
+		*out = new(AbortStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RPO != nil {
+		in, out := &in.RPO, &out.RPO
+		*out = new(RPOStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Validation != nil {
+		in, out := &in.Validation, &out.Validation
+		*out = make([]ValidationResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaosDRTestStatus.
+func (in *ChaosDRTestStatus) DeepCopy() *ChaosDRTestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ChaosDRTestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChaosExperimentStatus) DeepCopyInto(out *ChaosExperimentStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaosExperimentStatus.
+func (in *ChaosExperimentStatus) DeepCopy() *ChaosExperimentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ChaosExperimentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChaosSpec) DeepCopyInto(out *ChaosSpec) {
+	*out = *in
+	if in.PodDelete != nil {
+		in, out := &in.PodDelete, &out.PodDelete
+		*out = new(PodDeleteSpec)
+		**out = **in
+	}
+	if in.NetworkDelay != nil {
+		in, out := &in.NetworkDelay, &out.NetworkDelay
+		*out = new(NetworkDelaySpec)
+		**out = **in
+	}
+	if in.NetworkLoss != nil {
+		in, out := &in.NetworkLoss, &out.NetworkLoss
+		*out = new(NetworkLossSpec)
+		**out = **in
+	}
+	if in.NetworkPartition != nil {
+		in, out := &in.NetworkPartition, &out.NetworkPartition
+		*out = new(NetworkPartitionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkCorrupt != nil {
+		in, out := &in.NetworkCorrupt, &out.NetworkCorrupt
+		*out = new(NetworkCorruptSpec)
+		**out = **in
+	}
+	if in.NetworkDuplicate != nil {
+		in, out := &in.NetworkDuplicate, &out.NetworkDuplicate
+		*out = new(NetworkDuplicateSpec)
+		**out = **in
+	}
+	if in.NetworkBandwidth != nil {
+		in, out := &in.NetworkBandwidth, &out.NetworkBandwidth
+		*out = new(NetworkBandwidthSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Stress != nil {
+		in, out := &in.Stress, &out.Stress
+		*out = new(StressSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IO != nil {
+		in, out := &in.IO, &out.IO
+		*out = new(IOSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TimeSkew != nil {
+		in, out := &in.TimeSkew, &out.TimeSkew
+		*out = new(TimeSkewSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(DNSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeDrain != nil {
+		in, out := &in.NodeDrain, &out.NodeDrain
+		*out = new(NodeDrainSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaosSpec.
+func (in *ChaosSpec) DeepCopy() *ChaosSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ChaosSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommandProbe) DeepCopyInto(out *CommandProbe) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommandProbe.
+func (in *CommandProbe) DeepCopy() *CommandProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(CommandProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSSpec) DeepCopyInto(out *DNSSpec) {
+	*out = *in
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSpec.
+func (in *DNSSpec) DeepCopy() *DNSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseQuery) DeepCopyInto(out *DatabaseQuery) {
+	*out = *in
+	if in.ConnectionSecretRef != nil {
+		in, out := &in.ConnectionSecretRef, &out.ConnectionSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExpectedScalar != nil {
+		in, out := &in.ExpectedScalar, &out.ExpectedScalar
+		*out = new(string)
+		**out = **in
+	}
+	if in.ExpectedRowSet != nil {
+		in, out := &in.ExpectedRowSet, &out.ExpectedRowSet
+		*out = make([][]string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseQuery.
+func (in *DatabaseQuery) DeepCopy() *DatabaseQuery {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseQuery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FingerprintCheck) DeepCopyInto(out *FingerprintCheck) {
+	*out = *in
+	if in.SQL != nil {
+		in, out := &in.SQL, &out.SQL
+		*out = new(SQLFingerprint)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(RedisFingerprint)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PVC != nil {
+		in, out := &in.PVC, &out.PVC
+		*out = new(PVCFingerprint)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FingerprintCheck.
+func (in *FingerprintCheck) DeepCopy() *FingerprintCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(FingerprintCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FingerprintDiff) DeepCopyInto(out *FingerprintDiff) {
+	*out = *in
+	if in.MissingEntities != nil {
+		in, out := &in.MissingEntities, &out.MissingEntities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ChangedEntities != nil {
+		in, out := &in.ChangedEntities, &out.ChangedEntities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FingerprintDiff.
+func (in *FingerprintDiff) DeepCopy() *FingerprintDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(FingerprintDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPCheck) DeepCopyInto(out *HTTPCheck) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Assertions != nil {
+		in, out := &in.Assertions, &out.Assertions
+		*out = make([]BodyAssertion, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxLatency != nil {
+		in, out := &in.MaxLatency, &out.MaxLatency
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Eventually != nil {
+		in, out := &in.Eventually, &out.Eventually
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(HTTPTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPCheck.
+func (in *HTTPCheck) DeepCopy() *HTTPCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPErrorRateCondition) DeepCopyInto(out *HTTPErrorRateCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPErrorRateCondition.
+func (in *HTTPErrorRateCondition) DeepCopy() *HTTPErrorRateCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPErrorRateCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProbe) DeepCopyInto(out *HTTPProbe) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProbe.
+func (in *HTTPProbe) DeepCopy() *HTTPProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPTLSConfig) DeepCopyInto(out *HTTPTLSConfig) {
+	*out = *in
+	if in.CASecretRef != nil {
+		in, out := &in.CASecretRef, &out.CASecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientCertSecretRef != nil {
+		in, out := &in.ClientCertSecretRef, &out.ClientCertSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPTLSConfig.
+func (in *HTTPTLSConfig) DeepCopy() *HTTPTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IOAttrOverrideSpec) DeepCopyInto(out *IOAttrOverrideSpec) {
+	*out = *in
+	if in.Perm != nil {
+		in, out := &in.Perm, &out.Perm
+		*out = new(uint16)
+		**out = **in
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.Blocks != nil {
+		in, out := &in.Blocks, &out.Blocks
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.Nlink != nil {
+		in, out := &in.Nlink, &out.Nlink
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.UID != nil {
+		in, out := &in.UID, &out.UID
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.GID != nil {
+		in, out := &in.GID, &out.GID
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IOAttrOverrideSpec.
+func (in *IOAttrOverrideSpec) DeepCopy() *IOAttrOverrideSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IOAttrOverrideSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IOSpec) DeepCopyInto(out *IOSpec) {
+	*out = *in
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Attr != nil {
+		in, out := &in.Attr, &out.Attr
+		*out = new(IOAttrOverrideSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IOSpec.
+func (in *IOSpec) DeepCopy() *IOSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IOSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MinReadyPodsCondition) DeepCopyInto(out *MinReadyPodsCondition) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MinReadyPodsCondition.
+func (in *MinReadyPodsCondition) DeepCopy() *MinReadyPodsCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(MinReadyPodsCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBCheck) DeepCopyInto(out *MongoDBCheck) {
+	*out = *in
+	in.ConnectionSecretRef.DeepCopyInto(&out.ConnectionSecretRef)
+	if in.ExpectedCount != nil {
+		in, out := &in.ExpectedCount, &out.ExpectedCount
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBCheck.
+func (in *MongoDBCheck) DeepCopy() *MongoDBCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkBandwidthSpec) DeepCopyInto(out *NetworkBandwidthSpec) {
+	*out = *in
+	if in.Peakrate != nil {
+		in, out := &in.Peakrate, &out.Peakrate
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.Minburst != nil {
+		in, out := &in.Minburst, &out.Minburst
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkBandwidthSpec.
+func (in *NetworkBandwidthSpec) DeepCopy() *NetworkBandwidthSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkBandwidthSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkCorruptSpec) DeepCopyInto(out *NetworkCorruptSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkCorruptSpec.
+func (in *NetworkCorruptSpec) DeepCopy() *NetworkCorruptSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkCorruptSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkDelaySpec) DeepCopyInto(out *NetworkDelaySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkDelaySpec.
+func (in *NetworkDelaySpec) DeepCopy() *NetworkDelaySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkDelaySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkDuplicateSpec) DeepCopyInto(out *NetworkDuplicateSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkDuplicateSpec.
+func (in *NetworkDuplicateSpec) DeepCopy() *NetworkDuplicateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkDuplicateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkLossSpec) DeepCopyInto(out *NetworkLossSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkLossSpec.
+func (in *NetworkLossSpec) DeepCopy() *NetworkLossSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkLossSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPartitionSpec) DeepCopyInto(out *NetworkPartitionSpec) {
+	*out = *in
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(NetworkTarget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalTargets != nil {
+		in, out := &in.ExternalTargets, &out.ExternalTargets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPartitionSpec.
+func (in *NetworkPartitionSpec) DeepCopy() *NetworkPartitionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPartitionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkTarget) DeepCopyInto(out *NetworkTarget) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkTarget.
+func (in *NetworkTarget) DeepCopy() *NetworkTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDrainSpec) DeepCopyInto(out *NodeDrainSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDrainSpec.
+func (in *NodeDrainSpec) DeepCopy() *NodeDrainSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDrainSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperationStatus) DeepCopyInto(out *OperationStatus) {
+	*out = *in
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = make([]VolumeSnapshot, len(*in))
+		copy(*out, *in)
+	}
+	if in.Messages != nil {
+		in, out := &in.Messages, &out.Messages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperationStatus.
+func (in *OperationStatus) DeepCopy() *OperationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OperationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PVCFingerprint) DeepCopyInto(out *PVCFingerprint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PVCFingerprint.
+func (in *PVCFingerprint) DeepCopy() *PVCFingerprint {
+	if in == nil {
+		return nil
+	}
+	out := new(PVCFingerprint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhaseTiming) DeepCopyInto(out *PhaseTiming) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.FinishTime != nil {
+		in, out := &in.FinishTime, &out.FinishTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseTiming.
+func (in *PhaseTiming) DeepCopy() *PhaseTiming {
+	if in == nil {
+		return nil
+	}
+	out := new(PhaseTiming)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDeleteSpec) DeepCopyInto(out *PodDeleteSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDeleteSpec.
+func (in *PodDeleteSpec) DeepCopy() *PodDeleteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDeleteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeSample) DeepCopyInto(out *ProbeSample) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeSample.
+func (in *ProbeSample) DeepCopy() *ProbeSample {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeSample)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeSpec) DeepCopyInto(out *ProbeSpec) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPProbe)
+		**out = **in
+	}
+	if in.TCP != nil {
+		in, out := &in.TCP, &out.TCP
+		*out = new(TCPProbe)
+		**out = **in
+	}
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(PrometheusProbe)
+		**out = **in
+	}
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = new(CommandProbe)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeSpec.
+func (in *ProbeSpec) DeepCopy() *ProbeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeStatus) DeepCopyInto(out *ProbeStatus) {
+	*out = *in
+	if in.Samples != nil {
+		in, out := &in.Samples, &out.Samples
+		*out = make([]ProbeSample, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeStatus.
+func (in *ProbeStatus) DeepCopy() *ProbeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusProbe) DeepCopyInto(out *PrometheusProbe) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusProbe.
+func (in *PrometheusProbe) DeepCopy() *PrometheusProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RPOHTTPTarget) DeepCopyInto(out *RPOHTTPTarget) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RPOHTTPTarget.
+func (in *RPOHTTPTarget) DeepCopy() *RPOHTTPTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(RPOHTTPTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RPOProbeSpec) DeepCopyInto(out *RPOProbeSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxDataLoss != nil {
+		in, out := &in.MaxDataLoss, &out.MaxDataLoss
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(RPOHTTPTarget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SQL != nil {
+		in, out := &in.SQL, &out.SQL
+		*out = new(RPOSQLTarget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(RPORedisTarget)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RPOProbeSpec.
+func (in *RPOProbeSpec) DeepCopy() *RPOProbeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RPOProbeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RPORedisTarget) DeepCopyInto(out *RPORedisTarget) {
+	*out = *in
+	in.ConnectionSecretRef.DeepCopyInto(&out.ConnectionSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RPORedisTarget.
+func (in *RPORedisTarget) DeepCopy() *RPORedisTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(RPORedisTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RPOSQLTarget) DeepCopyInto(out *RPOSQLTarget) {
+	*out = *in
+	in.ConnectionSecretRef.DeepCopyInto(&out.ConnectionSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RPOSQLTarget.
+func (in *RPOSQLTarget) DeepCopy() *RPOSQLTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(RPOSQLTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RPOStatus) DeepCopyInto(out *RPOStatus) {
+	*out = *in
+	if in.FirstMarker != nil {
+		in, out := &in.FirstMarker, &out.FirstMarker
+		*out = (*in).DeepCopy()
+	}
+	if in.LastMarker != nil {
+		in, out := &in.LastMarker, &out.LastMarker
+		*out = (*in).DeepCopy()
+	}
+	if in.FailureMarker != nil {
+		in, out := &in.FailureMarker, &out.FailureMarker
+		*out = (*in).DeepCopy()
+	}
+	if in.NewestRestored != nil {
+		in, out := &in.NewestRestored, &out.NewestRestored
+		*out = (*in).DeepCopy()
+	}
+	if in.DataLoss != nil {
+		in, out := &in.DataLoss, &out.DataLoss
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RPOStatus.
+func (in *RPOStatus) DeepCopy() *RPOStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RPOStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisCheck) DeepCopyInto(out *RedisCheck) {
+	*out = *in
+	in.ConnectionSecretRef.DeepCopyInto(&out.ConnectionSecretRef)
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinDBSize != nil {
+		in, out := &in.MinDBSize, &out.MinDBSize
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisCheck.
+func (in *RedisCheck) DeepCopy() *RedisCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisFingerprint) DeepCopyInto(out *RedisFingerprint) {
+	*out = *in
+	in.ConnectionSecretRef.DeepCopyInto(&out.ConnectionSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisFingerprint.
+func (in *RedisFingerprint) DeepCopy() *RedisFingerprint {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisFingerprint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResticSpec) DeepCopyInto(out *ResticSpec) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticSpec.
+func (in *ResticSpec) DeepCopy() *ResticSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResticSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLFingerprint) DeepCopyInto(out *SQLFingerprint) {
+	*out = *in
+	in.ConnectionSecretRef.DeepCopyInto(&out.ConnectionSecretRef)
+	if in.Tables != nil {
+		in, out := &in.Tables, &out.Tables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLFingerprint.
+func (in *SQLFingerprint) DeepCopy() *SQLFingerprint {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLFingerprint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScenarioChaosStatus) DeepCopyInto(out *ScenarioChaosStatus) {
+	*out = *in
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(ChaosExperimentStatus)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioChaosStatus.
+func (in *ScenarioChaosStatus) DeepCopy() *ScenarioChaosStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScenarioChaosStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScenarioStep) DeepCopyInto(out *ScenarioStep) {
+	*out = *in
+	if in.Chaos != nil {
+		in, out := &in.Chaos, &out.Chaos
+		*out = new(ChaosSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Parallel != nil {
+		in, out := &in.Parallel, &out.Parallel
+		*out = make([]ChaosSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Wait != nil {
+		in, out := &in.Wait, &out.Wait
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioStep.
+func (in *ScenarioStep) DeepCopy() *ScenarioStep {
+	if in == nil {
+		return nil
+	}
+	out := new(ScenarioStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScenarioStepStatus) DeepCopyInto(out *ScenarioStepStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Chaos != nil {
+		in, out := &in.Chaos, &out.Chaos
+		*out = make([]ScenarioChaosStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioStepStatus.
+func (in *ScenarioStepStatus) DeepCopy() *ScenarioStepStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScenarioStepStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScriptCheck) DeepCopyInto(out *ScriptCheck) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScriptCheck.
+func (in *ScriptCheck) DeepCopy() *ScriptCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ScriptCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SteadyStateSpec) DeepCopyInto(out *SteadyStateSpec) {
+	*out = *in
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make([]ProbeSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SteadyStateSpec.
+func (in *SteadyStateSpec) DeepCopy() *SteadyStateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SteadyStateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StressSpec) DeepCopyInto(out *StressSpec) {
+	*out = *in
+	if in.Load != nil {
+		in, out := &in.Load, &out.Load
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StressSpec.
+func (in *StressSpec) DeepCopy() *StressSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StressSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TCPProbe) DeepCopyInto(out *TCPProbe) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TCPProbe.
+func (in *TCPProbe) DeepCopy() *TCPProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(TCPProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimeSkewSpec) DeepCopyInto(out *TimeSkewSpec) {
+	*out = *in
+	if in.ClockIDs != nil {
+		in, out := &in.ClockIDs, &out.ClockIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeSkewSpec.
+func (in *TimeSkewSpec) DeepCopy() *TimeSkewSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeSkewSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationCheck) DeepCopyInto(out *ValidationCheck) {
+	*out = *in
+	if in.Script != nil {
+		in, out := &in.Script, &out.Script
+		*out = new(ScriptCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SQL != nil {
+		in, out := &in.SQL, &out.SQL
+		*out = new(DatabaseQuery)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(RedisCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MongoDB != nil {
+		in, out := &in.MongoDB, &out.MongoDB
+		*out = new(MongoDBCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Fingerprint != nil {
+		in, out := &in.Fingerprint, &out.Fingerprint
+		*out = new(FingerprintCheck)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationCheck.
+func (in *ValidationCheck) DeepCopy() *ValidationCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationConfig) DeepCopyInto(out *ValidationConfig) {
+	*out = *in
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]ValidationCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DatabaseQuery != nil {
+		in, out := &in.DatabaseQuery, &out.DatabaseQuery
+		*out = new(DatabaseQuery)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationConfig.
+func (in *ValidationConfig) DeepCopy() *ValidationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationJobStatus) DeepCopyInto(out *ValidationJobStatus) {
+	*out = *in
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationJobStatus.
+func (in *ValidationJobStatus) DeepCopy() *ValidationJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationResult) DeepCopyInto(out *ValidationResult) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(ValidationJobStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Diff != nil {
+		in, out := &in.Diff, &out.Diff
+		*out = new(FingerprintDiff)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationResult.
+func (in *ValidationResult) DeepCopy() *ValidationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSnapshot) DeepCopyInto(out *VolumeSnapshot) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshot.
+func (in *VolumeSnapshot) DeepCopy() *VolumeSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
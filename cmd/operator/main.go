@@ -4,8 +4,10 @@ import (
 	"flag"
 	"os"
 
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -15,6 +17,11 @@ import (
 
 	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
 	"github.com/harrisin2037/chaos-dr-validator/controllers"
+	"github.com/harrisin2037/chaos-dr-validator/internal/backup"
+	"github.com/harrisin2037/chaos-dr-validator/internal/chaos"
+	"github.com/harrisin2037/chaos-dr-validator/internal/litmus"
+	"github.com/harrisin2037/chaos-dr-validator/internal/validation"
+	"github.com/harrisin2037/chaos-dr-validator/internal/velero"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -25,6 +32,7 @@ var (
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(chaosmeshv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(chaosdrv1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
@@ -33,11 +41,29 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var veleroNamespace string
+	var defaultBackupProvider string
+	var defaultChaosProvider string
+	var litmusServiceAccount string
+	var allowDestructiveChaos bool
+	var allowCommandProbes bool
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&veleroNamespace, "velero-namespace", velero.DefaultNamespace,
+		"The namespace Velero watches for Backup and Restore objects.")
+	flag.StringVar(&defaultBackupProvider, "default-backup-provider", envOrDefault("BACKUP_TOOL", "velero"),
+		"The backup provider used by tests that do not set spec.backup.provider.")
+	flag.StringVar(&defaultChaosProvider, "default-chaos-provider", envOrDefault("CHAOS_TOOL", chaos.ProviderChaosMesh),
+		"The chaos provider used by tests that do not set spec.chaos.provider.")
+	flag.StringVar(&litmusServiceAccount, "litmus-service-account", litmus.DefaultServiceAccount,
+		"The service account Litmus chaos experiments run as.")
+	flag.BoolVar(&allowDestructiveChaos, "allow-destructive-chaos", false,
+		"Allow the native chaos provider to run pvc-delete, which destroys the app's data.")
+	flag.BoolVar(&allowCommandProbes, "allow-command-probes", false,
+		"Allow steady-state command probes, which run in the operator's container with its service account.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -58,9 +84,42 @@ func main() {
 		os.Exit(1)
 	}
 
+	veleroClient := velero.NewVeleroClient(mgr.GetClient(), veleroNamespace)
+	backupProviders := backup.NewRegistry()
+	backupProviders.Register("velero", func(*chaosdrv1.ChaosDRTest) (backup.BackupClient, error) {
+		return veleroClient, nil
+	})
+	backupProviders.Register("restic", backup.NewResticFactory(mgr.GetClient(), mgr.GetAPIReader()))
+	if !backupProviders.Has(defaultBackupProvider) {
+		setupLog.Error(nil, "unknown default backup provider", "provider", defaultBackupProvider, "available", backupProviders.Names())
+		os.Exit(1)
+	}
+
+	chaosProviders := chaos.NewRegistry()
+	chaosProviders.Register(chaos.ProviderChaosMesh, chaos.NewChaosMeshFactory(mgr.GetClient()))
+	chaosProviders.Register(chaos.ProviderNative, chaos.NewNativeFactory(mgr.GetClient(), mgr.GetAPIReader(), allowDestructiveChaos))
+	chaosProviders.Register("litmus", litmus.NewLitmusFactory(mgr.GetClient(), litmusServiceAccount))
+	if !chaosProviders.Has(defaultChaosProvider) {
+		setupLog.Error(nil, "unknown default chaos provider", "provider", defaultChaosProvider, "available", chaosProviders.Names())
+		os.Exit(1)
+	}
+
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create clientset")
+		os.Exit(1)
+	}
+
 	if err = (&controllers.ChaosDRTestReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:                mgr.GetClient(),
+		APIReader:             mgr.GetAPIReader(),
+		Scheme:                mgr.GetScheme(),
+		BackupProviders:       backupProviders,
+		DefaultBackupProvider: defaultBackupProvider,
+		ChaosProviders:        chaosProviders,
+		DefaultChaosProvider:  defaultChaosProvider,
+		Validators:            validation.NewDefaultRegistry(mgr.GetClient(), mgr.GetAPIReader(), clientset.CoreV1()),
+		AllowCommandProbes:    allowCommandProbes,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ChaosDRTest")
 		os.Exit(1)
@@ -82,3 +141,11 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// envOrDefault returns the value of the environment variable key, or def when it is unset.
+func envOrDefault(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,178 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrr "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+	"github.com/harrisin2037/chaos-dr-validator/internal/probe"
+)
+
+// defaultMinSamples is how many samples an httpErrorRate condition waits for
+// when minSamples is unset
+const defaultMinSamples = 3
+
+// validateAbortConditions checks that every abort condition of cr can be
+// evaluated before the test starts.
+func validateAbortConditions(cr *chaosdrv1.ChaosDRTest) error {
+	seen := map[string]bool{}
+	for _, cond := range cr.Spec.AbortConditions {
+		if seen[cond.Name] {
+			return fmt.Errorf("abort condition name %s is used twice", cond.Name)
+		}
+		seen[cond.Name] = true
+
+		switch {
+		case cond.Prometheus != nil:
+			if _, err := probe.Compare(0, cond.Prometheus.Comparator, cond.Prometheus.Threshold); err != nil {
+				return fmt.Errorf("abort condition %s: %w", cond.Name, err)
+			}
+		case cond.HTTPErrorRate != nil:
+			if !hasHTTPProbe(cr, cond.HTTPErrorRate.Probe) {
+				return fmt.Errorf("abort condition %s: spec.steadyState has no http probe named %s", cond.Name, cond.HTTPErrorRate.Probe)
+			}
+		case cond.MinReadyPods != nil:
+		default:
+			return fmt.Errorf("abort condition %s needs one of prometheus, httpErrorRate or minReadyPods", cond.Name)
+		}
+	}
+	return nil
+}
+
+// checkAbortConditions evaluates the abort conditions of cr in order and
+// returns the first one that tripped, or nil. A condition that cannot be
+// evaluated is logged and evaluated again on the next requeue.
+func (r *ChaosDRTestReconciler) checkAbortConditions(ctx context.Context, cr *chaosdrv1.ChaosDRTest) *chaosdrv1.AbortStatus {
+	for _, cond := range cr.Spec.AbortConditions {
+		tripped, msg, err := r.evaluateAbortCondition(ctx, cr, cond)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "unable to evaluate abort condition", "condition", cond.Name)
+			continue
+		}
+		if tripped {
+			return &chaosdrv1.AbortStatus{Condition: cond.Name, Message: msg, Time: metav1.Now()}
+		}
+	}
+	return nil
+}
+
+// evaluateAbortCondition reports whether cond has tripped and, if so, why.
+func (r *ChaosDRTestReconciler) evaluateAbortCondition(ctx context.Context, cr *chaosdrv1.ChaosDRTest, cond chaosdrv1.AbortCondition) (bool, string, error) {
+	switch {
+	case cond.Prometheus != nil:
+		spec := cond.Prometheus
+		ctx, cancel := context.WithTimeout(ctx, probe.DefaultTimeout)
+		defer cancel()
+		value, err := probe.Query(ctx, spec.URL, spec.Query)
+		if err != nil {
+			return false, "", err
+		}
+		tripped, err := probe.Compare(value, spec.Comparator, spec.Threshold)
+		return tripped, fmt.Sprintf("%s returned %g, which is %s %s", spec.Query, value, spec.Comparator, spec.Threshold), err
+
+	case cond.HTTPErrorRate != nil:
+		spec := cond.HTTPErrorRate
+		minSamples := spec.MinSamples
+		if minSamples <= 0 {
+			minSamples = defaultMinSamples
+		}
+		total, failed := 0, 0
+		for _, st := range cr.Status.SteadyState {
+			if st.Name != spec.Probe {
+				continue
+			}
+			for _, sample := range st.Samples {
+				if sample.Phase != chaosdrv1.PhaseInjectingChaos {
+					continue
+				}
+				total++
+				if !sample.Passed {
+					failed++
+				}
+			}
+		}
+		if total < minSamples {
+			return false, "", nil
+		}
+		return failed*100 > spec.MaxPercent*total,
+			fmt.Sprintf("%d of %d runs of probe %s failed during chaos, more than %d%%", failed, total, spec.Probe, spec.MaxPercent), nil
+
+	case cond.MinReadyPods != nil:
+		spec := cond.MinReadyPods
+		selector := spec.Selector
+		if len(selector) == 0 {
+			selector = cr.Spec.AppSelector
+		}
+		pods := &corev1.PodList{}
+		if err := r.List(ctx, pods, client.InNamespace(cr.Namespace), client.MatchingLabels(selector)); err != nil {
+			return false, "", fmt.Errorf("failed to list pods: %w", err)
+		}
+		ready := 0
+		for _, pod := range pods.Items {
+			if pod.DeletionTimestamp == nil && podReady(&pod) {
+				ready++
+			}
+		}
+		return ready < spec.Count, fmt.Sprintf("%d pods matching %v are ready, fewer than %d", ready, selector, spec.Count), nil
+
+	default:
+		return false, "", fmt.Errorf("abort condition %s has no prometheus, httpErrorRate or minReadyPods", cond.Name)
+	}
+}
+
+// abort stops the chaos of cr after status.abort was set, skips the restore
+// and ends the test in phase Aborted. A failed cleanup is retried, and the
+// abort is kept in the status so chaos is not resumed meanwhile.
+func (r *ChaosDRTestReconciler) abort(ctx context.Context, cr *chaosdrv1.ChaosDRTest) (ctrr.Result, error) {
+	a := cr.Status.Abort
+	log.FromContext(ctx).Info("Abort condition tripped, stopping chaos", "condition", a.Condition, "message", a.Message)
+	if err := r.cleanupChaos(ctx, cr); err != nil {
+		if updateErr := r.updateStatus(ctx, cr); updateErr != nil {
+			log.FromContext(ctx).Error(updateErr, "unable to update status")
+		}
+		return ctrr.Result{}, err
+	}
+
+	msg := fmt.Sprintf("aborted by condition %s: %s", a.Condition, a.Message)
+	for i := range cr.Status.Scenario {
+		if st := &cr.Status.Scenario[i]; st.Phase == chaosdrv1.StepRunning {
+			st.Phase = chaosdrv1.StepFailed
+			st.CompletionTime = &a.Time
+			st.Message = msg
+		}
+	}
+	setCondition(cr, chaosdrv1.ConditionChaosInjected, metav1.ConditionFalse, "Aborted", msg)
+	cr.Status.ErrorMessage = msg
+	cr.Status.Success = false
+	drTestSuccess.Set(0)
+	return r.transition(ctx, cr, chaosdrv1.PhaseAborted)
+}
+
+// hasHTTPProbe reports whether spec.steadyState of cr has an http probe named name.
+func hasHTTPProbe(cr *chaosdrv1.ChaosDRTest, name string) bool {
+	if cr.Spec.SteadyState == nil {
+		return false
+	}
+	for _, p := range cr.Spec.SteadyState.Probes {
+		if p.Name == name && p.HTTP != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// podReady reports whether pod has the Ready condition.
+func podReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+func newTestPod(name string, ready bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "redis"}},
+		Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}},
+	}
+}
+
+func TestReconcileInjectingChaosAbortsOnMinReadyPods(t *testing.T) {
+	cr := newTestCR(chaosdrv1.PhaseInjectingChaos, time.Now())
+	cr.Status.ChaosName = "chaos-redis-dr-test"
+	cr.Spec.AbortConditions = []chaosdrv1.AbortCondition{
+		{Name: "quorum", MinReadyPods: &chaosdrv1.MinReadyPodsCondition{Count: 2}},
+	}
+	podChaos := newTestPodChaos(chaosmeshv1alpha1.Injected, chaosmeshv1alpha1.RunningPhase)
+	r := newTestReconciler(cr, podChaos, newTestPod("redis-0", true), newTestPod("redis-1", false))
+
+	res, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseAborted {
+		t.Fatalf("Expected phase Aborted, got %q", got.Status.Phase)
+	}
+	if res.RequeueAfter != 0 {
+		t.Errorf("Expected no requeue after the abort, got %s", res.RequeueAfter)
+	}
+	if got.Status.Abort == nil || got.Status.Abort.Condition != "quorum" {
+		t.Errorf("Expected the abort to name condition quorum, got %+v", got.Status.Abort)
+	}
+	if exists(t, r, podChaos) {
+		t.Error("Expected the PodChaos to be removed right away")
+	}
+	if got.Status.RestoreName != "" {
+		t.Errorf("Expected the restore to be skipped, got %q", got.Status.RestoreName)
+	}
+	cond := meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionChaosInjected)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "Aborted" {
+		t.Errorf("Expected ChaosInjected=False with reason Aborted, got %+v", cond)
+	}
+	if cond := meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionReady); cond == nil || cond.Reason != "Aborted" {
+		t.Errorf("Expected Ready=False with reason Aborted, got %+v", cond)
+	}
+}
+
+func TestReconcileInjectingChaosAbortsOnPrometheusThreshold(t *testing.T) {
+	errorRate := "0.01"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,%q]}]}}`, errorRate)
+	}))
+	defer srv.Close()
+
+	cr := newTestCR(chaosdrv1.PhaseInjectingChaos, time.Now())
+	cr.Status.ChaosName = "chaos-redis-dr-test"
+	cr.Spec.AbortConditions = []chaosdrv1.AbortCondition{{
+		Name: "errors",
+		Prometheus: &chaosdrv1.PrometheusProbe{
+			URL: srv.URL, Query: "sum(rate(redis_errors_total[1m]))", Comparator: ">", Threshold: "0.1",
+		},
+	}}
+	podChaos := newTestPodChaos(chaosmeshv1alpha1.Injected, chaosmeshv1alpha1.RunningPhase)
+	r := newTestReconciler(cr, podChaos)
+
+	_, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseInjectingChaos {
+		t.Fatalf("Expected the chaos to go on below the threshold, got phase %q", got.Status.Phase)
+	}
+
+	errorRate = "0.5"
+	_, got = reconcileOnce(t, r, got)
+	if got.Status.Phase != chaosdrv1.PhaseAborted {
+		t.Fatalf("Expected phase Aborted, got %q", got.Status.Phase)
+	}
+	if got.Status.Abort == nil || got.Status.Abort.Condition != "errors" {
+		t.Errorf("Expected the abort to name condition errors, got %+v", got.Status.Abort)
+	}
+	if exists(t, r, podChaos) {
+		t.Error("Expected the PodChaos to be removed")
+	}
+}
+
+func TestReconcileInjectingChaosAbortsOnHTTPErrorRate(t *testing.T) {
+	cr := newTestCR(chaosdrv1.PhaseInjectingChaos, time.Now())
+	cr.Status.ChaosName = "chaos-redis-dr-test"
+	cr.Spec.SteadyState = newSteadyState("http://redis.default/healthz")
+	cr.Spec.SteadyState.Interval = &metav1.Duration{Duration: time.Hour}
+	cr.Spec.AbortConditions = []chaosdrv1.AbortCondition{
+		{Name: "healthz-errors", HTTPErrorRate: &chaosdrv1.HTTPErrorRateCondition{Probe: "healthz", MaxPercent: 50}},
+	}
+	now := metav1.Now()
+	sample := func(phase chaosdrv1.ChaosDRTestPhase, passed bool) chaosdrv1.ProbeSample {
+		return chaosdrv1.ProbeSample{Time: now, Phase: phase, Passed: passed}
+	}
+	cr.Status.SteadyState = []chaosdrv1.ProbeStatus{{Name: "healthz", Samples: []chaosdrv1.ProbeSample{
+		sample(chaosdrv1.PhasePending, true),
+		sample(chaosdrv1.PhaseInjectingChaos, true),
+		sample(chaosdrv1.PhaseInjectingChaos, false),
+	}}}
+	r := newTestReconciler(cr, newTestPodChaos(chaosmeshv1alpha1.Injected, chaosmeshv1alpha1.RunningPhase))
+
+	// Two samples during chaos are fewer than the default minSamples
+	_, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseInjectingChaos {
+		t.Fatalf("Expected phase InjectingChaos, got %q", got.Status.Phase)
+	}
+
+	got.Status.SteadyState[0].Samples = append(got.Status.SteadyState[0].Samples, sample(chaosdrv1.PhaseInjectingChaos, false))
+	if err := r.Status().Update(t.Context(), got); err != nil {
+		t.Fatalf("Failed to update status: %v", err)
+	}
+	_, got = reconcileOnce(t, r, got)
+	if got.Status.Phase != chaosdrv1.PhaseAborted {
+		t.Fatalf("Expected phase Aborted once 2 of 3 samples failed, got %q", got.Status.Phase)
+	}
+}
+
+func TestReconcilePendingRejectsInvalidAbortCondition(t *testing.T) {
+	cr := newTestCR("", time.Now())
+	cr.Status = chaosdrv1.ChaosDRTestStatus{}
+	cr.Spec.AbortConditions = []chaosdrv1.AbortCondition{
+		{Name: "errors", HTTPErrorRate: &chaosdrv1.HTTPErrorRateCondition{Probe: "healthz", MaxPercent: 10}},
+	}
+	r := newTestReconciler(cr)
+
+	_, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseFailed {
+		t.Fatalf("Expected phase Failed, got %q", got.Status.Phase)
+	}
+	cond := meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionChaosInjected)
+	if cond == nil || cond.Reason != "InvalidAbortCondition" {
+		t.Errorf("Expected ChaosInjected=False with reason InvalidAbortCondition, got %+v", cond)
+	}
+}
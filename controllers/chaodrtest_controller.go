@@ -2,27 +2,29 @@ package controllers
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
-	"net/http"
-	"os/exec"
+	"strings"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrr "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
 	"github.com/harrisin2037/chaos-dr-validator/internal/backup"
 	"github.com/harrisin2037/chaos-dr-validator/internal/chaos"
 	sidecarproto "github.com/harrisin2037/chaos-dr-validator/internal/proto/sidecar"
-	"github.com/harrisin2037/chaos-dr-validator/internal/velero"
+	"github.com/harrisin2037/chaos-dr-validator/internal/validation"
 )
 
 var (
@@ -40,27 +42,77 @@ var (
 		Help:    "Duration of restore operation",
 		Buckets: prometheus.LinearBuckets(1, 5, 10),
 	})
+	rpoDataLoss = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "chaosdr_rpo_data_loss_seconds",
+		Help: "Data-loss window measured by the RPO probe of the last restore",
+	})
+)
+
+const (
+	// phaseRequeueDelay is the pause between finishing one phase and starting the next
+	phaseRequeueDelay = time.Second
+	// pollInterval is how often a running backup, restore or chaos experiment is checked
+	pollInterval = 10 * time.Second
+	// cleanupFinalizer holds a ChaosDRTest until the resources it created are removed
+	cleanupFinalizer = "chaosdr.io/cleanup"
 )
 
+// phaseConditions maps each working phase to the condition it completes
+var phaseConditions = map[chaosdrv1.ChaosDRTestPhase]string{
+	chaosdrv1.PhaseBackingUp:      chaosdrv1.ConditionBackupCompleted,
+	chaosdrv1.PhaseInjectingChaos: chaosdrv1.ConditionChaosInjected,
+	chaosdrv1.PhaseRestoring:      chaosdrv1.ConditionRestoreCompleted,
+	chaosdrv1.PhaseValidating:     chaosdrv1.ConditionValidated,
+	chaosdrv1.PhaseStoringProof:   chaosdrv1.ConditionProofStored,
+}
+
 // ChaosDRTestReconciler reconciles a ChaosDRTest object
 type ChaosDRTestReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	// APIReader reads the Secrets of RPO probes and lists the source
+	// fingerprints. It must not be the cached client: the operator may not
+	// list or watch Secrets, nor ConfigMaps outside its namespace.
+	APIReader client.Reader
+	Scheme    *runtime.Scheme
+	// BackupProviders holds the backup backends tests can select
+	BackupProviders *backup.Registry
+	// DefaultBackupProvider is used by tests that do not set spec.backup.provider
+	DefaultBackupProvider string
+	// ChaosProviders holds the chaos backends tests can select
+	ChaosProviders *chaos.Registry
+	// DefaultChaosProvider is used by tests that do not set spec.chaos.provider
+	DefaultChaosProvider string
+	// Validators run the checks of spec.validationConfig
+	Validators *validation.Registry
+	// AllowCommandProbes permits steady-state command probes, which run in
+	// the operator's container with its service account
+	AllowCommandProbes bool
 }
 
 //+kubebuilder:rbac:groups=chaosdr.io,resources=chaodrtests,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=chaosdr.io,resources=chaodrtests/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=chaosdr.io,resources=chaodrtests/finalizers,verbs=update
+//+kubebuilder:rbac:groups=chaos-mesh.org,resources=podchaos;networkchaos;stresschaos;iochaos;timechaos;dnschaos,verbs=get;list;watch;create;delete
+//+kubebuilder:rbac:groups=litmuschaos.io,resources=chaosengines;chaosresults,verbs=get;list;watch;create;delete
 //+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;delete
+//+kubebuilder:rbac:groups=velero.io,namespace=velero,resources=backups;restores,verbs=get;list;watch;create;delete
+//+kubebuilder:rbac:groups=velero.io,namespace=velero,resources=deletebackuprequests,verbs=create
+//+kubebuilder:rbac:groups=velero.io,namespace=velero,resources=podvolumebackups,verbs=get;list;watch
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete;deletecollection
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;create
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;create;delete
+//+kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=delete
+//+kubebuilder:rbac:groups="",resources=pods/eviction,verbs=create
+//+kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups="",resources=pods/log,verbs=get
 
+// Reconcile advances a ChaosDRTest by at most one phase per call. Progress is
+// persisted in the status, so an operator restart resumes the test where it stopped.
 func (r *ChaosDRTestReconciler) Reconcile(ctx context.Context, req ctrr.Request) (ctrr.Result, error) {
 	log := log.FromContext(ctx)
-	var backupClient backup.BackupClient
-	backupTool := "velero"
-	if backupTool == "restic" {
-		backupClient = &backup.ResticClient{}
-	} else {
-		backupClient = &velero.VeleroClient{}
-	}
 
 	cr := &chaosdrv1.ChaosDRTest{}
 	if err := r.Get(ctx, req.NamespacedName, cr); err != nil {
@@ -71,117 +123,587 @@ func (r *ChaosDRTestReconciler) Reconcile(ctx context.Context, req ctrr.Request)
 		return ctrr.Result{}, err
 	}
 
-	// Step 1: Trigger backup
-	start := time.Now()
-	backupName := "dr-backup-" + req.Name
-	log.Info("Starting ChaosDRTest reconciliation")
-	if err := backupClient.CreateBackup(backupName, cr.Spec.AppSelector); err != nil {
-		cr.Status.ErrorMessage = err.Error()
-		r.Status().Update(ctx, cr)
-		return ctrr.Result{}, err
+	if !cr.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, cr)
+	}
+	if cleanupPolicy(cr) != chaosdrv1.CleanupNever && controllerutil.AddFinalizer(cr, cleanupFinalizer) {
+		if err := r.Update(ctx, cr); err != nil {
+			return ctrr.Result{}, err
+		}
+	}
+
+	switch cr.Status.Phase {
+	case "", chaosdrv1.PhasePending:
+		return r.reconcilePending(ctx, cr)
+	case chaosdrv1.PhaseBackingUp:
+		backupClient, err := r.BackupProviders.New(cr.Status.BackupProvider, cr)
+		if err != nil {
+			return r.fail(ctx, cr, err)
+		}
+		r.writeMarker(ctx, cr)
+		res, err := r.reconcileBackingUp(ctx, cr, backupClient)
+		return rpoRequeue(cr, res), err
+	case chaosdrv1.PhaseInjectingChaos:
+		r.probeDuringChaos(ctx, cr)
+		r.writeMarker(ctx, cr)
+		if cr.Status.Abort == nil {
+			cr.Status.Abort = r.checkAbortConditions(ctx, cr)
+		}
+		if cr.Status.Abort != nil {
+			return r.abort(ctx, cr)
+		}
+		res, err := r.reconcileChaos(ctx, cr)
+		return steadyStateRequeue(cr, rpoRequeue(cr, res)), err
+	case chaosdrv1.PhaseRestoring:
+		backupClient, err := r.BackupProviders.New(cr.Status.BackupProvider, cr)
+		if err != nil {
+			return r.fail(ctx, cr, err)
+		}
+		return r.reconcileRestoring(ctx, cr, backupClient)
+	case chaosdrv1.PhaseValidating:
+		return r.reconcileValidating(ctx, cr)
+	case chaosdrv1.PhaseStoringProof:
+		return r.reconcileStoringProof(ctx, cr)
+	case chaosdrv1.PhaseSucceeded, chaosdrv1.PhaseFailed, chaosdrv1.PhaseAborted:
+		return r.reconcileFinished(ctx, cr)
+	default:
+		return r.fail(ctx, cr, fmt.Errorf("unknown phase %q", cr.Status.Phase))
+	}
+}
+
+// reconcileChaos runs the scenario of cr, or its single chaos.
+func (r *ChaosDRTestReconciler) reconcileChaos(ctx context.Context, cr *chaosdrv1.ChaosDRTest) (ctrr.Result, error) {
+	if len(cr.Spec.Scenario) > 0 {
+		return r.reconcileScenario(ctx, cr)
+	}
+	chaosProvider, err := r.ChaosProviders.Cleaner(chaosProviderName(cr))
+	if err != nil {
+		return r.fail(ctx, cr, err)
+	}
+	return r.reconcileInjectingChaos(ctx, cr, chaosProvider)
+}
+
+// reconcilePending resolves the chaos and the chaos and backup providers and
+// starts the test once the steady-state baseline holds. An invalid chaos
+// spec, a provider that is unknown or cannot run the chaos, an invalid
+// validation check, abort condition or RPO probe, a probe name used twice,
+// a command probe the operator does not allow, or a failing baseline fails
+// the test before anything is backed up.
+func (r *ChaosDRTestReconciler) reconcilePending(ctx context.Context, cr *chaosdrv1.ChaosDRTest) (ctrr.Result, error) {
+	chaosType, chaosProvider, reason, err := r.resolveChaos(ctx, cr)
+	if err != nil {
+		setCondition(cr, chaosdrv1.ConditionChaosInjected, metav1.ConditionFalse, reason, err.Error())
+		return r.fail(ctx, cr, err)
+	}
+	if err := r.Validators.ValidateChecks(cr); err != nil {
+		setCondition(cr, chaosdrv1.ConditionValidated, metav1.ConditionFalse, "InvalidValidation", err.Error())
+		return r.fail(ctx, cr, err)
+	}
+	if err := validateAbortConditions(cr); err != nil {
+		setCondition(cr, chaosdrv1.ConditionChaosInjected, metav1.ConditionFalse, "InvalidAbortCondition", err.Error())
+		return r.fail(ctx, cr, err)
+	}
+	if reason, err := r.validateProbes(cr); err != nil {
+		setCondition(cr, chaosdrv1.ConditionSteadyState, metav1.ConditionFalse, reason, err.Error())
+		return r.fail(ctx, cr, err)
+	}
+	if cr.Spec.RPOProbe != nil {
+		if err := validation.ValidateRPOProbe(cr.Spec.RPOProbe); err != nil {
+			setCondition(cr, chaosdrv1.ConditionRPOMet, metav1.ConditionFalse, "InvalidRPOProbe", err.Error())
+			return r.fail(ctx, cr, err)
+		}
+	}
+
+	provider := r.DefaultBackupProvider
+	if cr.Spec.Backup != nil && cr.Spec.Backup.Provider != "" {
+		provider = cr.Spec.Backup.Provider
+	}
+	if _, err := r.BackupProviders.New(provider, cr); err != nil {
+		setCondition(cr, chaosdrv1.ConditionBackupCompleted, metav1.ConditionFalse, "InvalidBackupProvider", err.Error())
+		return r.fail(ctx, cr, err)
+	}
+	if err := r.probeBaseline(ctx, cr); err != nil {
+		return r.fail(ctx, cr, err)
+	}
+
+	log.FromContext(ctx).Info("Starting ChaosDRTest", "chaosType", chaosType, "chaosProvider", chaosProvider, "backupProvider", provider)
+	now := metav1.Now()
+	cr.Status.StartTime = &now
+	cr.Status.ChaosType = chaosType
+	cr.Status.ChaosProvider = chaosProvider
+	cr.Status.BackupProvider = provider
+	cr.Status.Success = false
+	cr.Status.ErrorMessage = ""
+	return r.transition(ctx, cr, chaosdrv1.PhaseBackingUp)
+}
+
+// resolveChaos checks the chaos of cr, or every chaos of its scenario, and
+// returns the chaos type and provider to record. On error it also returns the
+// reason for the ChaosInjected condition.
+func (r *ChaosDRTestReconciler) resolveChaos(ctx context.Context, cr *chaosdrv1.ChaosDRTest) (string, string, string, error) {
+	if len(cr.Spec.Scenario) > 0 {
+		reason, err := r.validateScenario(ctx, cr)
+		return chaosdrv1.ChaosTypeScenario, "", reason, err
+	}
+
+	chaosSpec, err := chaos.ResolveSpec(cr)
+	if err != nil {
+		return "", "", "InvalidChaosSpec", err
+	}
+	chaosProvider := r.DefaultChaosProvider
+	if chaosSpec.Provider != "" {
+		chaosProvider = chaosSpec.Provider
+	}
+	if _, err := r.ChaosProviders.New(chaosProvider, cr); err != nil {
+		return "", "", "InvalidChaosProvider", err
+	}
+	if _, err := chaos.CheckBlastRadius(ctx, r.Client, cr, chaosSpec); err != nil {
+		return "", "", "BlastRadiusExceeded", err
+	}
+	return chaosSpec.Type, chaosProvider, "", nil
+}
+
+// reconcileBackingUp triggers the backup of the selected app and waits for the
+// backend to report it complete.
+func (r *ChaosDRTestReconciler) reconcileBackingUp(ctx context.Context, cr *chaosdrv1.ChaosDRTest, backupClient backup.BackupClient) (ctrr.Result, error) {
+	if cr.Status.Backup == nil {
+		captured, err := r.captureSources(ctx, cr)
+		if err != nil {
+			return r.failWithReason(ctx, cr, "CaptureFailed", err)
+		}
+		if !captured {
+			return r.waitFor(ctx, cr)
+		}
+		backupName := "dr-backup-" + cr.Name
+		ref, err := backupClient.CreateBackup(ctx, backupName, cr.Namespace, cr.Spec.AppSelector)
+		if errors.IsAlreadyExists(err) {
+			// Created before an operator restart; the status update was lost
+			ref, err = backup.OperationRef{Name: backupName}, nil
+		}
+		if err != nil {
+			return r.fail(ctx, cr, err)
+		}
+		cr.Status.BackupName = ref.Name
+		cr.Status.Backup = &chaosdrv1.OperationStatus{Name: ref.Name, Namespace: ref.Namespace}
 	}
-	backupDuration.Observe(time.Since(start).Seconds())
-	log.Info("Ending ChaosDRTest reconciliation")
-	cr.Status.BackupName = backupName
 
-	// Step 2: Inject chaos (pod-delete)
-	chaosName := "chaos-" + req.Name
-	if err := chaos.ApplyChaosExperiment(ctx, r.Client, cr, chaosName, cr.Spec.ChaosType); err != nil {
-		cr.Status.ErrorMessage = err.Error()
-		r.Status().Update(ctx, cr)
+	st, err := backupClient.GetBackupStatus(ctx, operationRef(cr.Status.Backup))
+	if err != nil {
 		return ctrr.Result{}, err
 	}
+	recordOperation(cr.Status.Backup, st)
+	if !st.Done {
+		return r.waitFor(ctx, cr)
+	}
+	if st.Failed {
+		return r.fail(ctx, cr, operationError("backup", cr.Status.Backup))
+	}
 
-	// Wait for chaos to complete (simplified for prototype)
-	time.Sleep(30 * time.Second)
+	elapsed := operationDuration(cr, st).Seconds()
+	cr.Status.BackupDuration = elapsed
+	backupDuration.Observe(elapsed)
+	markFailure(cr)
+	return r.transition(ctx, cr, chaosdrv1.PhaseInjectingChaos)
+}
+
+// reconcileInjectingChaos injects chaos and waits until the experiment has
+// recovered its targets or the chaos duration has run out.
+func (r *ChaosDRTestReconciler) reconcileInjectingChaos(ctx context.Context, cr *chaosdrv1.ChaosDRTest, chaosProvider chaos.ChaosProvider) (ctrr.Result, error) {
+	if cr.Status.ChaosName == "" {
+		chaosSpec, err := chaos.ResolveSpec(cr)
+		if err != nil {
+			return r.fail(ctx, cr, err)
+		}
+		// The app may have scaled up while it was backed up
+		affected, err := chaos.CheckBlastRadius(ctx, r.Client, cr, chaosSpec)
+		if err != nil {
+			return r.failWithReason(ctx, cr, "BlastRadiusExceeded", err)
+		}
+		log.FromContext(ctx).Info("Injecting chaos", "chaosType", chaosSpec.Type, "mode", chaos.Mode(chaosSpec), "affectedPods", affected)
+		chaosName := "chaos-" + cr.Name
+		err = chaosProvider.Apply(ctx, cr, chaosName, chaosSpec)
+		if err != nil && !errors.IsAlreadyExists(err) {
+			return r.fail(ctx, cr, err)
+		}
+		cr.Status.ChaosName = chaosName
+		return r.waitFor(ctx, cr)
+	}
 
-	// Step 3: Restore to sandbox namespace
-	restoreName := "dr-restore-" + req.Name
-	sandboxNs := "sandbox-" + req.Name
-	if err := backupClient.CreateRestore(backupName, sandboxNs); err != nil {
-		cr.Status.ErrorMessage = err.Error()
-		r.Status().Update(ctx, cr)
+	st, err := chaosProvider.Status(ctx, cr.Namespace, cr.Status.ChaosName, chaosType(cr))
+	if errors.IsNotFound(err) {
+		return r.fail(ctx, cr, err)
+	}
+	if err != nil {
 		return ctrr.Result{}, err
 	}
-	cr.Status.RestoreName = restoreName
+	cr.Status.Chaos = &chaosdrv1.ChaosExperimentStatus{
+		State:           string(st.State),
+		InjectedTargets: st.InjectedTargets,
+		TotalTargets:    st.TotalTargets,
+		Message:         st.Message,
+	}
+
+	remaining := chaos.Duration(cr) - phaseElapsed(cr)
+	switch {
+	case st.State == chaos.ExperimentFailed:
+		return r.fail(ctx, cr, fmt.Errorf("chaos %s failed: %s", cr.Status.ChaosName, st.Message))
+	case st.State == chaos.ExperimentRecovered:
+		return r.transition(ctx, cr, chaosdrv1.PhaseRestoring)
+	case remaining > 0:
+		if err := r.updateStatus(ctx, cr); err != nil {
+			return ctrr.Result{}, err
+		}
+		return ctrr.Result{RequeueAfter: min(remaining, pollInterval)}, nil
+	case st.TotalTargets == 0:
+		return r.fail(ctx, cr, fmt.Errorf("chaos %s selected no targets within %s", cr.Status.ChaosName, chaos.Duration(cr)))
+	default:
+		log.FromContext(ctx).Info("Chaos duration elapsed before recovery was reported", "chaos", cr.Status.ChaosName, "state", st.State)
+		return r.transition(ctx, cr, chaosdrv1.PhaseRestoring)
+	}
+}
+
+// reconcileRestoring restores the backup into the sandbox namespace and waits
+// for the backend to report it complete.
+func (r *ChaosDRTestReconciler) reconcileRestoring(ctx context.Context, cr *chaosdrv1.ChaosDRTest, backupClient backup.BackupClient) (ctrr.Result, error) {
+	if cr.Status.Restore == nil {
+		restoreName := "dr-restore-" + cr.Name
+		sandboxNs := "sandbox-" + cr.Name
+		ref, err := backupClient.CreateRestore(ctx, restoreName, operationRef(cr.Status.Backup), sandboxNs)
+		if errors.IsAlreadyExists(err) {
+			ref, err = backup.OperationRef{Name: restoreName}, nil
+		}
+		if err != nil {
+			return r.fail(ctx, cr, err)
+		}
+		cr.Status.RestoreName = ref.Name
+		cr.Status.SandboxNamespace = sandboxNs
+		cr.Status.Restore = &chaosdrv1.OperationStatus{Name: ref.Name, Namespace: ref.Namespace}
+	}
 
-	// Step 4: Validate app
-	if err := r.validateApp(ctx, cr); err != nil {
-		cr.Status.ErrorMessage = err.Error()
-		cr.Status.Success = false
-		drTestSuccess.Set(0)
-		r.Status().Update(ctx, cr)
+	st, err := backupClient.GetRestoreStatus(ctx, operationRef(cr.Status.Restore))
+	if err != nil {
 		return ctrr.Result{}, err
 	}
+	recordOperation(cr.Status.Restore, st)
+	if !st.Done {
+		return r.waitFor(ctx, cr)
+	}
+	if st.Failed {
+		return r.fail(ctx, cr, operationError("restore", cr.Status.Restore))
+	}
+
+	elapsed := operationDuration(cr, st).Seconds()
+	cr.Status.RestoreDuration = elapsed
+	restoreDuration.Observe(elapsed)
+	return r.transition(ctx, cr, chaosdrv1.PhaseValidating)
+}
 
-	// Step 5: Call Rust sidecar for data proof
+// reconcileValidating measures the data loss of the restore with the RPO
+// probe, then runs the validation checks against the restored app until all
+// of them have finished, and fails the test if any did not pass.
+func (r *ChaosDRTestReconciler) reconcileValidating(ctx context.Context, cr *chaosdrv1.ChaosDRTest) (ctrr.Result, error) {
+	if cr.Status.Validation != nil && len(cr.Status.Validation) != len(validation.Checks(cr)) {
+		return r.fail(ctx, cr, fmt.Errorf("spec.validationConfig changed while the test was validating"))
+	}
+	measured, reason, err := r.measureRPO(ctx, cr)
+	if err != nil {
+		return r.failWithReason(ctx, cr, reason, err)
+	}
+	if !measured {
+		return r.waitFor(ctx, cr)
+	}
+	if !r.runChecks(ctx, cr) {
+		return r.waitFor(ctx, cr)
+	}
+	if failed := failedChecks(cr); len(failed) > 0 {
+		return r.failWithReason(ctx, cr, "ValidationFailed", fmt.Errorf("validation failed: %s", strings.Join(failed, "; ")))
+	}
+	return r.transition(ctx, cr, chaosdrv1.PhaseStoringProof)
+}
+
+// reconcileStoringProof calls the Rust sidecar to store the data proof.
+func (r *ChaosDRTestReconciler) reconcileStoringProof(ctx context.Context, cr *chaosdrv1.ChaosDRTest) (ctrr.Result, error) {
 	if err := r.storeValidationProof(ctx, cr); err != nil {
-		cr.Status.ErrorMessage = err.Error()
-		cr.Status.Success = false
-		drTestSuccess.Set(0)
-		r.Status().Update(ctx, cr)
-		return ctrr.Result{}, err
+		return r.fail(ctx, cr, err)
 	}
 
-	// Step 6: Update status
 	cr.Status.Success = true
 	cr.Status.ErrorMessage = ""
 	drTestSuccess.Set(1)
-	if err := r.Status().Update(ctx, cr); err != nil {
-		log.Error(err, "unable to update status")
-		return ctrr.Result{}, err
+	log.FromContext(ctx).Info("ChaosDRTest succeeded")
+	return r.transition(ctx, cr, chaosdrv1.PhaseSucceeded)
+}
+
+// reconcileFinished cleans up after a finished test as its cleanupPolicy
+// allows. The CleanedUp condition records the outcome so it happens once.
+func (r *ChaosDRTestReconciler) reconcileFinished(ctx context.Context, cr *chaosdrv1.ChaosDRTest) (ctrr.Result, error) {
+	if cond := meta.FindStatusCondition(cr.Status.Conditions, chaosdrv1.ConditionCleanedUp); cond != nil && cond.Reason != "CleanupFailed" {
+		return ctrr.Result{}, nil
+	}
+
+	switch policy := cleanupPolicy(cr); {
+	case policy == chaosdrv1.CleanupNever:
+		setCondition(cr, chaosdrv1.ConditionCleanedUp, metav1.ConditionFalse, "Retained", "cleanupPolicy is Never")
+	case policy == chaosdrv1.CleanupOnSuccess && cr.Status.Phase != chaosdrv1.PhaseSucceeded:
+		setCondition(cr, chaosdrv1.ConditionCleanedUp, metav1.ConditionFalse, "Retained", "kept for inspection until the ChaosDRTest is deleted")
+	default:
+		if err := r.cleanup(ctx, cr); err != nil {
+			setCondition(cr, chaosdrv1.ConditionCleanedUp, metav1.ConditionFalse, "CleanupFailed", err.Error())
+			if updateErr := r.updateStatus(ctx, cr); updateErr != nil {
+				log.FromContext(ctx).Error(updateErr, "unable to update status")
+			}
+			return ctrr.Result{}, err
+		}
+		setCondition(cr, chaosdrv1.ConditionCleanedUp, metav1.ConditionTrue, "CleanedUp", "chaos and sandbox resources removed")
+	}
+	return ctrr.Result{}, r.updateStatus(ctx, cr)
+}
+
+// reconcileDelete cleans up a ChaosDRTest being deleted, unless its policy is
+// Never or it was already cleaned up, and then releases the finalizer.
+func (r *ChaosDRTestReconciler) reconcileDelete(ctx context.Context, cr *chaosdrv1.ChaosDRTest) (ctrr.Result, error) {
+	if !controllerutil.ContainsFinalizer(cr, cleanupFinalizer) {
+		return ctrr.Result{}, nil
+	}
+	if cleanupPolicy(cr) != chaosdrv1.CleanupNever && !meta.IsStatusConditionTrue(cr.Status.Conditions, chaosdrv1.ConditionCleanedUp) {
+		if err := r.cleanup(ctx, cr); err != nil {
+			return ctrr.Result{}, err
+		}
 	}
 
-	return ctrr.Result{}, nil
+	controllerutil.RemoveFinalizer(cr, cleanupFinalizer)
+	return ctrr.Result{}, r.Update(ctx, cr)
 }
 
-func (r *ChaosDRTestReconciler) validateApp(ctx context.Context, cr *chaosdrv1.ChaosDRTest) error {
+// cleanup removes the chaos experiments and the sandbox namespace, and with
+// spec.deleteBackup the restore and backup too. Everything already gone is skipped,
+// so cleanup can be retried.
+func (r *ChaosDRTestReconciler) cleanup(ctx context.Context, cr *chaosdrv1.ChaosDRTest) error {
 	log := log.FromContext(ctx)
-	cfg := cr.Spec.ValidationConfig
 
-	if cfg.Script != "" {
-		cmd := exec.Command("bash", "-c", cfg.Script)
-		output, err := cmd.CombinedOutput()
-		if err != nil {
-			log.Error(err, "validation script failed", "output", string(output))
-			return err
+	if err := r.cleanupChaos(ctx, cr); err != nil {
+		return err
+	}
+
+	if err := validation.DeleteFingerprints(ctx, r.Client, r.APIReader, cr); err != nil {
+		return err
+	}
+	r.deleteMarkers(ctx, cr)
+
+	if cr.Status.SandboxNamespace != "" {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: cr.Status.SandboxNamespace}}
+		if err := client.IgnoreNotFound(r.Delete(ctx, ns)); err != nil {
+			return fmt.Errorf("failed to delete sandbox namespace %s: %w", ns.Name, err)
 		}
+		log.Info("Deleted sandbox namespace", "namespace", ns.Name)
 	}
 
-	if cfg.APIEndpoint != "" {
-		resp, err := http.Get(cfg.APIEndpoint)
-		if err != nil {
-			log.Error(err, "API validation failed")
+	if !cr.Spec.DeleteBackup || cr.Status.BackupProvider == "" {
+		return nil
+	}
+	backupClient, err := r.BackupProviders.New(cr.Status.BackupProvider, cr)
+	if err != nil {
+		return err
+	}
+	if cr.Status.Restore != nil {
+		if err := backupClient.DeleteRestore(ctx, operationRef(cr.Status.Restore)); err != nil {
 			return err
 		}
-		defer resp.Body.Close()
-		if resp.StatusCode != cfg.ExpectedStatusCode {
-			return fmt.Errorf("unexpected status code: got %d, expected %d", resp.StatusCode, cfg.ExpectedStatusCode)
-		}
 	}
-
-	if cfg.DatabaseQuery != nil {
-		// Example: Validate database query (e.g., using SQL driver)
-		db, err := sql.Open("mysql", cfg.DatabaseQuery.ConnectionString)
-		if err != nil {
+	// The backup goes last: deleting it is not idempotent for every provider
+	if cr.Status.Backup != nil {
+		if err := backupClient.DeleteBackup(ctx, operationRef(cr.Status.Backup)); err != nil {
 			return err
 		}
-		defer db.Close()
-		rows, err := db.Query(cfg.DatabaseQuery.Query)
+		log.Info("Deleted backup", "backup", cr.Status.Backup.Name)
+	}
+	return nil
+}
+
+// cleanupChaos stops and removes the chaos injected by cr, or by every step
+// of its scenario, through the provider that injected it. Only what the
+// status recorded is used, so an edited spec cannot hold up the cleanup.
+func (r *ChaosDRTestReconciler) cleanupChaos(ctx context.Context, cr *chaosdrv1.ChaosDRTest) error {
+	if cr.Status.ChaosName != "" {
+		chaosProvider, err := r.ChaosProviders.Cleaner(chaosProviderName(cr))
 		if err != nil {
 			return err
 		}
-		defer rows.Close()
-		rowCount := 0
-		for rows.Next() {
-			rowCount++
-		}
-		if rowCount != cfg.DatabaseQuery.ExpectedRows {
-			return fmt.Errorf("unexpected row count: got %d, expected %d", rowCount, cfg.DatabaseQuery.ExpectedRows)
+		if err := chaosProvider.Cleanup(ctx, cr.Namespace, cr.Status.ChaosName, chaosType(cr)); err != nil {
+			return fmt.Errorf("failed to clean up chaos %s: %w", cr.Status.ChaosName, err)
 		}
+		log.FromContext(ctx).Info("Removed chaos experiment", "chaos", cr.Status.ChaosName)
 	}
+	return r.cleanupScenario(ctx, cr)
+}
 
-	return nil
+// chaosType returns the chaos type resolved when cr started. Tests started
+// before it was recorded fall back to the spec.
+func chaosType(cr *chaosdrv1.ChaosDRTest) string {
+	switch {
+	case cr.Status.ChaosType != "":
+		return cr.Status.ChaosType
+	case cr.Spec.Chaos != nil:
+		return cr.Spec.Chaos.Type
+	default:
+		return cr.Spec.ChaosType
+	}
+}
+
+// chaosProviderName returns the chaos provider resolved when cr started. Tests
+// started before it was recorded ran on Chaos Mesh, the only provider then.
+func chaosProviderName(cr *chaosdrv1.ChaosDRTest) string {
+	if cr.Status.ChaosProvider != "" {
+		return cr.Status.ChaosProvider
+	}
+	return chaos.ProviderChaosMesh
+}
+
+// cleanupPolicy returns cr's cleanup policy, applying the API default.
+func cleanupPolicy(cr *chaosdrv1.ChaosDRTest) chaosdrv1.CleanupPolicy {
+	if cr.Spec.CleanupPolicy == "" {
+		return chaosdrv1.CleanupAlways
+	}
+	return cr.Spec.CleanupPolicy
+}
+
+// transition moves cr into the next phase and persists the status. The
+// condition of the phase being left is marked complete unless the test is
+// failing or aborted. Terminal phases are not requeued.
+func (r *ChaosDRTestReconciler) transition(ctx context.Context, cr *chaosdrv1.ChaosDRTest, next chaosdrv1.ChaosDRTestPhase) (ctrr.Result, error) {
+	now := metav1.Now()
+	prev := cr.Status.Phase
+	if condType, ok := phaseConditions[prev]; ok && next != chaosdrv1.PhaseFailed && next != chaosdrv1.PhaseAborted {
+		setCondition(cr, condType, metav1.ConditionTrue, "Completed", fmt.Sprintf("%s finished", prev))
+	}
+	if n := len(cr.Status.PhaseTimings); n > 0 && cr.Status.PhaseTimings[n-1].FinishTime == nil {
+		cr.Status.PhaseTimings[n-1].FinishTime = &now
+	}
+
+	cr.Status.Phase = next
+	cr.Status.PhaseStartTime = &now
+	switch next {
+	case chaosdrv1.PhaseSucceeded:
+		setCondition(cr, chaosdrv1.ConditionReady, metav1.ConditionTrue, "Succeeded", "ChaosDRTest succeeded")
+	case chaosdrv1.PhaseFailed:
+		setCondition(cr, chaosdrv1.ConditionReady, metav1.ConditionFalse, "Failed", cr.Status.ErrorMessage)
+	case chaosdrv1.PhaseAborted:
+		setCondition(cr, chaosdrv1.ConditionReady, metav1.ConditionFalse, "Aborted", cr.Status.ErrorMessage)
+	default:
+		setCondition(cr, chaosdrv1.ConditionReady, metav1.ConditionFalse, string(next), fmt.Sprintf("ChaosDRTest is in phase %s", next))
+		cr.Status.PhaseTimings = append(cr.Status.PhaseTimings, chaosdrv1.PhaseTiming{Phase: next, StartTime: now})
+	}
+	if next.IsTerminal() {
+		cr.Status.CompletionTime = &now
+	}
+
+	if err := r.updateStatus(ctx, cr); err != nil {
+		log.FromContext(ctx).Error(err, "unable to update status", "phase", next)
+		return ctrr.Result{}, err
+	}
+	if next.IsTerminal() {
+		return ctrr.Result{}, nil
+	}
+	return ctrr.Result{RequeueAfter: phaseRequeueDelay}, nil
+}
+
+// waitFor persists progress made within the current phase and checks back after pollInterval.
+func (r *ChaosDRTestReconciler) waitFor(ctx context.Context, cr *chaosdrv1.ChaosDRTest) (ctrr.Result, error) {
+	if err := r.updateStatus(ctx, cr); err != nil {
+		return ctrr.Result{}, err
+	}
+	return ctrr.Result{RequeueAfter: pollInterval}, nil
+}
+
+// updateStatus persists cr's status, stamping the generation it was computed from.
+func (r *ChaosDRTestReconciler) updateStatus(ctx context.Context, cr *chaosdrv1.ChaosDRTest) error {
+	cr.Status.ObservedGeneration = cr.Generation
+	return r.Status().Update(ctx, cr)
+}
+
+// setCondition adds or updates a condition on cr, keeping LastTransitionTime
+// when the status is unchanged.
+func setCondition(cr *chaosdrv1.ChaosDRTest, condType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
+		Type:               condType,
+		Status:             status,
+		ObservedGeneration: cr.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// operationRef rebuilds the backend reference of a recorded operation.
+func operationRef(op *chaosdrv1.OperationStatus) backup.OperationRef {
+	if op == nil {
+		return backup.OperationRef{}
+	}
+	return backup.OperationRef{Name: op.Name, Namespace: op.Namespace}
+}
+
+// recordOperation copies a backend progress report into op.
+func recordOperation(op *chaosdrv1.OperationStatus, st *backup.Status) {
+	op.Phase = st.Phase
+	op.TotalItems = st.TotalItems
+	op.ItemsCompleted = st.ItemsCompleted
+	op.Warnings = st.Warnings
+	op.Errors = st.Errors
+	op.SizeBytes = st.SizeBytes
+	op.Snapshots = nil
+	for _, snap := range st.Snapshots {
+		op.Snapshots = append(op.Snapshots, chaosdrv1.VolumeSnapshot{Volume: snap.Volume, ID: snap.ID})
+	}
+	op.Messages = st.Messages
+	if st.StartTime != nil {
+		op.StartTime = &metav1.Time{Time: *st.StartTime}
+	}
+	if st.CompletionTime != nil {
+		op.CompletionTime = &metav1.Time{Time: *st.CompletionTime}
+	}
+}
+
+// operationDuration prefers the backend's own timing of an operation and falls
+// back to the time spent in the current phase.
+func operationDuration(cr *chaosdrv1.ChaosDRTest, st *backup.Status) time.Duration {
+	if d := st.Duration(); d > 0 {
+		return d
+	}
+	return phaseElapsed(cr)
+}
+
+// operationError describes a backup or restore that finished unsuccessfully.
+func operationError(kind string, op *chaosdrv1.OperationStatus) error {
+	err := fmt.Errorf("%s %s finished in phase %s with %d errors", kind, op.Name, op.Phase, op.Errors)
+	if len(op.Messages) > 0 {
+		err = fmt.Errorf("%w: %s", err, strings.Join(op.Messages, "; "))
+	}
+	return err
+}
+
+// phaseElapsed returns how long cr has been in its current phase.
+func phaseElapsed(cr *chaosdrv1.ChaosDRTest) time.Duration {
+	if cr.Status.PhaseStartTime == nil {
+		return 0
+	}
+	return time.Since(cr.Status.PhaseStartTime.Time)
+}
+
+// fail records err on cr and moves it to the Failed phase.
+func (r *ChaosDRTestReconciler) fail(ctx context.Context, cr *chaosdrv1.ChaosDRTest, err error) (ctrr.Result, error) {
+	return r.failWithReason(ctx, cr, "Failed", err)
+}
+
+// failWithReason is fail with a more specific reason on the condition of the
+// current phase.
+func (r *ChaosDRTestReconciler) failWithReason(ctx context.Context, cr *chaosdrv1.ChaosDRTest, reason string, err error) (ctrr.Result, error) {
+	log.FromContext(ctx).Error(err, "ChaosDRTest failed", "phase", cr.Status.Phase)
+	if condType, ok := phaseConditions[cr.Status.Phase]; ok {
+		setCondition(cr, condType, metav1.ConditionFalse, reason, err.Error())
+	}
+	cr.Status.ErrorMessage = err.Error()
+	cr.Status.Success = false
+	drTestSuccess.Set(0)
+	return r.transition(ctx, cr, chaosdrv1.PhaseFailed)
 }
 
 func (r *ChaosDRTestReconciler) storeValidationProof(ctx context.Context, cr *chaosdrv1.ChaosDRTest) error {
@@ -3,29 +3,531 @@ package controllers
 import (
 	"context"
 	"testing"
+	"time"
 
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+	"github.com/harrisin2037/chaos-dr-validator/internal/backup"
+	"github.com/harrisin2037/chaos-dr-validator/internal/chaos"
+	"github.com/harrisin2037/chaos-dr-validator/internal/validation"
+	"github.com/harrisin2037/chaos-dr-validator/internal/velero"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrr "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-func TestChaosDRTestReconcile(t *testing.T) {
-	// Setup fake client
-	cl := fake.NewClientBuilder().Build()
-	r := &ChaosDRTestReconciler{Client: cl}
+func newTestReconciler(objs ...client.Object) *ChaosDRTestReconciler {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = chaosmeshv1alpha1.AddToScheme(scheme)
+	_ = chaosdrv1.AddToScheme(scheme)
 
-	// Create test CR
-	_ = &chaosdrv1.ChaosDRTest{
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&chaosdrv1.ChaosDRTest{}).
+		Build()
+	veleroClient := velero.NewVeleroClient(cl, velero.DefaultNamespace)
+	providers := backup.NewRegistry()
+	providers.Register("velero", func(*chaosdrv1.ChaosDRTest) (backup.BackupClient, error) {
+		return veleroClient, nil
+	})
+	chaosProviders := chaos.NewRegistry()
+	chaosProviders.Register(chaos.ProviderChaosMesh, chaos.NewChaosMeshFactory(cl))
+	return &ChaosDRTestReconciler{
+		Client:                cl,
+		APIReader:             cl,
+		Scheme:                scheme,
+		BackupProviders:       providers,
+		DefaultBackupProvider: "velero",
+		ChaosProviders:        chaosProviders,
+		DefaultChaosProvider:  chaos.ProviderChaosMesh,
+		Validators:            validation.NewDefaultRegistry(cl, cl, kubefake.NewClientset().CoreV1()),
+	}
+}
+
+func newTestCR(phase chaosdrv1.ChaosDRTestPhase, phaseStart time.Time) *chaosdrv1.ChaosDRTest {
+	start := metav1.NewTime(phaseStart)
+	return &chaosdrv1.ChaosDRTest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "redis-dr-test",
+			Namespace: "default",
+		},
 		Spec: chaosdrv1.ChaosDRTestSpec{
 			AppSelector:      map[string]string{"app": "redis"},
 			ChaosType:        "pod-delete",
 			ValidationScript: "curl http://redis/healthz",
 		},
+		Status: chaosdrv1.ChaosDRTestStatus{
+			Phase:          phase,
+			PhaseStartTime: &start,
+			BackupProvider: "velero",
+		},
+	}
+}
+
+func reconcileOnce(t *testing.T, r *ChaosDRTestReconciler, cr *chaosdrv1.ChaosDRTest) (ctrr.Result, *chaosdrv1.ChaosDRTest) {
+	t.Helper()
+	key := types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}
+	res, err := r.Reconcile(context.Background(), ctrr.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	got := &chaosdrv1.ChaosDRTest{}
+	if err := r.Get(context.Background(), key, got); err != nil {
+		t.Fatalf("Failed to get ChaosDRTest: %v", err)
 	}
+	return res, got
+}
+
+func TestChaosDRTestReconcile(t *testing.T) {
+	r := newTestReconciler()
 
-	// Test reconcile
+	// A request for a deleted CR is not an error
 	_, err := r.Reconcile(context.Background(), ctrr.Request{})
 	if err != nil {
 		t.Fatalf("Reconcile failed: %v", err)
 	}
 }
+
+func TestReconcilePendingStartsBackup(t *testing.T) {
+	cr := newTestCR("", time.Now())
+	cr.Status = chaosdrv1.ChaosDRTestStatus{}
+	r := newTestReconciler(cr)
+
+	res, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseBackingUp {
+		t.Errorf("Expected phase BackingUp, got %q", got.Status.Phase)
+	}
+	if got.Status.StartTime == nil || got.Status.PhaseStartTime == nil {
+		t.Error("Expected start timestamps to be set")
+	}
+	if got.Status.BackupProvider != "velero" {
+		t.Errorf("Expected default backup provider velero, got %q", got.Status.BackupProvider)
+	}
+	if got.Status.ChaosType != "pod-delete" {
+		t.Errorf("Expected chaos type pod-delete, got %q", got.Status.ChaosType)
+	}
+	if got.Status.ChaosProvider != chaos.ProviderChaosMesh {
+		t.Errorf("Expected default chaos provider %s, got %q", chaos.ProviderChaosMesh, got.Status.ChaosProvider)
+	}
+	if res.RequeueAfter <= 0 {
+		t.Error("Expected reconcile to be requeued")
+	}
+}
+
+// newTestPodChaos returns the PodChaos of newTestCR with one experiment record in phase.
+func newTestPodChaos(phase chaosmeshv1alpha1.Phase, desired chaosmeshv1alpha1.DesiredPhase) *chaosmeshv1alpha1.PodChaos {
+	podChaos := &chaosmeshv1alpha1.PodChaos{ObjectMeta: metav1.ObjectMeta{Name: "chaos-redis-dr-test", Namespace: "default"}}
+	podChaos.Status.Experiment.DesiredPhase = desired
+	if phase != "" {
+		podChaos.Status.Experiment.Records = []*chaosmeshv1alpha1.Record{{Id: "default/redis-0", SelectorKey: ".", Phase: phase}}
+	}
+	return podChaos
+}
+
+func TestReconcileInjectingChaosAppliesExperiment(t *testing.T) {
+	cr := newTestCR(chaosdrv1.PhaseInjectingChaos, time.Now())
+	cr.Spec.ChaosDuration = &metav1.Duration{Duration: 2 * time.Minute}
+	r := newTestReconciler(cr)
+
+	res, got := reconcileOnce(t, r, cr)
+	if got.Status.ChaosName != "chaos-redis-dr-test" {
+		t.Fatalf("Expected chaos to be recorded, got %q", got.Status.ChaosName)
+	}
+	if res.RequeueAfter != pollInterval {
+		t.Errorf("Expected requeue after %s, got %s", pollInterval, res.RequeueAfter)
+	}
+
+	podChaos := &chaosmeshv1alpha1.PodChaos{}
+	if err := r.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: got.Status.ChaosName}, podChaos); err != nil {
+		t.Fatalf("Failed to get PodChaos: %v", err)
+	}
+	if podChaos.Spec.Duration == nil || *podChaos.Spec.Duration != "2m0s" {
+		t.Errorf("Expected chaos duration 2m0s, got %v", podChaos.Spec.Duration)
+	}
+}
+
+func TestReconcileInjectingChaosRefusesLargeBlastRadius(t *testing.T) {
+	cr := newTestCR(chaosdrv1.PhaseInjectingChaos, time.Now())
+	cr.Spec.ChaosType = ""
+	cr.Spec.Chaos = &chaosdrv1.ChaosSpec{
+		Type:            chaosdrv1.ChaosTypePodDelete,
+		Mode:            chaosdrv1.ChaosModeFixedPercent,
+		Value:           "50",
+		MaxAffectedPods: 1,
+	}
+	objs := []client.Object{cr}
+	for _, name := range []string{"redis-0", "redis-1", "redis-2", "redis-3"} {
+		objs = append(objs, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "redis"}}})
+	}
+	r := newTestReconciler(objs...)
+
+	_, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseFailed {
+		t.Fatalf("Expected phase Failed, got %q", got.Status.Phase)
+	}
+	if got.Status.ChaosName != "" {
+		t.Errorf("Expected no chaos to be injected, got %q", got.Status.ChaosName)
+	}
+	cond := meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionChaosInjected)
+	if cond == nil || cond.Reason != "BlastRadiusExceeded" {
+		t.Errorf("Expected ChaosInjected=False with reason BlastRadiusExceeded, got %+v", cond)
+	}
+}
+
+func TestReconcileInjectingChaosWaitsWhileInjected(t *testing.T) {
+	cr := newTestCR(chaosdrv1.PhaseInjectingChaos, time.Now())
+	cr.Status.ChaosName = "chaos-redis-dr-test"
+	r := newTestReconciler(cr, newTestPodChaos(chaosmeshv1alpha1.Injected, chaosmeshv1alpha1.RunningPhase))
+
+	res, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseInjectingChaos {
+		t.Errorf("Expected phase InjectingChaos, got %q", got.Status.Phase)
+	}
+	if got.Status.Chaos == nil || got.Status.Chaos.State != "Injected" || got.Status.Chaos.InjectedTargets != 1 {
+		t.Errorf("Expected 1 injected target, got %+v", got.Status.Chaos)
+	}
+	if res.RequeueAfter <= 0 || res.RequeueAfter > pollInterval {
+		t.Errorf("Expected requeue within %s, got %s", pollInterval, res.RequeueAfter)
+	}
+}
+
+func TestReconcileInjectingChaosAdvancesOnRecovery(t *testing.T) {
+	cr := newTestCR(chaosdrv1.PhaseInjectingChaos, time.Now())
+	cr.Status.ChaosName = "chaos-redis-dr-test"
+	r := newTestReconciler(cr, newTestPodChaos(chaosmeshv1alpha1.NotInjected, chaosmeshv1alpha1.StoppedPhase))
+
+	_, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseRestoring {
+		t.Errorf("Expected phase Restoring, got %q", got.Status.Phase)
+	}
+	if got.Status.Chaos == nil || got.Status.Chaos.State != "Recovered" {
+		t.Errorf("Expected recovered chaos, got %+v", got.Status.Chaos)
+	}
+}
+
+func TestReconcileInjectingChaosAdvancesAfterDuration(t *testing.T) {
+	cr := newTestCR(chaosdrv1.PhaseInjectingChaos, time.Now().Add(-2*chaos.DefaultDuration))
+	cr.Status.ChaosName = "chaos-redis-dr-test"
+	r := newTestReconciler(cr, newTestPodChaos(chaosmeshv1alpha1.Injected, chaosmeshv1alpha1.RunningPhase))
+
+	_, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseRestoring {
+		t.Errorf("Expected phase Restoring, got %q", got.Status.Phase)
+	}
+}
+
+func TestReconcileInjectingChaosFailsWithoutTargets(t *testing.T) {
+	cr := newTestCR(chaosdrv1.PhaseInjectingChaos, time.Now().Add(-2*chaos.DefaultDuration))
+	cr.Status.ChaosName = "chaos-redis-dr-test"
+	r := newTestReconciler(cr, newTestPodChaos("", chaosmeshv1alpha1.RunningPhase))
+
+	_, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseFailed {
+		t.Errorf("Expected phase Failed, got %q", got.Status.Phase)
+	}
+	cond := meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionChaosInjected)
+	if cond == nil || cond.Status != metav1.ConditionFalse {
+		t.Errorf("Expected ChaosInjected=False, got %+v", cond)
+	}
+}
+
+func TestReconcileTerminalPhaseIsNoop(t *testing.T) {
+	cr := newTestCR(chaosdrv1.PhaseSucceeded, time.Now())
+	cr.Status.Success = true
+	r := newTestReconciler(cr)
+
+	res, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseSucceeded || !got.Status.Success {
+		t.Errorf("Expected test to stay Succeeded, got %q", got.Status.Phase)
+	}
+	if res.RequeueAfter != 0 {
+		t.Errorf("Expected no requeue, got %s", res.RequeueAfter)
+	}
+}
+
+func TestReconcileRecordsConditionsAndTimings(t *testing.T) {
+	cr := newTestCR(chaosdrv1.PhaseInjectingChaos, time.Now())
+	cr.Generation = 3
+	cr.Status.ChaosName = "chaos-redis-dr-test"
+	cr.Status.PhaseTimings = []chaosdrv1.PhaseTiming{{Phase: chaosdrv1.PhaseInjectingChaos, StartTime: *cr.Status.PhaseStartTime}}
+	r := newTestReconciler(cr, newTestPodChaos(chaosmeshv1alpha1.NotInjected, chaosmeshv1alpha1.StoppedPhase))
+
+	_, got := reconcileOnce(t, r, cr)
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, chaosdrv1.ConditionChaosInjected) {
+		t.Error("Expected ChaosInjected condition to be True")
+	}
+	ready := meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionReady)
+	if ready == nil || ready.Status != metav1.ConditionFalse || ready.Reason != string(chaosdrv1.PhaseRestoring) {
+		t.Errorf("Expected Ready=False with reason Restoring, got %+v", ready)
+	}
+	if len(got.Status.PhaseTimings) != 2 || got.Status.PhaseTimings[0].FinishTime == nil {
+		t.Errorf("Expected finished InjectingChaos timing followed by Restoring, got %+v", got.Status.PhaseTimings)
+	}
+	if got.Status.ObservedGeneration != 3 {
+		t.Errorf("Expected observedGeneration 3, got %d", got.Status.ObservedGeneration)
+	}
+}
+
+func TestReconcileFailureSetsConditions(t *testing.T) {
+	cr := newTestCR(chaosdrv1.PhaseValidating, time.Now())
+	cr.Spec.ValidationConfig.APIEndpoint = "http://127.0.0.1:1/healthz"
+	r := newTestReconciler(cr)
+
+	_, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseFailed {
+		t.Fatalf("Expected phase Failed, got %q", got.Status.Phase)
+	}
+	validated := meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionValidated)
+	if validated == nil || validated.Status != metav1.ConditionFalse {
+		t.Errorf("Expected Validated=False, got %+v", validated)
+	}
+	ready := meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionReady)
+	if ready == nil || ready.Status != metav1.ConditionFalse || ready.Reason != "Failed" {
+		t.Errorf("Expected Ready=False with reason Failed, got %+v", ready)
+	}
+	if got.Status.CompletionTime == nil {
+		t.Error("Expected completion time to be set")
+	}
+}
+
+func TestReconcileBackingUpWaitsForVelero(t *testing.T) {
+	cr := newTestCR(chaosdrv1.PhaseBackingUp, time.Now())
+	r := newTestReconciler(cr)
+
+	res, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseBackingUp {
+		t.Fatalf("Expected phase BackingUp, got %q", got.Status.Phase)
+	}
+	if got.Status.BackupName != "dr-backup-redis-dr-test" || got.Status.Backup == nil {
+		t.Fatalf("Expected backup to be recorded, got %+v", got.Status)
+	}
+	if res.RequeueAfter != pollInterval {
+		t.Errorf("Expected requeue after %s, got %s", pollInterval, res.RequeueAfter)
+	}
+
+	bkp := &unstructured.Unstructured{}
+	bkp.SetAPIVersion("velero.io/v1")
+	bkp.SetKind("Backup")
+	key := types.NamespacedName{Namespace: velero.DefaultNamespace, Name: got.Status.BackupName}
+	if err := r.Get(context.Background(), key, bkp); err != nil {
+		t.Fatalf("Failed to get velero backup: %v", err)
+	}
+	bkp.Object["status"] = map[string]interface{}{"phase": "Completed", "warnings": int64(1)}
+	if err := r.Update(context.Background(), bkp); err != nil {
+		t.Fatalf("Failed to update velero backup: %v", err)
+	}
+
+	_, got = reconcileOnce(t, r, got)
+	if got.Status.Phase != chaosdrv1.PhaseInjectingChaos {
+		t.Errorf("Expected phase InjectingChaos, got %q", got.Status.Phase)
+	}
+	if got.Status.Backup.Phase != "Completed" || got.Status.Backup.Warnings != 1 {
+		t.Errorf("Expected completed backup with 1 warning, got %+v", got.Status.Backup)
+	}
+}
+
+func TestReconcilePendingRejectsUnknownBackupProvider(t *testing.T) {
+	cr := newTestCR("", time.Now())
+	cr.Status = chaosdrv1.ChaosDRTestStatus{}
+	cr.Spec.Backup = &chaosdrv1.BackupSpec{Provider: "tape"}
+	r := newTestReconciler(cr)
+
+	_, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseFailed {
+		t.Fatalf("Expected phase Failed, got %q", got.Status.Phase)
+	}
+	cond := meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionBackupCompleted)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "InvalidBackupProvider" {
+		t.Errorf("Expected BackupCompleted=False with reason InvalidBackupProvider, got %+v", cond)
+	}
+	if got.Status.BackupName != "" {
+		t.Errorf("Expected no backup to be started, got %q", got.Status.BackupName)
+	}
+}
+
+func TestReconcilePendingRejectsInvalidChaos(t *testing.T) {
+	cr := newTestCR("", time.Now())
+	cr.Status = chaosdrv1.ChaosDRTestStatus{}
+	cr.Spec.ChaosType = "network-delay"
+	cr.Spec.ChaosParameters = map[string]string{"dealy": "100ms"}
+	r := newTestReconciler(cr)
+
+	_, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseFailed {
+		t.Fatalf("Expected phase Failed, got %q", got.Status.Phase)
+	}
+	cond := meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionChaosInjected)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "InvalidChaosSpec" {
+		t.Errorf("Expected ChaosInjected=False with reason InvalidChaosSpec, got %+v", cond)
+	}
+}
+
+func TestReconcilePendingRejectsUnknownChaosProvider(t *testing.T) {
+	cr := newTestCR("", time.Now())
+	cr.Status = chaosdrv1.ChaosDRTestStatus{}
+	cr.Spec.Chaos = &chaosdrv1.ChaosSpec{
+		Type:      chaosdrv1.ChaosTypePodDelete,
+		Provider:  "gremlin",
+		PodDelete: &chaosdrv1.PodDeleteSpec{},
+	}
+	r := newTestReconciler(cr)
+
+	_, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseFailed {
+		t.Fatalf("Expected phase Failed, got %q", got.Status.Phase)
+	}
+	cond := meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionChaosInjected)
+	if cond == nil || cond.Reason != "InvalidChaosProvider" {
+		t.Errorf("Expected ChaosInjected=False with reason InvalidChaosProvider, got %+v", cond)
+	}
+}
+
+func TestReconcileAddsCleanupFinalizer(t *testing.T) {
+	cr := newTestCR("", time.Now())
+	cr.Status = chaosdrv1.ChaosDRTestStatus{}
+	r := newTestReconciler(cr)
+
+	_, got := reconcileOnce(t, r, cr)
+	if !controllerutil.ContainsFinalizer(got, cleanupFinalizer) {
+		t.Errorf("Expected finalizer %s, got %v", cleanupFinalizer, got.Finalizers)
+	}
+	if got.Status.Phase != chaosdrv1.PhaseBackingUp {
+		t.Errorf("Expected phase BackingUp, got %q", got.Status.Phase)
+	}
+
+	cr = newTestCR("", time.Now())
+	cr.Status = chaosdrv1.ChaosDRTestStatus{}
+	cr.Spec.CleanupPolicy = chaosdrv1.CleanupNever
+	r = newTestReconciler(cr)
+
+	_, got = reconcileOnce(t, r, cr)
+	if len(got.Finalizers) != 0 {
+		t.Errorf("Expected no finalizer with cleanupPolicy Never, got %v", got.Finalizers)
+	}
+}
+
+// newFinishedTestCR returns a test in phase that left chaos and a sandbox behind.
+func newFinishedTestCR(phase chaosdrv1.ChaosDRTestPhase) (*chaosdrv1.ChaosDRTest, []client.Object) {
+	cr := newTestCR(phase, time.Now())
+	cr.Finalizers = []string{cleanupFinalizer}
+	cr.Status.ChaosName = "chaos-redis-dr-test"
+	cr.Status.SandboxNamespace = "sandbox-redis-dr-test"
+	podChaos := &chaosmeshv1alpha1.PodChaos{ObjectMeta: metav1.ObjectMeta{Name: cr.Status.ChaosName, Namespace: cr.Namespace}}
+	sandbox := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: cr.Status.SandboxNamespace}}
+	return cr, []client.Object{cr, podChaos, sandbox}
+}
+
+// exists reports whether obj is still present.
+func exists(t *testing.T, r *ChaosDRTestReconciler, obj client.Object) bool {
+	t.Helper()
+	err := r.Get(context.Background(), client.ObjectKeyFromObject(obj), obj)
+	if err != nil && !errors.IsNotFound(err) {
+		t.Fatalf("Failed to get %s: %v", obj.GetName(), err)
+	}
+	return err == nil
+}
+
+func TestReconcileFinishedCleansUp(t *testing.T) {
+	cr, objs := newFinishedTestCR(chaosdrv1.PhaseFailed)
+	r := newTestReconciler(objs...)
+
+	_, got := reconcileOnce(t, r, cr)
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, chaosdrv1.ConditionCleanedUp) {
+		t.Errorf("Expected CleanedUp=True, got %+v", meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionCleanedUp))
+	}
+	if exists(t, r, objs[1]) {
+		t.Error("Expected PodChaos to be deleted")
+	}
+	if exists(t, r, objs[2]) {
+		t.Error("Expected sandbox namespace to be deleted")
+	}
+	if got.Status.Phase != chaosdrv1.PhaseFailed {
+		t.Errorf("Expected phase to stay Failed, got %q", got.Status.Phase)
+	}
+}
+
+func TestReconcileCleansUpAfterSpecEdit(t *testing.T) {
+	cr, objs := newFinishedTestCR(chaosdrv1.PhaseFailed)
+	cr.Status.ChaosType = chaosdrv1.ChaosTypePodDelete
+	cr.Status.ChaosProvider = chaos.ProviderChaosMesh
+	// The spec no longer describes chaos any provider accepts
+	cr.Spec.ChaosType = "no-such-chaos"
+	r := newTestReconciler(objs...)
+
+	_, got := reconcileOnce(t, r, cr)
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, chaosdrv1.ConditionCleanedUp) {
+		t.Errorf("Expected CleanedUp=True, got %+v", meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionCleanedUp))
+	}
+	if exists(t, r, objs[1]) {
+		t.Error("Expected PodChaos recorded in the status to be deleted")
+	}
+}
+
+func TestReconcileOnSuccessKeepsFailedTest(t *testing.T) {
+	cr, objs := newFinishedTestCR(chaosdrv1.PhaseFailed)
+	cr.Spec.CleanupPolicy = chaosdrv1.CleanupOnSuccess
+	r := newTestReconciler(objs...)
+
+	_, got := reconcileOnce(t, r, cr)
+	cond := meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionCleanedUp)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "Retained" {
+		t.Errorf("Expected CleanedUp=False with reason Retained, got %+v", cond)
+	}
+	if !exists(t, r, objs[1]) || !exists(t, r, objs[2]) {
+		t.Error("Expected chaos and sandbox to be kept")
+	}
+
+	// Deleting the test still removes what was kept
+	if err := r.Delete(context.Background(), got); err != nil {
+		t.Fatalf("Failed to delete ChaosDRTest: %v", err)
+	}
+	if _, err := r.Reconcile(context.Background(), ctrr.Request{NamespacedName: client.ObjectKeyFromObject(cr)}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if exists(t, r, objs[1]) || exists(t, r, objs[2]) {
+		t.Error("Expected chaos and sandbox to be deleted with the test")
+	}
+	if exists(t, r, &chaosdrv1.ChaosDRTest{ObjectMeta: cr.ObjectMeta}) {
+		t.Error("Expected ChaosDRTest to be gone once the finalizer is released")
+	}
+}
+
+func TestReconcileDeleteRemovesBackup(t *testing.T) {
+	cr, objs := newFinishedTestCR(chaosdrv1.PhaseSucceeded)
+	cr.Spec.DeleteBackup = true
+	cr.Status.Backup = &chaosdrv1.OperationStatus{Name: "dr-backup-redis-dr-test", Namespace: velero.DefaultNamespace}
+	cr.Status.Restore = &chaosdrv1.OperationStatus{Name: "dr-restore-redis-dr-test", Namespace: velero.DefaultNamespace}
+	r := newTestReconciler(objs...)
+
+	if err := r.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("Failed to delete ChaosDRTest: %v", err)
+	}
+	if _, err := r.Reconcile(context.Background(), ctrr.Request{NamespacedName: client.ObjectKeyFromObject(cr)}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	requests := &unstructured.UnstructuredList{}
+	requests.SetAPIVersion("velero.io/v1")
+	requests.SetKind("DeleteBackupRequestList")
+	if err := r.List(context.Background(), requests, client.InNamespace(velero.DefaultNamespace)); err != nil {
+		t.Fatalf("Failed to list DeleteBackupRequests: %v", err)
+	}
+	if len(requests.Items) != 1 {
+		t.Errorf("Expected 1 DeleteBackupRequest, got %d", len(requests.Items))
+	}
+	if exists(t, r, &chaosdrv1.ChaosDRTest{ObjectMeta: cr.ObjectMeta}) {
+		t.Error("Expected ChaosDRTest to be gone once the finalizer is released")
+	}
+}
@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrr "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+	"github.com/harrisin2037/chaos-dr-validator/internal/validation"
+)
+
+const (
+	// defaultRPOInterval is the time between markers when spec.rpoProbe.interval is unset
+	defaultRPOInterval = 5 * time.Second
+	// rpoReadTimeout bounds how long the restored app may fail to return its markers
+	rpoReadTimeout = 5 * time.Minute
+)
+
+// writeMarker writes a marker of the RPO probe once its interval has elapsed
+// since the last one. A marker that cannot be written is counted but does
+// not fail the test.
+func (r *ChaosDRTestReconciler) writeMarker(ctx context.Context, cr *chaosdrv1.ChaosDRTest) {
+	if cr.Spec.RPOProbe == nil {
+		return
+	}
+	if cr.Status.RPO == nil {
+		cr.Status.RPO = &chaosdrv1.RPOStatus{}
+	}
+	st := cr.Status.RPO
+	if st.LastMarker != nil && time.Since(st.LastMarker.Time) < rpoInterval(cr) {
+		return
+	}
+
+	// Status times have a precision of one second, as do the markers
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	if err := validation.NewRPOMarkers(r.APIReader, cr).Write(ctx, now.Time); err != nil {
+		log.FromContext(ctx).Info("Failed to write RPO marker", "error", err.Error())
+		st.WriteErrors++
+		st.LastError = err.Error()
+		return
+	}
+	st.MarkersWritten++
+	if st.FirstMarker == nil {
+		st.FirstMarker = &now
+	}
+	st.LastMarker = &now
+}
+
+// markFailure records the newest marker written before the chaos is
+// injected, which the data-loss window is measured up to: markers written
+// during the chaos cannot be in a backup taken before it.
+func markFailure(cr *chaosdrv1.ChaosDRTest) {
+	if st := cr.Status.RPO; st != nil && st.FailureMarker == nil {
+		st.FailureMarker = st.LastMarker
+	}
+}
+
+// rpoRequeue shortens the requeue of a test writing RPO markers so they are
+// written at their interval.
+func rpoRequeue(cr *chaosdrv1.ChaosDRTest, res ctrr.Result) ctrr.Result {
+	writing := cr.Status.Phase == chaosdrv1.PhaseBackingUp || cr.Status.Phase == chaosdrv1.PhaseInjectingChaos
+	if cr.Spec.RPOProbe != nil && writing && res.RequeueAfter > 0 {
+		res.RequeueAfter = min(res.RequeueAfter, rpoInterval(cr))
+	}
+	return res
+}
+
+// measureRPO finds the newest marker that survived in the restored app and
+// records the data-loss window up to the newest marker written before the
+// chaos was injected. It returns
+// false while the restored app cannot return its markers, and the reason
+// and error when no window can be measured or it exceeds maxDataLoss.
+func (r *ChaosDRTestReconciler) measureRPO(ctx context.Context, cr *chaosdrv1.ChaosDRTest) (bool, string, error) {
+	st := cr.Status.RPO
+	if cr.Spec.RPOProbe == nil || (st != nil && st.DataLoss != nil) {
+		return true, "", nil
+	}
+	if st == nil || st.FailureMarker == nil {
+		err := fmt.Errorf("the rpo probe wrote no marker before the chaos was injected")
+		if st != nil && st.LastError != "" {
+			err = fmt.Errorf("%w: %s", err, st.LastError)
+		}
+		setCondition(cr, chaosdrv1.ConditionRPOMet, metav1.ConditionFalse, "RPOProbeFailed", err.Error())
+		return false, "RPOProbeFailed", err
+	}
+
+	newest, err := validation.NewRPOMarkers(r.APIReader, cr).Newest(ctx)
+	if err != nil {
+		if phaseElapsed(cr) < rpoReadTimeout {
+			log.FromContext(ctx).Info("Restored app did not return its RPO markers, retrying", "error", err.Error())
+			return false, "", nil
+		}
+		err = fmt.Errorf("failed to read the rpo markers of the restored app: %w", err)
+		setCondition(cr, chaosdrv1.ConditionRPOMet, metav1.ConditionFalse, "RPOProbeFailed", err.Error())
+		return false, "RPOProbeFailed", err
+	}
+
+	var loss time.Duration
+	var msg string
+	if newest.IsZero() {
+		loss = st.FailureMarker.Sub(st.FirstMarker.Time)
+		msg = fmt.Sprintf("no marker survived the restore, %s of markers before the chaos were lost", loss)
+	} else {
+		restored := metav1.NewTime(newest)
+		st.NewestRestored = &restored
+		loss = max(st.FailureMarker.Sub(newest), 0)
+		msg = fmt.Sprintf("the newest marker restored was written %s before the chaos was injected", loss)
+	}
+	st.DataLoss = &metav1.Duration{Duration: loss}
+	rpoDataLoss.Set(loss.Seconds())
+	log.FromContext(ctx).Info("Measured RPO", "dataLoss", loss, "newestRestored", newest)
+
+	maxLoss := cr.Spec.RPOProbe.MaxDataLoss
+	if maxLoss != nil && loss > maxLoss.Duration {
+		err := fmt.Errorf("data loss of %s exceeds the maximum of %s: %s", loss, maxLoss.Duration, msg)
+		setCondition(cr, chaosdrv1.ConditionRPOMet, metav1.ConditionFalse, "RPOExceeded", err.Error())
+		return false, "RPOExceeded", err
+	}
+	setCondition(cr, chaosdrv1.ConditionRPOMet, metav1.ConditionTrue, "RPOMeasured", msg)
+	return true, "", nil
+}
+
+// deleteMarkers removes the RPO markers of cr from the source app. Failing
+// to do so is logged but does not hold up the cleanup.
+func (r *ChaosDRTestReconciler) deleteMarkers(ctx context.Context, cr *chaosdrv1.ChaosDRTest) {
+	if cr.Spec.RPOProbe == nil || cr.Status.RPO == nil {
+		return
+	}
+	if err := validation.NewRPOMarkers(r.APIReader, cr).Delete(ctx); err != nil {
+		log.FromContext(ctx).Error(err, "unable to delete RPO markers")
+	}
+}
+
+// rpoInterval returns the time between the RPO markers of cr.
+func rpoInterval(cr *chaosdrv1.ChaosDRTest) time.Duration {
+	if cr.Spec.RPOProbe.Interval != nil && cr.Spec.RPOProbe.Interval.Duration > 0 {
+		return cr.Spec.RPOProbe.Interval.Duration
+	}
+	return defaultRPOInterval
+}
@@ -0,0 +1,182 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+	"github.com/harrisin2037/chaos-dr-validator/internal/velero"
+)
+
+// newMarkerServer returns an http RPO probe against a server that stores the
+// markers posted to it and returns *restored as the newest one.
+func newMarkerServer(t *testing.T, restored *string) (*chaosdrv1.RPOProbeSpec, *int) {
+	t.Helper()
+	written := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			written++
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"marker": *restored})
+	}))
+	t.Cleanup(srv.Close)
+	return &chaosdrv1.RPOProbeSpec{
+		Interval: &metav1.Duration{Duration: 5 * time.Second},
+		HTTP:     &chaosdrv1.RPOHTTPTarget{URL: srv.URL + "/markers", ReadURL: srv.URL + "/markers/newest"},
+	}, &written
+}
+
+func TestReconcileBackingUpWritesRPOMarkers(t *testing.T) {
+	restored := ""
+	cr := newTestCR(chaosdrv1.PhaseBackingUp, time.Now())
+	probe, written := newMarkerServer(t, &restored)
+	cr.Spec.RPOProbe = probe
+	r := newTestReconciler(cr)
+
+	res, got := reconcileOnce(t, r, cr)
+	if got.Status.Backup == nil {
+		t.Fatalf("Expected the backup to start, got %+v", got.Status)
+	}
+	if res.RequeueAfter <= 0 || res.RequeueAfter > 5*time.Second {
+		t.Errorf("Expected requeue within the marker interval, got %s", res.RequeueAfter)
+	}
+	st := got.Status.RPO
+	if *written != 1 || st == nil || st.MarkersWritten != 1 || st.FirstMarker == nil || !st.LastMarker.Equal(st.FirstMarker) {
+		t.Fatalf("Expected one marker before the backup, got %d written, status %+v", *written, st)
+	}
+
+	// No marker is written before the interval has elapsed
+	_, got = reconcileOnce(t, r, got)
+	if *written != 1 || got.Status.RPO.MarkersWritten != 1 {
+		t.Errorf("Expected no new marker within the interval, got %d written", *written)
+	}
+
+	got.Status.RPO.LastMarker = &metav1.Time{Time: got.Status.RPO.LastMarker.Add(-time.Minute)}
+	if err := r.Status().Update(t.Context(), got); err != nil {
+		t.Fatalf("Failed to update status: %v", err)
+	}
+	_, got = reconcileOnce(t, r, got)
+	if *written != 2 || got.Status.RPO.MarkersWritten != 2 {
+		t.Errorf("Expected a second marker after the interval, got %d written", *written)
+	}
+}
+
+func TestReconcileBackingUpRecordsRPOFailureMarker(t *testing.T) {
+	restored := ""
+	cr := newTestCR(chaosdrv1.PhaseBackingUp, time.Now())
+	probe, written := newMarkerServer(t, &restored)
+	cr.Spec.RPOProbe = probe
+	r := newTestReconciler(cr)
+
+	_, got := reconcileOnce(t, r, cr)
+	if got.Status.RPO == nil || got.Status.RPO.FailureMarker != nil {
+		t.Fatalf("Expected no failure marker while backing up, got %+v", got.Status.RPO)
+	}
+	bkp := &unstructured.Unstructured{}
+	bkp.SetAPIVersion("velero.io/v1")
+	bkp.SetKind("Backup")
+	key := types.NamespacedName{Namespace: velero.DefaultNamespace, Name: got.Status.BackupName}
+	if err := r.Get(t.Context(), key, bkp); err != nil {
+		t.Fatalf("Failed to get velero backup: %v", err)
+	}
+	bkp.Object["status"] = map[string]interface{}{"phase": "Completed"}
+	if err := r.Update(t.Context(), bkp); err != nil {
+		t.Fatalf("Failed to update velero backup: %v", err)
+	}
+
+	_, got = reconcileOnce(t, r, got)
+	st := got.Status.RPO
+	if got.Status.Phase != chaosdrv1.PhaseInjectingChaos || st.FailureMarker == nil || !st.FailureMarker.Equal(st.LastMarker) {
+		t.Fatalf("Expected the last marker before the chaos as failure marker, got phase %q, %+v", got.Status.Phase, st)
+	}
+	failure := *st.FailureMarker
+
+	// Markers written during the chaos leave the failure marker alone
+	st.LastMarker = &metav1.Time{Time: st.LastMarker.Add(-time.Minute)}
+	if err := r.Status().Update(t.Context(), got); err != nil {
+		t.Fatalf("Failed to update status: %v", err)
+	}
+	_, got = reconcileOnce(t, r, got)
+	if *written != 2 || !got.Status.RPO.FailureMarker.Equal(&failure) {
+		t.Errorf("Expected a marker during the chaos and the failure marker %s, got %d written, %+v", failure.Time, *written, got.Status.RPO)
+	}
+}
+
+func TestReconcileValidatingMeasuresRPO(t *testing.T) {
+	first := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	failure := first.Add(20 * time.Second)
+	tests := []struct {
+		name     string
+		restored string
+		// chaos is how long markers were written after the failure marker
+		chaos   time.Duration
+		maxLoss time.Duration
+		loss    time.Duration
+		phase   chaosdrv1.ChaosDRTestPhase
+		reason  string
+	}{
+		{"within objective", "2026-10-01T12:00:15Z", 0, 10 * time.Second, 5 * time.Second, chaosdrv1.PhaseStoringProof, "RPOMeasured"},
+		{"exceeded", "2026-10-01T12:00:05Z", 0, 10 * time.Second, 15 * time.Second, chaosdrv1.PhaseFailed, "RPOExceeded"},
+		{"no marker survived", "", 0, time.Minute, 20 * time.Second, chaosdrv1.PhaseStoringProof, "RPOMeasured"},
+		{"long chaos", "2026-10-01T12:00:15Z", 30 * time.Minute, 10 * time.Second, 5 * time.Second, chaosdrv1.PhaseStoringProof, "RPOMeasured"},
+		{"no marker survived a long chaos", "", 30 * time.Minute, time.Minute, 20 * time.Second, chaosdrv1.PhaseStoringProof, "RPOMeasured"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restored := tt.restored
+			cr := newTestCR(chaosdrv1.PhaseValidating, time.Now())
+			probe, _ := newMarkerServer(t, &restored)
+			probe.MaxDataLoss = &metav1.Duration{Duration: tt.maxLoss}
+			cr.Spec.RPOProbe = probe
+			cr.Status.SandboxNamespace = "dr-sandbox-redis-dr-test"
+			cr.Status.RPO = &chaosdrv1.RPOStatus{
+				MarkersWritten: 5,
+				FirstMarker:    &metav1.Time{Time: first},
+				LastMarker:     &metav1.Time{Time: failure.Add(tt.chaos)},
+				FailureMarker:  &metav1.Time{Time: failure},
+			}
+			r := newTestReconciler(cr)
+
+			_, got := reconcileOnce(t, r, cr)
+			if got.Status.Phase != tt.phase {
+				t.Errorf("Expected phase %s, got %q", tt.phase, got.Status.Phase)
+			}
+			if got.Status.RPO.DataLoss == nil || got.Status.RPO.DataLoss.Duration != tt.loss {
+				t.Errorf("Expected a data loss of %s, got %+v", tt.loss, got.Status.RPO.DataLoss)
+			}
+			if (got.Status.RPO.NewestRestored == nil) != (tt.restored == "") {
+				t.Errorf("Expected the newest restored marker %q, got %v", tt.restored, got.Status.RPO.NewestRestored)
+			}
+			cond := meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionRPOMet)
+			if cond == nil || cond.Reason != tt.reason {
+				t.Errorf("Expected RPOMet with reason %s, got %+v", tt.reason, cond)
+			}
+		})
+	}
+}
+
+func TestReconcileValidatingFailsWithoutRPOMarkers(t *testing.T) {
+	restored := ""
+	cr := newTestCR(chaosdrv1.PhaseValidating, time.Now())
+	cr.Spec.RPOProbe, _ = newMarkerServer(t, &restored)
+	cr.Status.RPO = &chaosdrv1.RPOStatus{WriteErrors: 3, LastError: "connection refused"}
+	r := newTestReconciler(cr)
+
+	_, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseFailed {
+		t.Fatalf("Expected phase Failed, got %q", got.Status.Phase)
+	}
+	cond := meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionValidated)
+	if cond == nil || cond.Reason != "RPOProbeFailed" {
+		t.Errorf("Expected Validated=False with reason RPOProbeFailed, got %+v", cond)
+	}
+}
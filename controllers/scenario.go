@@ -0,0 +1,288 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrr "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+	"github.com/harrisin2037/chaos-dr-validator/internal/chaos"
+)
+
+// validateScenario checks every step of cr's scenario before anything is
+// backed up: step names must be unique, and each chaos valid, runnable by its
+// provider and within its blast radius. On error it also returns the reason
+// for the ChaosInjected condition.
+func (r *ChaosDRTestReconciler) validateScenario(ctx context.Context, cr *chaosdrv1.ChaosDRTest) (string, error) {
+	seen := map[string]bool{}
+	for i, step := range cr.Spec.Scenario {
+		name := stepName(i, step)
+		if seen[name] {
+			return "InvalidChaosSpec", fmt.Errorf("scenario step name %s is used twice", name)
+		}
+		seen[name] = true
+
+		specs := stepChaos(step)
+		if step.Wait == nil && len(specs) == 0 {
+			return "InvalidChaosSpec", fmt.Errorf("scenario step %s needs one of chaos, parallel or wait", name)
+		}
+		if step.Wait != nil && len(specs) > 0 {
+			return "InvalidChaosSpec", fmt.Errorf("scenario step %s must not both wait and inject chaos", name)
+		}
+		for j := range specs {
+			spec := &specs[j]
+			if err := chaos.ValidateSpec(spec); err != nil {
+				return "InvalidChaosSpec", fmt.Errorf("scenario step %s: %w", name, err)
+			}
+			if _, _, err := r.stepProvider(cr, step, spec); err != nil {
+				return "InvalidChaosProvider", fmt.Errorf("scenario step %s: %w", name, err)
+			}
+			if _, err := chaos.CheckBlastRadius(ctx, r.Client, cr, spec); err != nil {
+				return "BlastRadiusExceeded", fmt.Errorf("scenario step %s: %w", name, err)
+			}
+		}
+	}
+	return "", nil
+}
+
+// reconcileScenario runs the steps of spec.scenario one after another, each
+// advanced by at most one change per call, and moves on to the restore once
+// the last step has completed. Progress is tracked in status.scenario.
+func (r *ChaosDRTestReconciler) reconcileScenario(ctx context.Context, cr *chaosdrv1.ChaosDRTest) (ctrr.Result, error) {
+	if cr.Status.Scenario == nil {
+		for i, step := range cr.Spec.Scenario {
+			cr.Status.Scenario = append(cr.Status.Scenario, chaosdrv1.ScenarioStepStatus{Name: stepName(i, step), Phase: chaosdrv1.StepPending})
+		}
+	}
+	if len(cr.Status.Scenario) != len(cr.Spec.Scenario) {
+		return r.fail(ctx, cr, fmt.Errorf("spec.scenario changed while the test was running"))
+	}
+
+	i := slices.IndexFunc(cr.Status.Scenario, func(st chaosdrv1.ScenarioStepStatus) bool {
+		return st.Phase != chaosdrv1.StepCompleted
+	})
+	if i < 0 {
+		return r.transition(ctx, cr, chaosdrv1.PhaseRestoring)
+	}
+	step, st := cr.Spec.Scenario[i], &cr.Status.Scenario[i]
+	switch {
+	case st.Phase == chaosdrv1.StepPending:
+		return r.startStep(ctx, cr, step, st)
+	case step.Wait != nil:
+		if remaining := step.Wait.Duration - time.Since(st.StartTime.Time); remaining > 0 {
+			if err := r.updateStatus(ctx, cr); err != nil {
+				return ctrr.Result{}, err
+			}
+			return ctrr.Result{RequeueAfter: min(remaining, pollInterval)}, nil
+		}
+		return r.completeStep(ctx, cr, st)
+	default:
+		return r.watchStep(ctx, cr, step, st)
+	}
+}
+
+// startStep injects the chaos of a step, or starts its wait.
+func (r *ChaosDRTestReconciler) startStep(ctx context.Context, cr *chaosdrv1.ChaosDRTest, step chaosdrv1.ScenarioStep, st *chaosdrv1.ScenarioStepStatus) (ctrr.Result, error) {
+	now := metav1.Now()
+	st.Phase = chaosdrv1.StepRunning
+	st.StartTime = &now
+	st.Chaos = nil
+
+	specs := stepChaos(step)
+	for j := range specs {
+		spec := &specs[j]
+		providerName, provider, err := r.stepProvider(cr, step, spec)
+		if err != nil {
+			return r.failStep(ctx, cr, st, "InvalidChaosProvider", err)
+		}
+		// The app may have scaled up while it was backed up or an earlier step ran
+		if _, err := chaos.CheckBlastRadius(ctx, r.Client, cr, spec); err != nil {
+			return r.failStep(ctx, cr, st, "BlastRadiusExceeded", err)
+		}
+		name := stepChaosName(cr, st.Name, j, len(specs))
+		err = provider.Apply(ctx, stepTest(cr, step, spec), name, spec)
+		if err != nil && !errors.IsAlreadyExists(err) {
+			return r.failStep(ctx, cr, st, "Failed", err)
+		}
+		st.Chaos = append(st.Chaos, chaosdrv1.ScenarioChaosStatus{Name: name, Type: spec.Type, Provider: providerName})
+	}
+	log.FromContext(ctx).Info("Started scenario step", "step", st.Name, "chaos", len(st.Chaos))
+
+	if err := r.updateStatus(ctx, cr); err != nil {
+		return ctrr.Result{}, err
+	}
+	if step.Wait != nil {
+		return ctrr.Result{RequeueAfter: min(step.Wait.Duration, pollInterval)}, nil
+	}
+	return ctrr.Result{RequeueAfter: pollInterval}, nil
+}
+
+// watchStep follows the chaos of a running step. The step completes once all
+// of them have recovered, or when its duration has elapsed; chaos still
+// running then is stopped so it does not overlap the next step.
+func (r *ChaosDRTestReconciler) watchStep(ctx context.Context, cr *chaosdrv1.ChaosDRTest, step chaosdrv1.ScenarioStep, st *chaosdrv1.ScenarioStepStatus) (ctrr.Result, error) {
+	specs := stepChaos(step)
+	if len(specs) != len(st.Chaos) {
+		return r.failStep(ctx, cr, st, "Failed", fmt.Errorf("spec.scenario changed while the step was running"))
+	}
+
+	recovered, noTargets := true, ""
+	providers := make([]chaos.ChaosProvider, len(specs))
+	for j := range st.Chaos {
+		c := &st.Chaos[j]
+		provider, err := r.ChaosProviders.New(c.Provider, stepTest(cr, step, &specs[j]))
+		if err != nil {
+			return r.failStep(ctx, cr, st, "Failed", err)
+		}
+		providers[j] = provider
+		es, err := provider.Status(ctx, cr.Namespace, c.Name, c.Type)
+		if errors.IsNotFound(err) {
+			return r.failStep(ctx, cr, st, "Failed", err)
+		}
+		if err != nil {
+			return ctrr.Result{}, err
+		}
+		c.Status = &chaosdrv1.ChaosExperimentStatus{
+			State:           string(es.State),
+			InjectedTargets: es.InjectedTargets,
+			TotalTargets:    es.TotalTargets,
+			Message:         es.Message,
+		}
+		if es.State == chaos.ExperimentFailed {
+			return r.failStep(ctx, cr, st, "Failed", fmt.Errorf("chaos %s failed: %s", c.Name, es.Message))
+		}
+		if es.State != chaos.ExperimentRecovered {
+			recovered = false
+			if es.TotalTargets == 0 {
+				noTargets = c.Name
+			}
+		}
+	}
+
+	duration := stepDuration(cr, step)
+	remaining := duration - time.Since(st.StartTime.Time)
+	switch {
+	case recovered:
+		return r.completeStep(ctx, cr, st)
+	case remaining > 0:
+		if err := r.updateStatus(ctx, cr); err != nil {
+			return ctrr.Result{}, err
+		}
+		return ctrr.Result{RequeueAfter: min(remaining, pollInterval)}, nil
+	case noTargets != "":
+		return r.failStep(ctx, cr, st, "Failed", fmt.Errorf("chaos %s selected no targets within %s", noTargets, duration))
+	}
+
+	for j, c := range st.Chaos {
+		if c.Status.State == string(chaos.ExperimentRecovered) {
+			continue
+		}
+		log.FromContext(ctx).Info("Step duration elapsed before recovery was reported, stopping chaos", "step", st.Name, "chaos", c.Name, "state", c.Status.State)
+		if err := providers[j].Cleanup(ctx, cr.Namespace, c.Name, c.Type); err != nil {
+			return ctrr.Result{}, fmt.Errorf("failed to stop chaos %s: %w", c.Name, err)
+		}
+	}
+	return r.completeStep(ctx, cr, st)
+}
+
+// completeStep marks a step completed and requeues for the next one.
+func (r *ChaosDRTestReconciler) completeStep(ctx context.Context, cr *chaosdrv1.ChaosDRTest, st *chaosdrv1.ScenarioStepStatus) (ctrr.Result, error) {
+	now := metav1.Now()
+	st.Phase = chaosdrv1.StepCompleted
+	st.CompletionTime = &now
+	log.FromContext(ctx).Info("Completed scenario step", "step", st.Name)
+	if err := r.updateStatus(ctx, cr); err != nil {
+		return ctrr.Result{}, err
+	}
+	return ctrr.Result{RequeueAfter: phaseRequeueDelay}, nil
+}
+
+// failStep marks a step failed and fails the test.
+func (r *ChaosDRTestReconciler) failStep(ctx context.Context, cr *chaosdrv1.ChaosDRTest, st *chaosdrv1.ScenarioStepStatus, reason string, err error) (ctrr.Result, error) {
+	now := metav1.Now()
+	st.Phase = chaosdrv1.StepFailed
+	st.CompletionTime = &now
+	st.Message = err.Error()
+	return r.failWithReason(ctx, cr, reason, fmt.Errorf("scenario step %s: %w", st.Name, err))
+}
+
+// cleanupScenario removes the chaos every step of cr's scenario injected, as
+// recorded in the status; the spec may have been edited since.
+func (r *ChaosDRTestReconciler) cleanupScenario(ctx context.Context, cr *chaosdrv1.ChaosDRTest) error {
+	for _, st := range cr.Status.Scenario {
+		for _, c := range st.Chaos {
+			provider, err := r.ChaosProviders.Cleaner(c.Provider)
+			if err != nil {
+				return err
+			}
+			if err := provider.Cleanup(ctx, cr.Namespace, c.Name, c.Type); err != nil {
+				return fmt.Errorf("failed to clean up chaos %s: %w", c.Name, err)
+			}
+			log.FromContext(ctx).Info("Removed chaos experiment", "chaos", c.Name, "step", st.Name)
+		}
+	}
+	return nil
+}
+
+// stepProvider returns the name and an instance of the provider running spec.
+func (r *ChaosDRTestReconciler) stepProvider(cr *chaosdrv1.ChaosDRTest, step chaosdrv1.ScenarioStep, spec *chaosdrv1.ChaosSpec) (string, chaos.ChaosProvider, error) {
+	name := r.DefaultChaosProvider
+	if spec.Provider != "" {
+		name = spec.Provider
+	}
+	provider, err := r.ChaosProviders.New(name, stepTest(cr, step, spec))
+	return name, provider, err
+}
+
+// stepTest returns a copy of cr running spec alone for the step's duration.
+// Providers read the chaos and its duration from the test, so each chaos of
+// a scenario is handed to them this way.
+func stepTest(cr *chaosdrv1.ChaosDRTest, step chaosdrv1.ScenarioStep, spec *chaosdrv1.ChaosSpec) *chaosdrv1.ChaosDRTest {
+	test := cr.DeepCopy()
+	test.Spec.Scenario = nil
+	test.Spec.ChaosType = ""
+	test.Spec.ChaosParameters = nil
+	test.Spec.Chaos = spec
+	test.Spec.ChaosDuration = &metav1.Duration{Duration: stepDuration(cr, step)}
+	return test
+}
+
+// stepName returns the name of the i-th step, defaulting to step-<i+1>.
+func stepName(i int, step chaosdrv1.ScenarioStep) string {
+	if step.Name != "" {
+		return step.Name
+	}
+	return "step-" + strconv.Itoa(i+1)
+}
+
+// stepChaos returns the chaos a step injects.
+func stepChaos(step chaosdrv1.ScenarioStep) []chaosdrv1.ChaosSpec {
+	if step.Chaos != nil {
+		return []chaosdrv1.ChaosSpec{*step.Chaos}
+	}
+	return slices.Clone(step.Parallel)
+}
+
+// stepDuration returns how long the chaos of a step runs.
+func stepDuration(cr *chaosdrv1.ChaosDRTest, step chaosdrv1.ScenarioStep) time.Duration {
+	if step.Duration != nil && step.Duration.Duration > 0 {
+		return step.Duration.Duration
+	}
+	return chaos.Duration(cr)
+}
+
+// stepChaosName names the j-th of n chaos objects created by a step.
+func stepChaosName(cr *chaosdrv1.ChaosDRTest, step string, j, n int) string {
+	name := "chaos-" + cr.Name + "-" + step
+	if n > 1 {
+		name += "-" + strconv.Itoa(j+1)
+	}
+	return name
+}
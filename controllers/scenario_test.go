@@ -0,0 +1,239 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+// newScenarioTestCR returns a test in phase running a pod kill, a wait and a
+// parallel network delay and CPU stress.
+func newScenarioTestCR(phase chaosdrv1.ChaosDRTestPhase) *chaosdrv1.ChaosDRTest {
+	cr := newTestCR(phase, time.Now())
+	cr.Spec.ChaosType = ""
+	cr.Spec.Scenario = []chaosdrv1.ScenarioStep{
+		{
+			Name:     "kill",
+			Chaos:    &chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypePodDelete},
+			Duration: &metav1.Duration{Duration: time.Minute},
+		},
+		{Wait: &metav1.Duration{Duration: 30 * time.Second}},
+		{
+			Name: "degrade",
+			Parallel: []chaosdrv1.ChaosSpec{
+				{Type: chaosdrv1.ChaosTypeNetworkDelay, NetworkDelay: &chaosdrv1.NetworkDelaySpec{Latency: "100ms"}},
+				{Type: chaosdrv1.ChaosTypeCPUStress},
+			},
+		},
+	}
+	return cr
+}
+
+// setRecords replaces the experiment records of a chaos object.
+func setRecords(t *testing.T, r *ChaosDRTestReconciler, obj chaosmeshv1alpha1.StatefulObject, phase chaosmeshv1alpha1.Phase, desired chaosmeshv1alpha1.DesiredPhase) {
+	t.Helper()
+	if err := r.Get(context.Background(), client.ObjectKeyFromObject(obj), obj); err != nil {
+		t.Fatalf("Failed to get %s: %v", obj.GetName(), err)
+	}
+	status := obj.GetStatus()
+	status.Experiment.DesiredPhase = desired
+	status.Experiment.Records = []*chaosmeshv1alpha1.Record{{Id: "default/redis-0", SelectorKey: ".", Phase: phase}}
+	if err := r.Update(context.Background(), obj); err != nil {
+		t.Fatalf("Failed to update %s: %v", obj.GetName(), err)
+	}
+}
+
+// startStepEarlier moves the start of the i-th scenario step into the past.
+func startStepEarlier(t *testing.T, r *ChaosDRTestReconciler, cr *chaosdrv1.ChaosDRTest, i int, by time.Duration) {
+	t.Helper()
+	start := metav1.NewTime(cr.Status.Scenario[i].StartTime.Add(-by))
+	cr.Status.Scenario[i].StartTime = &start
+	if err := r.Status().Update(context.Background(), cr); err != nil {
+		t.Fatalf("Failed to update status: %v", err)
+	}
+}
+
+func TestReconcilePendingStartsScenario(t *testing.T) {
+	cr := newScenarioTestCR("")
+	cr.Status = chaosdrv1.ChaosDRTestStatus{}
+	r := newTestReconciler(cr)
+
+	_, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseBackingUp {
+		t.Fatalf("Expected phase BackingUp, got %q (%s)", got.Status.Phase, got.Status.ErrorMessage)
+	}
+	if got.Status.ChaosType != chaosdrv1.ChaosTypeScenario {
+		t.Errorf("Expected chaos type scenario, got %q", got.Status.ChaosType)
+	}
+}
+
+func TestReconcilePendingRejectsInvalidScenario(t *testing.T) {
+	tests := map[string]func(cr *chaosdrv1.ChaosDRTest){
+		"duplicate step name": func(cr *chaosdrv1.ChaosDRTest) { cr.Spec.Scenario[2].Name = "kill" },
+		"invalid chaos": func(cr *chaosdrv1.ChaosDRTest) {
+			cr.Spec.Scenario[2].Parallel[0].NetworkDelay = nil
+		},
+		"empty step": func(cr *chaosdrv1.ChaosDRTest) { cr.Spec.Scenario[1].Wait = nil },
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := newScenarioTestCR("")
+			cr.Status = chaosdrv1.ChaosDRTestStatus{}
+			mutate(cr)
+			r := newTestReconciler(cr)
+
+			_, got := reconcileOnce(t, r, cr)
+			if got.Status.Phase != chaosdrv1.PhaseFailed {
+				t.Fatalf("Expected phase Failed, got %q", got.Status.Phase)
+			}
+			cond := meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionChaosInjected)
+			if cond == nil || cond.Reason != "InvalidChaosSpec" {
+				t.Errorf("Expected ChaosInjected=False with reason InvalidChaosSpec, got %+v", cond)
+			}
+		})
+	}
+}
+
+func TestReconcileScenarioRunsStepsInOrder(t *testing.T) {
+	cr := newScenarioTestCR(chaosdrv1.PhaseInjectingChaos)
+	r := newTestReconciler(cr)
+
+	// The pod kill starts with its own duration
+	_, got := reconcileOnce(t, r, cr)
+	if len(got.Status.Scenario) != 3 || got.Status.Scenario[0].Phase != chaosdrv1.StepRunning {
+		t.Fatalf("Expected the first of three steps to run, got %+v", got.Status.Scenario)
+	}
+	if got.Status.Scenario[1].Name != "step-2" || got.Status.Scenario[1].Phase != chaosdrv1.StepPending {
+		t.Errorf("Expected step-2 to be pending, got %+v", got.Status.Scenario[1])
+	}
+	podChaos := &chaosmeshv1alpha1.PodChaos{ObjectMeta: metav1.ObjectMeta{Name: "chaos-redis-dr-test-kill", Namespace: "default"}}
+	if !exists(t, r, podChaos) {
+		t.Fatal("Expected PodChaos chaos-redis-dr-test-kill to be created")
+	}
+	if podChaos.Spec.Duration == nil || *podChaos.Spec.Duration != "1m0s" {
+		t.Errorf("Expected the step duration 1m0s, got %v", podChaos.Spec.Duration)
+	}
+
+	// Recovery completes the step
+	setRecords(t, r, podChaos, chaosmeshv1alpha1.NotInjected, chaosmeshv1alpha1.StoppedPhase)
+	_, got = reconcileOnce(t, r, cr)
+	if got.Status.Scenario[0].Phase != chaosdrv1.StepCompleted || got.Status.Scenario[0].Chaos[0].Status.State != "Recovered" {
+		t.Fatalf("Expected the pod kill to complete, got %+v", got.Status.Scenario[0])
+	}
+
+	// The wait runs for its duration
+	res, got := reconcileOnce(t, r, cr)
+	if got.Status.Scenario[1].Phase != chaosdrv1.StepRunning || res.RequeueAfter != pollInterval {
+		t.Fatalf("Expected the wait to run and poll, got %+v after %s", got.Status.Scenario[1], res.RequeueAfter)
+	}
+	_, got = reconcileOnce(t, r, cr)
+	if got.Status.Scenario[1].Phase != chaosdrv1.StepRunning {
+		t.Fatalf("Expected the wait to still run, got %+v", got.Status.Scenario[1])
+	}
+	startStepEarlier(t, r, got, 1, time.Minute)
+	_, got = reconcileOnce(t, r, cr)
+	if got.Status.Scenario[1].Phase != chaosdrv1.StepCompleted {
+		t.Fatalf("Expected the wait to complete, got %+v", got.Status.Scenario[1])
+	}
+
+	// The parallel group injects both chaos together
+	_, got = reconcileOnce(t, r, cr)
+	networkChaos := &chaosmeshv1alpha1.NetworkChaos{ObjectMeta: metav1.ObjectMeta{Name: "chaos-redis-dr-test-degrade-1", Namespace: "default"}}
+	stressChaos := &chaosmeshv1alpha1.StressChaos{ObjectMeta: metav1.ObjectMeta{Name: "chaos-redis-dr-test-degrade-2", Namespace: "default"}}
+	if !exists(t, r, networkChaos) || !exists(t, r, stressChaos) {
+		t.Fatalf("Expected both chaos of the parallel group, got %+v", got.Status.Scenario[2])
+	}
+
+	// Chaos still injected when the step's duration ends is stopped
+	setRecords(t, r, networkChaos, chaosmeshv1alpha1.Injected, chaosmeshv1alpha1.RunningPhase)
+	setRecords(t, r, stressChaos, chaosmeshv1alpha1.Injected, chaosmeshv1alpha1.RunningPhase)
+	startStepEarlier(t, r, got, 2, time.Hour)
+	_, got = reconcileOnce(t, r, cr)
+	if got.Status.Scenario[2].Phase != chaosdrv1.StepCompleted {
+		t.Fatalf("Expected the parallel step to complete, got %+v", got.Status.Scenario[2])
+	}
+	if exists(t, r, networkChaos) || exists(t, r, stressChaos) {
+		t.Error("Expected chaos still running after the step to be stopped")
+	}
+
+	_, got = reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseRestoring {
+		t.Errorf("Expected phase Restoring after the last step, got %q", got.Status.Phase)
+	}
+}
+
+func TestReconcileScenarioFailsStep(t *testing.T) {
+	cr := newScenarioTestCR(chaosdrv1.PhaseInjectingChaos)
+	r := newTestReconciler(cr)
+
+	reconcileOnce(t, r, cr)
+	podChaos := &chaosmeshv1alpha1.PodChaos{ObjectMeta: metav1.ObjectMeta{Name: "chaos-redis-dr-test-kill", Namespace: "default"}}
+	if err := r.Get(context.Background(), client.ObjectKeyFromObject(podChaos), podChaos); err != nil {
+		t.Fatalf("Failed to get PodChaos: %v", err)
+	}
+	podChaos.Status.Conditions = []chaosmeshv1alpha1.ChaosCondition{
+		{Type: chaosmeshv1alpha1.ConditionSelected, Status: corev1.ConditionFalse, Reason: "no pod is selected"},
+	}
+	if err := r.Update(context.Background(), podChaos); err != nil {
+		t.Fatalf("Failed to update PodChaos: %v", err)
+	}
+
+	_, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseFailed {
+		t.Fatalf("Expected phase Failed, got %q", got.Status.Phase)
+	}
+	if st := got.Status.Scenario[0]; st.Phase != chaosdrv1.StepFailed || st.Message == "" {
+		t.Errorf("Expected the pod kill to fail with a message, got %+v", st)
+	}
+	if got.Status.Scenario[1].Phase != chaosdrv1.StepPending {
+		t.Errorf("Expected later steps to stay pending, got %+v", got.Status.Scenario[1])
+	}
+}
+
+func TestReconcileFinishedCleansUpScenario(t *testing.T) {
+	for _, edited := range []bool{false, true} {
+		t.Run(fmt.Sprintf("edited=%v", edited), func(t *testing.T) {
+			testCleanupScenario(t, edited)
+		})
+	}
+}
+
+// testCleanupScenario cleans up a finished scenario, with its steps removed
+// from the spec after they ran if edited is set.
+func testCleanupScenario(t *testing.T, edited bool) {
+	cr := newScenarioTestCR(chaosdrv1.PhaseFailed)
+	cr.Finalizers = []string{cleanupFinalizer}
+	if edited {
+		cr.Spec.Scenario = cr.Spec.Scenario[:1]
+		cr.Spec.Scenario[0].Chaos = &chaosdrv1.ChaosSpec{Type: "no-such-chaos"}
+	}
+	cr.Status.Scenario = []chaosdrv1.ScenarioStepStatus{
+		{Name: "kill", Phase: chaosdrv1.StepCompleted, Chaos: []chaosdrv1.ScenarioChaosStatus{
+			{Name: "chaos-redis-dr-test-kill", Type: chaosdrv1.ChaosTypePodDelete, Provider: "chaos-mesh"},
+		}},
+		{Name: "step-2", Phase: chaosdrv1.StepCompleted},
+		{Name: "degrade", Phase: chaosdrv1.StepFailed, Chaos: []chaosdrv1.ScenarioChaosStatus{
+			{Name: "chaos-redis-dr-test-degrade-1", Type: chaosdrv1.ChaosTypeNetworkDelay, Provider: "chaos-mesh"},
+		}},
+	}
+	podChaos := &chaosmeshv1alpha1.PodChaos{ObjectMeta: metav1.ObjectMeta{Name: "chaos-redis-dr-test-kill", Namespace: "default"}}
+	networkChaos := &chaosmeshv1alpha1.NetworkChaos{ObjectMeta: metav1.ObjectMeta{Name: "chaos-redis-dr-test-degrade-1", Namespace: "default"}}
+	r := newTestReconciler(cr, podChaos, networkChaos)
+
+	_, got := reconcileOnce(t, r, cr)
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, chaosdrv1.ConditionCleanedUp) {
+		t.Errorf("Expected CleanedUp=True, got %+v", meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionCleanedUp))
+	}
+	if exists(t, r, podChaos) || exists(t, r, networkChaos) {
+		t.Error("Expected the chaos of every step to be deleted")
+	}
+}
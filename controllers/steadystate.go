@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrr "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+	"github.com/harrisin2037/chaos-dr-validator/internal/probe"
+)
+
+// defaultMaxSamples is the number of samples kept per probe when
+// spec.steadyState.maxSamples is unset
+const defaultMaxSamples = 60
+
+// validateProbes rejects probes sharing a name, and command probes unless the
+// operator allows them. It returns the condition reason with the error.
+func (r *ChaosDRTestReconciler) validateProbes(cr *chaosdrv1.ChaosDRTest) (string, error) {
+	if cr.Spec.SteadyState == nil {
+		return "", nil
+	}
+	seen := map[string]bool{}
+	for _, p := range cr.Spec.SteadyState.Probes {
+		if seen[p.Name] {
+			return "InvalidProbe", fmt.Errorf("steady-state probe name %s is used twice", p.Name)
+		}
+		seen[p.Name] = true
+
+		if p.Command != nil && !r.AllowCommandProbes {
+			return "CommandProbesDisabled", fmt.Errorf("steady-state probe %s runs a command in the operator's container and requires the operator's --allow-command-probes", p.Name)
+		}
+	}
+	return "", nil
+}
+
+// probeBaseline runs the steady-state probes before anything is backed up. It
+// returns an error naming the failed probes when the baseline does not hold.
+func (r *ChaosDRTestReconciler) probeBaseline(ctx context.Context, cr *chaosdrv1.ChaosDRTest) error {
+	if cr.Spec.SteadyState == nil {
+		return nil
+	}
+	if failed := r.runProbes(ctx, cr); len(failed) > 0 {
+		err := fmt.Errorf("steady state does not hold before the test: %s", strings.Join(failed, "; "))
+		setCondition(cr, chaosdrv1.ConditionSteadyState, metav1.ConditionFalse, "BaselineFailed", err.Error())
+		return err
+	}
+	setCondition(cr, chaosdrv1.ConditionSteadyState, metav1.ConditionTrue, "BaselineConfirmed",
+		fmt.Sprintf("all %d steady-state probes passed before the backup", len(cr.Spec.SteadyState.Probes)))
+	return nil
+}
+
+// probeDuringChaos runs the steady-state probes once their interval has
+// elapsed since the last run. A deviation is recorded on the SteadyState
+// condition but does not fail the test.
+func (r *ChaosDRTestReconciler) probeDuringChaos(ctx context.Context, cr *chaosdrv1.ChaosDRTest) {
+	if cr.Spec.SteadyState == nil || time.Since(lastProbeTime(cr)) < probeInterval(cr) {
+		return
+	}
+	if failed := r.runProbes(ctx, cr); len(failed) > 0 {
+		msg := fmt.Sprintf("steady state deviated during chaos: %s", strings.Join(failed, "; "))
+		log.FromContext(ctx).Info("Steady state deviated", "failed", failed)
+		setCondition(cr, chaosdrv1.ConditionSteadyState, metav1.ConditionFalse, "DeviatedDuringChaos", msg)
+		return
+	}
+	setCondition(cr, chaosdrv1.ConditionSteadyState, metav1.ConditionTrue, "HeldDuringChaos",
+		fmt.Sprintf("all %d steady-state probes passed during chaos", len(cr.Spec.SteadyState.Probes)))
+}
+
+// steadyStateRequeue shortens the requeue of a test under chaos so its probes
+// run at their interval.
+func steadyStateRequeue(cr *chaosdrv1.ChaosDRTest, res ctrr.Result) ctrr.Result {
+	if cr.Spec.SteadyState != nil && cr.Status.Phase == chaosdrv1.PhaseInjectingChaos && res.RequeueAfter > 0 {
+		res.RequeueAfter = min(res.RequeueAfter, probeInterval(cr))
+	}
+	return res
+}
+
+// runProbes runs every probe of cr concurrently, appends a sample for each to
+// the status and returns a description of each probe that failed. A command
+// probe added after the test started fails unless the operator allows them.
+func (r *ChaosDRTestReconciler) runProbes(ctx context.Context, cr *chaosdrv1.ChaosDRTest) []string {
+	probes := cr.Spec.SteadyState.Probes
+	results := make([]probe.Result, len(probes))
+	var wg sync.WaitGroup
+	for i := range probes {
+		if probes[i].Command != nil && !r.AllowCommandProbes {
+			results[i] = probe.Result{Message: "command probes require the operator's --allow-command-probes"}
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = probe.Run(ctx, &probes[i])
+		}()
+	}
+	wg.Wait()
+
+	now := metav1.Now()
+	phase := cr.Status.Phase
+	if phase == "" {
+		phase = chaosdrv1.PhasePending
+	}
+	var failed []string
+	for i, res := range results {
+		recordSample(cr, probes[i].Name, chaosdrv1.ProbeSample{
+			Time:    now,
+			Phase:   phase,
+			Passed:  res.Passed,
+			Value:   res.Value,
+			Message: res.Message,
+		})
+		if !res.Passed {
+			failed = append(failed, fmt.Sprintf("%s: %s", probes[i].Name, res.Message))
+		}
+	}
+	return failed
+}
+
+// recordSample appends sample to the series of the named probe, dropping the
+// oldest samples beyond maxSamples.
+func recordSample(cr *chaosdrv1.ChaosDRTest, name string, sample chaosdrv1.ProbeSample) {
+	var st *chaosdrv1.ProbeStatus
+	for i := range cr.Status.SteadyState {
+		if cr.Status.SteadyState[i].Name == name {
+			st = &cr.Status.SteadyState[i]
+		}
+	}
+	if st == nil {
+		cr.Status.SteadyState = append(cr.Status.SteadyState, chaosdrv1.ProbeStatus{Name: name})
+		st = &cr.Status.SteadyState[len(cr.Status.SteadyState)-1]
+	}
+	st.Samples = append(st.Samples, sample)
+	maxSamples := cr.Spec.SteadyState.MaxSamples
+	if maxSamples <= 0 {
+		maxSamples = defaultMaxSamples
+	}
+	if n := len(st.Samples); n > maxSamples {
+		st.Samples = st.Samples[n-maxSamples:]
+	}
+}
+
+// lastProbeTime returns when the probes of cr last ran, or the zero time.
+func lastProbeTime(cr *chaosdrv1.ChaosDRTest) time.Time {
+	var last time.Time
+	for _, st := range cr.Status.SteadyState {
+		if n := len(st.Samples); n > 0 && st.Samples[n-1].Time.After(last) {
+			last = st.Samples[n-1].Time.Time
+		}
+	}
+	return last
+}
+
+// probeInterval returns how often the probes of cr run during chaos.
+func probeInterval(cr *chaosdrv1.ChaosDRTest) time.Duration {
+	if cr.Spec.SteadyState.Interval != nil && cr.Spec.SteadyState.Interval.Duration > 0 {
+		return cr.Spec.SteadyState.Interval.Duration
+	}
+	return pollInterval
+}
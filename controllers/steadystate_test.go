@@ -0,0 +1,187 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+// newHealthServer returns a server answering /healthz with the status in *code.
+func newHealthServer(t *testing.T, code *int) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(*code)
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL + "/healthz"
+}
+
+func newSteadyState(url string) *chaosdrv1.SteadyStateSpec {
+	return &chaosdrv1.SteadyStateSpec{
+		Probes: []chaosdrv1.ProbeSpec{
+			{Name: "healthz", HTTP: &chaosdrv1.HTTPProbe{URL: url}},
+			{Name: "script", Command: &chaosdrv1.CommandProbe{Command: "true"}},
+		},
+		Interval: &metav1.Duration{Duration: 5 * time.Second},
+	}
+}
+
+func TestReconcilePendingConfirmsBaseline(t *testing.T) {
+	code := http.StatusOK
+	cr := newTestCR("", time.Now())
+	cr.Status = chaosdrv1.ChaosDRTestStatus{}
+	cr.Spec.SteadyState = newSteadyState(newHealthServer(t, &code))
+	r := newTestReconciler(cr)
+	r.AllowCommandProbes = true
+
+	_, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseBackingUp {
+		t.Fatalf("Expected phase BackingUp, got %q", got.Status.Phase)
+	}
+	if len(got.Status.SteadyState) != 2 {
+		t.Fatalf("Expected a series per probe, got %+v", got.Status.SteadyState)
+	}
+	sample := got.Status.SteadyState[0].Samples[0]
+	if !sample.Passed || sample.Phase != chaosdrv1.PhasePending || sample.Value != "200" {
+		t.Errorf("Expected a passing baseline sample with value 200, got %+v", sample)
+	}
+	cond := meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionSteadyState)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != "BaselineConfirmed" {
+		t.Errorf("Expected SteadyState=True with reason BaselineConfirmed, got %+v", cond)
+	}
+}
+
+func TestReconcilePendingFailsOnBrokenBaseline(t *testing.T) {
+	code := http.StatusServiceUnavailable
+	cr := newTestCR("", time.Now())
+	cr.Status = chaosdrv1.ChaosDRTestStatus{}
+	cr.Spec.SteadyState = newSteadyState(newHealthServer(t, &code))
+	r := newTestReconciler(cr)
+	r.AllowCommandProbes = true
+
+	_, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseFailed {
+		t.Fatalf("Expected phase Failed, got %q", got.Status.Phase)
+	}
+	if got.Status.BackupName != "" || got.Status.ChaosName != "" {
+		t.Errorf("Expected nothing to be backed up or injected, got backup %q chaos %q", got.Status.BackupName, got.Status.ChaosName)
+	}
+	cond := meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionSteadyState)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "BaselineFailed" {
+		t.Errorf("Expected SteadyState=False with reason BaselineFailed, got %+v", cond)
+	}
+}
+
+func TestReconcilePendingRejectsCommandProbes(t *testing.T) {
+	code := http.StatusOK
+	cr := newTestCR("", time.Now())
+	cr.Status = chaosdrv1.ChaosDRTestStatus{}
+	cr.Spec.SteadyState = newSteadyState(newHealthServer(t, &code))
+	r := newTestReconciler(cr)
+
+	_, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseFailed {
+		t.Fatalf("Expected phase Failed, got %q", got.Status.Phase)
+	}
+	if len(got.Status.SteadyState) != 0 {
+		t.Errorf("Expected no probe to run, got %+v", got.Status.SteadyState)
+	}
+	cond := meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionSteadyState)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "CommandProbesDisabled" {
+		t.Errorf("Expected SteadyState=False with reason CommandProbesDisabled, got %+v", cond)
+	}
+}
+
+func TestReconcilePendingRejectsDuplicateProbeNames(t *testing.T) {
+	code := http.StatusOK
+	cr := newTestCR("", time.Now())
+	cr.Status = chaosdrv1.ChaosDRTestStatus{}
+	cr.Spec.SteadyState = newSteadyState(newHealthServer(t, &code))
+	cr.Spec.SteadyState.Probes[1] = cr.Spec.SteadyState.Probes[0]
+	r := newTestReconciler(cr)
+
+	_, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseFailed {
+		t.Fatalf("Expected phase Failed, got %q", got.Status.Phase)
+	}
+	cond := meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionSteadyState)
+	if cond == nil || cond.Reason != "InvalidProbe" || !strings.Contains(cond.Message, "healthz") {
+		t.Errorf("Expected SteadyState=False with reason InvalidProbe naming the probe, got %+v", cond)
+	}
+}
+
+func TestRunProbesRefusesCommandProbes(t *testing.T) {
+	code := http.StatusOK
+	cr := newTestCR(chaosdrv1.PhaseInjectingChaos, time.Now())
+	cr.Spec.SteadyState = newSteadyState(newHealthServer(t, &code))
+	r := newTestReconciler(cr)
+
+	// A command probe added once the test has started is not run either
+	failed := r.runProbes(t.Context(), cr)
+	if len(failed) != 1 || !strings.HasPrefix(failed[0], "script: ") {
+		t.Fatalf("Expected only the command probe to fail, got %q", failed)
+	}
+}
+
+func TestReconcileInjectingChaosRecordsSteadyState(t *testing.T) {
+	code := http.StatusOK
+	cr := newTestCR(chaosdrv1.PhaseInjectingChaos, time.Now())
+	cr.Spec.SteadyState = newSteadyState(newHealthServer(t, &code))
+	cr.Status.ChaosName = "chaos-redis-dr-test"
+	r := newTestReconciler(cr, newTestPodChaos(chaosmeshv1alpha1.Injected, chaosmeshv1alpha1.RunningPhase))
+	r.AllowCommandProbes = true
+
+	res, got := reconcileOnce(t, r, cr)
+	if res.RequeueAfter <= 0 || res.RequeueAfter > 5*time.Second {
+		t.Errorf("Expected requeue within the probe interval, got %s", res.RequeueAfter)
+	}
+	if len(got.Status.SteadyState) != 2 || len(got.Status.SteadyState[0].Samples) != 1 {
+		t.Fatalf("Expected one sample per probe, got %+v", got.Status.SteadyState)
+	}
+
+	// The probes do not run again before their interval
+	code = http.StatusInternalServerError
+	_, got = reconcileOnce(t, r, got)
+	if n := len(got.Status.SteadyState[0].Samples); n != 1 {
+		t.Errorf("Expected no new sample within the interval, got %d", n)
+	}
+
+	// A deviation during chaos is recorded but does not fail the test
+	got.Status.SteadyState[0].Samples[0].Time = metav1.NewTime(time.Now().Add(-time.Minute))
+	got.Status.SteadyState[1].Samples[0].Time = metav1.NewTime(time.Now().Add(-time.Minute))
+	if err := r.Status().Update(t.Context(), got); err != nil {
+		t.Fatalf("Failed to update status: %v", err)
+	}
+	_, got = reconcileOnce(t, r, got)
+	if got.Status.Phase != chaosdrv1.PhaseInjectingChaos {
+		t.Errorf("Expected phase InjectingChaos, got %q", got.Status.Phase)
+	}
+	samples := got.Status.SteadyState[0].Samples
+	if len(samples) != 2 || samples[1].Passed || samples[1].Phase != chaosdrv1.PhaseInjectingChaos {
+		t.Errorf("Expected a failed sample during chaos, got %+v", samples)
+	}
+	cond := meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionSteadyState)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "DeviatedDuringChaos" {
+		t.Errorf("Expected SteadyState=False with reason DeviatedDuringChaos, got %+v", cond)
+	}
+}
+
+func TestRecordSampleDropsOldest(t *testing.T) {
+	cr := newTestCR(chaosdrv1.PhaseInjectingChaos, time.Now())
+	cr.Spec.SteadyState = &chaosdrv1.SteadyStateSpec{MaxSamples: 3}
+	for i := range 5 {
+		recordSample(cr, "healthz", chaosdrv1.ProbeSample{Value: string(rune('a' + i))})
+	}
+	samples := cr.Status.SteadyState[0].Samples
+	if len(samples) != 3 || samples[0].Value != "c" || samples[2].Value != "e" {
+		t.Errorf("Expected the last 3 samples, got %+v", samples)
+	}
+}
@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+	"github.com/harrisin2037/chaos-dr-validator/internal/validation"
+)
+
+// runChecks runs every validation check of cr that has not finished yet and
+// records the results in status.validation. It reports whether all checks
+// have finished. A check whose validator fails to run is recorded as failed
+// with the error as its message.
+func (r *ChaosDRTestReconciler) runChecks(ctx context.Context, cr *chaosdrv1.ChaosDRTest) bool {
+	checks := validation.Checks(cr)
+	if cr.Status.Validation == nil {
+		for _, check := range checks {
+			cr.Status.Validation = append(cr.Status.Validation, chaosdrv1.ValidationResult{Name: check.Name, Type: check.Type})
+		}
+	}
+
+	done := true
+	for i := range checks {
+		check, st := &checks[i], &cr.Status.Validation[i]
+		if st.Duration != nil {
+			continue
+		}
+		if st.StartTime == nil {
+			now := metav1.Now()
+			st.StartTime = &now
+		}
+		validator, err := r.Validators.New(check.Type, cr)
+		if err != nil {
+			recordResult(ctx, st, &validation.Result{Message: err.Error()})
+			continue
+		}
+		res, err := validator.Validate(ctx, check)
+		if err != nil {
+			recordResult(ctx, st, &validation.Result{Message: err.Error()})
+			continue
+		}
+		if res == nil {
+			done = false
+			continue
+		}
+		recordResult(ctx, st, res)
+	}
+	return done
+}
+
+// captureSources lets every validator of cr that compares the restore with
+// the source app record the source. It reports whether all captures are done.
+func (r *ChaosDRTestReconciler) captureSources(ctx context.Context, cr *chaosdrv1.ChaosDRTest) (bool, error) {
+	checks := validation.Checks(cr)
+	done := true
+	for i := range checks {
+		check := &checks[i]
+		validator, err := r.Validators.New(check.Type, cr)
+		if err != nil {
+			return false, fmt.Errorf("validation check %s: %w", check.Name, err)
+		}
+		capturer, ok := validator.(validation.Capturer)
+		if !ok {
+			continue
+		}
+		captured, err := capturer.Capture(ctx, check)
+		if err != nil {
+			return false, fmt.Errorf("validation check %s: %w", check.Name, err)
+		}
+		done = done && captured
+	}
+	return done, nil
+}
+
+// recordResult stores the outcome of a finished check in st.
+func recordResult(ctx context.Context, st *chaosdrv1.ValidationResult, res *validation.Result) {
+	st.Passed = res.Passed
+	st.Observed = res.Observed
+	st.Expected = res.Expected
+	st.Message = res.Message
+	st.Job = res.Job
+	st.Diff = res.Diff
+	st.Duration = &metav1.Duration{Duration: time.Since(st.StartTime.Time).Round(time.Millisecond)}
+	log.FromContext(ctx).Info("Validation check finished", "check", st.Name, "type", st.Type, "passed", st.Passed,
+		"observed", st.Observed, "expected", st.Expected, "duration", st.Duration.Duration)
+}
+
+// failedChecks describes each validation check of cr that did not pass.
+func failedChecks(cr *chaosdrv1.ChaosDRTest) []string {
+	var failed []string
+	for _, st := range cr.Status.Validation {
+		if !st.Passed {
+			failed = append(failed, fmt.Sprintf("%s: %s", st.Name, st.Message))
+		}
+	}
+	return failed
+}
@@ -0,0 +1,184 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+	"github.com/harrisin2037/chaos-dr-validator/internal/validation"
+)
+
+// pendingValidator reports its checks as running until done is set.
+type pendingValidator struct {
+	done *bool
+}
+
+func (v pendingValidator) Validate(context.Context, *chaosdrv1.ValidationCheck) (*validation.Result, error) {
+	if !*v.done {
+		return nil, nil
+	}
+	return &validation.Result{Passed: true, Observed: "ready", Expected: "ready"}, nil
+}
+
+// capturingValidator captures the source app once captured is set.
+type capturingValidator struct {
+	pendingValidator
+	captured *bool
+}
+
+func (v capturingValidator) Capture(context.Context, *chaosdrv1.ValidationCheck) (bool, error) {
+	return *v.captured, nil
+}
+
+// erroringValidator fails to run every check.
+type erroringValidator struct{}
+
+func (erroringValidator) Validate(context.Context, *chaosdrv1.ValidationCheck) (*validation.Result, error) {
+	return nil, errors.New("connection refused")
+}
+
+// httpCheck returns an http check of path on a server where only /healthz is up.
+func httpCheck(t *testing.T, name, path string) chaosdrv1.ValidationCheck {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return chaosdrv1.ValidationCheck{Name: name, Type: chaosdrv1.ValidationTypeHTTP, HTTP: &chaosdrv1.HTTPCheck{URL: srv.URL + path}}
+}
+
+func TestReconcileValidatingRecordsEveryCheck(t *testing.T) {
+	cr := newTestCR(chaosdrv1.PhaseValidating, time.Now())
+	cr.Spec.ValidationConfig.Checks = []chaosdrv1.ValidationCheck{
+		httpCheck(t, "down", "/missing"),
+		httpCheck(t, "ping", "/healthz"),
+	}
+	r := newTestReconciler(cr)
+
+	_, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseFailed {
+		t.Fatalf("Expected phase Failed, got %q", got.Status.Phase)
+	}
+	if len(got.Status.Validation) != 2 {
+		t.Fatalf("Expected a result per check, got %+v", got.Status.Validation)
+	}
+	down, ping := got.Status.Validation[0], got.Status.Validation[1]
+	if down.Passed || down.Observed != "status code 404" || down.Expected != "status code 200" || down.Duration == nil {
+		t.Errorf("Expected a failed result with status code 404, got %+v", down)
+	}
+	if !ping.Passed || ping.Observed != "status code 200" {
+		t.Errorf("Expected the check after a failure to run and pass, got %+v", ping)
+	}
+	cond := meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionValidated)
+	if cond == nil || cond.Reason != "ValidationFailed" || !strings.Contains(cond.Message, "down") {
+		t.Errorf("Expected Validated=False with reason ValidationFailed naming the check, got %+v", cond)
+	}
+}
+
+func TestReconcileValidatingRecordsValidatorErrors(t *testing.T) {
+	cr := newTestCR(chaosdrv1.PhaseValidating, time.Now())
+	cr.Spec.ValidationConfig.Checks = []chaosdrv1.ValidationCheck{
+		httpCheck(t, "ping", "/healthz"),
+		{Name: "db", Type: "erroring"},
+	}
+	r := newTestReconciler(cr)
+	r.Validators.Register("erroring", func(*chaosdrv1.ChaosDRTest) (validation.Validator, error) {
+		return erroringValidator{}, nil
+	})
+
+	_, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseFailed {
+		t.Fatalf("Expected phase Failed, got %q", got.Status.Phase)
+	}
+	ping, db := got.Status.Validation[0], got.Status.Validation[1]
+	if !ping.Passed || ping.Duration == nil {
+		t.Errorf("Expected the earlier check to be recorded, got %+v", ping)
+	}
+	if db.Passed || db.Message != "connection refused" || db.Duration == nil {
+		t.Errorf("Expected the validator error as a failed result, got %+v", db)
+	}
+	cond := meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionValidated)
+	if cond == nil || cond.Reason != "ValidationFailed" || !strings.Contains(cond.Message, "connection refused") {
+		t.Errorf("Expected Validated=False with reason ValidationFailed naming the error, got %+v", cond)
+	}
+}
+
+func TestReconcileValidatingWaitsForRunningChecks(t *testing.T) {
+	cr := newTestCR(chaosdrv1.PhaseValidating, time.Now())
+	cr.Spec.ValidationConfig.Checks = []chaosdrv1.ValidationCheck{
+		httpCheck(t, "ping", "/healthz"),
+		{Name: "job", Type: "pending"},
+	}
+	r := newTestReconciler(cr)
+	done := false
+	r.Validators.Register("pending", func(*chaosdrv1.ChaosDRTest) (validation.Validator, error) {
+		return pendingValidator{done: &done}, nil
+	})
+
+	res, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseValidating || res.RequeueAfter != pollInterval {
+		t.Fatalf("Expected to wait in phase Validating, got %q requeue %s", got.Status.Phase, res.RequeueAfter)
+	}
+	if st := got.Status.Validation[1]; st.StartTime == nil || st.Duration != nil {
+		t.Errorf("Expected the pending check to be started and unfinished, got %+v", st)
+	}
+	pingDuration := got.Status.Validation[0].Duration
+
+	done = true
+	_, got = reconcileOnce(t, r, got)
+	if got.Status.Phase != chaosdrv1.PhaseStoringProof {
+		t.Fatalf("Expected phase StoringProof, got %q", got.Status.Phase)
+	}
+	if got.Status.Validation[0].Duration == nil || *got.Status.Validation[0].Duration != *pingDuration {
+		t.Error("Expected the finished check not to run again")
+	}
+	if st := got.Status.Validation[1]; !st.Passed || st.Observed != "ready" {
+		t.Errorf("Expected the pending check to pass, got %+v", st)
+	}
+}
+
+func TestReconcilePendingRejectsInvalidValidation(t *testing.T) {
+	cr := newTestCR("", time.Now())
+	cr.Status = chaosdrv1.ChaosDRTestStatus{}
+	cr.Spec.ValidationConfig.Checks = []chaosdrv1.ValidationCheck{{Name: "grpc", Type: "grpc"}}
+	r := newTestReconciler(cr)
+
+	_, got := reconcileOnce(t, r, cr)
+	if got.Status.Phase != chaosdrv1.PhaseFailed {
+		t.Fatalf("Expected phase Failed, got %q", got.Status.Phase)
+	}
+	cond := meta.FindStatusCondition(got.Status.Conditions, chaosdrv1.ConditionValidated)
+	if cond == nil || cond.Reason != "InvalidValidation" {
+		t.Errorf("Expected Validated=False with reason InvalidValidation, got %+v", cond)
+	}
+}
+
+func TestReconcileBackingUpCapturesSourcesFirst(t *testing.T) {
+	cr := newTestCR(chaosdrv1.PhaseBackingUp, time.Now())
+	cr.Spec.ValidationConfig.Checks = []chaosdrv1.ValidationCheck{{Name: "rows", Type: "capturing"}}
+	r := newTestReconciler(cr)
+	done, captured := true, false
+	r.Validators.Register("capturing", func(*chaosdrv1.ChaosDRTest) (validation.Validator, error) {
+		return capturingValidator{pendingValidator{done: &done}, &captured}, nil
+	})
+
+	res, got := reconcileOnce(t, r, cr)
+	if got.Status.Backup != nil || res.RequeueAfter != pollInterval {
+		t.Fatalf("Expected the backup to wait for the capture, got %+v requeue %s", got.Status.Backup, res.RequeueAfter)
+	}
+
+	captured = true
+	_, got = reconcileOnce(t, r, got)
+	if got.Status.Backup == nil {
+		t.Error("Expected the backup to start once the source is captured")
+	}
+}
@@ -1,6 +1,61 @@
 package backup
 
+import (
+	"context"
+	"time"
+)
+
 type BackupClient interface {
-	CreateBackup(name string, selector map[string]string) error
-	CreateRestore(backupName, namespace string) error
+	// CreateBackup starts a backup of the objects in namespace matching selector
+	CreateBackup(ctx context.Context, name, namespace string, selector map[string]string) (OperationRef, error)
+	// CreateRestore starts restoring the backup into namespace
+	CreateRestore(ctx context.Context, name string, backup OperationRef, namespace string) (OperationRef, error)
+	GetBackupStatus(ctx context.Context, ref OperationRef) (*Status, error)
+	GetRestoreStatus(ctx context.Context, ref OperationRef) (*Status, error)
+	// DeleteBackup removes the backup and the data it stored
+	DeleteBackup(ctx context.Context, ref OperationRef) error
+	// DeleteRestore removes the restore operation; the restored objects are left alone
+	DeleteRestore(ctx context.Context, ref OperationRef) error
+}
+
+// OperationRef identifies a backup or restore started by a BackupClient
+type OperationRef struct {
+	Name      string
+	Namespace string
+}
+
+// Snapshot is a backend snapshot of one volume
+type Snapshot struct {
+	Volume string
+	ID     string
+}
+
+// Status is the progress of a backup or restore as reported by the backend
+type Status struct {
+	Phase string
+	// Done is set once the operation has stopped, successfully or not
+	Done   bool
+	Failed bool
+	// TotalItems and ItemsCompleted count the objects handled so far
+	TotalItems     int
+	ItemsCompleted int
+	Warnings       int
+	Errors         int
+	// SizeBytes is the volume data transferred, when the backend reports it
+	SizeBytes int64
+	// Snapshots lists the per-volume snapshots of a backup, if the backend takes any
+	Snapshots []Snapshot
+	Messages  []string
+	// StartTime and CompletionTime are the backend's own timestamps, if known
+	StartTime      *time.Time
+	CompletionTime *time.Time
+}
+
+// Duration is how long the operation ran according to the backend. It is
+// zero unless both timestamps are known.
+func (s *Status) Duration() time.Duration {
+	if s.StartTime == nil || s.CompletionTime == nil {
+		return 0
+	}
+	return s.CompletionTime.Sub(*s.StartTime)
 }
@@ -0,0 +1,52 @@
+package backup
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+// Factory returns the BackupClient that runs the backups of one ChaosDRTest
+type Factory func(cr *chaosdrv1.ChaosDRTest) (BackupClient, error)
+
+// Registry holds the available backup providers keyed by name.
+type Registry struct {
+	factories map[string]Factory
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{factories: map[string]Factory{}}
+}
+
+// Register makes a provider available under name, replacing any earlier one.
+func (r *Registry) Register(name string, factory Factory) {
+	r.factories[name] = factory
+}
+
+// Has reports whether a provider is registered under name.
+func (r *Registry) Has(name string) bool {
+	_, ok := r.factories[name]
+	return ok
+}
+
+// Names returns the registered provider names in sorted order.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New builds the named provider's client for cr.
+func (r *Registry) New(name string, cr *chaosdrv1.ChaosDRTest) (BackupClient, error) {
+	factory, ok := r.factories[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown backup provider %q, available providers: %s", name, strings.Join(r.Names(), ", "))
+	}
+	return factory(cr)
+}
@@ -1,27 +1,497 @@
 package backup
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
-	"os/exec"
+	"path"
+	"sort"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+// DefaultResticImage runs the restic Jobs when spec.backup.restic.image is unset
+const DefaultResticImage = "restic/restic:0.16.4"
+
+const (
+	backupLabel  = "chaosdr.io/backup"
+	restoreLabel = "chaosdr.io/restore"
+	volumeLabel  = "chaosdr.io/volume"
+	// jobNameLabel is set by the Job controller on the pods it creates
+	jobNameLabel = "job-name"
+
+	// The source PVC's shape is kept on its backup Job so the restore can recreate it
+	pvcSizeAnnotation         = "chaosdr.io/pvc-size"
+	pvcAccessModesAnnotation  = "chaosdr.io/pvc-access-modes"
+	pvcStorageClassAnnotation = "chaosdr.io/pvc-storage-class"
+
+	// dataMountRoot is where each PVC is mounted, as /data/<pvc>, in backup and restore Jobs
+	dataMountRoot = "/data"
+	// forgetJobTTL is how long finished forget Jobs are kept, in seconds
+	forgetJobTTL = 600
 )
 
-type ResticClient struct{}
+// Each script gets its arguments as positional parameters. The backup prints
+// restic's JSON summary to the termination log, where the operator reads the snapshot ID.
+const (
+	backupScript = `set -o pipefail
+restic cat config >/dev/null 2>&1 || restic init
+restic backup "$1" --host "$2" --tag "$3" --json | tail -n 1 | tee /dev/termination-log`
+	restoreScript = `restic restore "$1" --target /`
+	forgetScript  = `restic forget --host "$1" --tag "$2" --prune`
+)
+
+// resticSummary is the final message of `restic backup --json`
+type resticSummary struct {
+	MessageType         string `json:"message_type"`
+	SnapshotID          string `json:"snapshot_id"`
+	TotalBytesProcessed int64  `json:"total_bytes_processed"`
+}
+
+// ResticClient backs up the PVCs of the selected pods with one restic Job per
+// volume, and restores them into freshly created PVCs of the target namespace.
+type ResticClient struct {
+	Client client.Client
+	// Reader reads the repository Secret. It must not be the manager's cached
+	// client: the operator may get Secrets but not list or watch them.
+	Reader client.Reader
+	Spec   chaosdrv1.ResticSpec
+}
+
+// NewResticClient returns a ResticClient for the given repository settings.
+func NewResticClient(cl client.Client, reader client.Reader, spec chaosdrv1.ResticSpec) *ResticClient {
+	if spec.Image == "" {
+		spec.Image = DefaultResticImage
+	}
+	return &ResticClient{Client: cl, Reader: reader, Spec: spec}
+}
+
+// NewResticFactory returns a Factory building a ResticClient from each test's
+// spec.backup.restic.
+func NewResticFactory(cl client.Client, reader client.Reader) Factory {
+	return func(cr *chaosdrv1.ChaosDRTest) (BackupClient, error) {
+		if cr.Spec.Backup == nil || cr.Spec.Backup.Restic == nil {
+			return nil, fmt.Errorf("backup provider restic requires spec.backup.restic")
+		}
+		return NewResticClient(cl, reader, *cr.Spec.Backup.Restic), nil
+	}
+}
+
+// claimUse is a PVC mounted by a selected pod
+type claimUse struct {
+	pvc      *corev1.PersistentVolumeClaim
+	nodeName string
+}
 
-func (c *ResticClient) CreateBackup(name string, selector map[string]string) error {
-	// Example: Restic backup command
-	cmd := exec.Command("restic", "backup", "--tag", name, "/data")
-	output, err := cmd.CombinedOutput()
+func (c *ResticClient) CreateBackup(ctx context.Context, name, namespace string, selector map[string]string) (OperationRef, error) {
+	claims, err := c.selectedClaims(ctx, namespace, selector)
 	if err != nil {
-		return fmt.Errorf("restic backup failed: %v, output: %s", err, output)
+		return OperationRef{}, err
+	}
+	if len(claims) == 0 {
+		return OperationRef{}, fmt.Errorf("no PVCs are mounted by pods matching %v in namespace %s", selector, namespace)
+	}
+
+	for _, claim := range claims {
+		pvc := claim.pvc
+		labels := map[string]string{backupLabel: name, volumeLabel: pvc.Name}
+		annotations := map[string]string{
+			pvcAccessModesAnnotation: joinAccessModes(pvc.Spec.AccessModes),
+		}
+		if size, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+			annotations[pvcSizeAnnotation] = size.String()
+		}
+		if pvc.Spec.StorageClassName != nil {
+			annotations[pvcStorageClassAnnotation] = *pvc.Spec.StorageClassName
+		}
+
+		job := c.newJob(jobName(name, pvc.Name), namespace, pvc.Name, labels, backupScript,
+			path.Join(dataMountRoot, pvc.Name), namespace, name)
+		job.Annotations = annotations
+		if claim.nodeName != "" {
+			// A ReadWriteOnce volume can only be mounted on the node already using it
+			job.Spec.Template.Spec.Affinity = NodeAffinity(claim.nodeName)
+		}
+		if err := c.Client.Create(ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+			return OperationRef{}, fmt.Errorf("failed to create restic backup job: %w", err)
+		}
+	}
+	return OperationRef{Name: name, Namespace: namespace}, nil
+}
+
+func (c *ResticClient) CreateRestore(ctx context.Context, name string, backup OperationRef, namespace string) (OperationRef, error) {
+	backupJobs, err := c.listJobs(ctx, backup.Namespace, backupLabel, backup.Name)
+	if err != nil {
+		return OperationRef{}, err
+	}
+	if len(backupJobs) == 0 {
+		return OperationRef{}, fmt.Errorf("no restic backup jobs found for backup %s", backup.Name)
+	}
+
+	if err := c.ensureNamespace(ctx, namespace); err != nil {
+		return OperationRef{}, err
+	}
+	if err := c.copyCredentials(ctx, backup.Namespace, namespace); err != nil {
+		return OperationRef{}, err
+	}
+
+	for i := range backupJobs {
+		bj := &backupJobs[i]
+		volume := bj.Labels[volumeLabel]
+		summary, err := c.jobSummary(ctx, bj)
+		if err != nil {
+			return OperationRef{}, err
+		}
+		if summary == nil || summary.SnapshotID == "" {
+			return OperationRef{}, fmt.Errorf("backup %s has no snapshot of volume %s", backup.Name, volume)
+		}
+
+		pvc, err := c.restoredClaim(bj, volume, namespace)
+		if err != nil {
+			return OperationRef{}, err
+		}
+		if err := c.Client.Create(ctx, pvc); err != nil && !apierrors.IsAlreadyExists(err) {
+			return OperationRef{}, fmt.Errorf("failed to create restore PVC %s: %w", volume, err)
+		}
+
+		labels := map[string]string{restoreLabel: name, volumeLabel: volume}
+		job := c.newJob(jobName(name, volume), namespace, volume, labels, restoreScript, summary.SnapshotID)
+		if err := c.Client.Create(ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+			return OperationRef{}, fmt.Errorf("failed to create restic restore job: %w", err)
+		}
+	}
+	return OperationRef{Name: name, Namespace: namespace}, nil
+}
+
+// GetBackupStatus aggregates the backup Jobs and collects the snapshot IDs of
+// the finished ones.
+func (c *ResticClient) GetBackupStatus(ctx context.Context, ref OperationRef) (*Status, error) {
+	jobs, err := c.listJobs(ctx, ref.Namespace, backupLabel, ref.Name)
+	if err != nil {
+		return nil, err
+	}
+	st, err := jobsStatus(jobs)
+	if err != nil {
+		return nil, fmt.Errorf("backup %s: %w", ref.Name, err)
+	}
+
+	for i := range jobs {
+		if !jobConditionTrue(&jobs[i], batchv1.JobComplete) {
+			continue
+		}
+		summary, err := c.jobSummary(ctx, &jobs[i])
+		if err != nil {
+			return nil, err
+		}
+		if summary == nil {
+			continue
+		}
+		st.Snapshots = append(st.Snapshots, Snapshot{Volume: jobs[i].Labels[volumeLabel], ID: summary.SnapshotID})
+		st.SizeBytes += summary.TotalBytesProcessed
+	}
+	return st, nil
+}
+
+func (c *ResticClient) GetRestoreStatus(ctx context.Context, ref OperationRef) (*Status, error) {
+	jobs, err := c.listJobs(ctx, ref.Namespace, restoreLabel, ref.Name)
+	if err != nil {
+		return nil, err
+	}
+	st, err := jobsStatus(jobs)
+	if err != nil {
+		return nil, fmt.Errorf("restore %s: %w", ref.Name, err)
+	}
+	return st, nil
+}
+
+// DeleteBackup removes the backup Jobs and starts a Job that forgets and
+// prunes the backup's snapshots from the repository.
+func (c *ResticClient) DeleteBackup(ctx context.Context, ref OperationRef) error {
+	err := c.Client.DeleteAllOf(ctx, &batchv1.Job{},
+		client.InNamespace(ref.Namespace),
+		client.MatchingLabels{backupLabel: ref.Name},
+		client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil {
+		return fmt.Errorf("failed to delete restic backup jobs: %w", err)
+	}
+
+	job := c.newJob(jobName(ref.Name, "forget"), ref.Namespace, "", nil, forgetScript, ref.Namespace, ref.Name)
+	ttl := int32(forgetJobTTL)
+	job.Spec.TTLSecondsAfterFinished = &ttl
+	if err := c.Client.Create(ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create restic forget job: %w", err)
 	}
 	return nil
 }
 
-func (c *ResticClient) CreateRestore(backupName, namespace string) error {
-	cmd := exec.Command("restic", "restore", backupName, "--target", "/restore")
-	output, err := cmd.CombinedOutput()
+// DeleteRestore removes the restore Jobs.
+func (c *ResticClient) DeleteRestore(ctx context.Context, ref OperationRef) error {
+	err := c.Client.DeleteAllOf(ctx, &batchv1.Job{},
+		client.InNamespace(ref.Namespace),
+		client.MatchingLabels{restoreLabel: ref.Name},
+		client.PropagationPolicy(metav1.DeletePropagationBackground))
 	if err != nil {
-		return fmt.Errorf("restic restore failed: %v, output: %s", err, output)
+		return fmt.Errorf("failed to delete restic restore jobs: %w", err)
 	}
 	return nil
 }
+
+// selectedClaims returns the PVCs mounted by pods in namespace matching selector.
+func (c *ResticClient) selectedClaims(ctx context.Context, namespace string, selector map[string]string) ([]claimUse, error) {
+	pods := &corev1.PodList{}
+	if err := c.Client.List(ctx, pods, client.InNamespace(namespace), client.MatchingLabels(selector)); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	seen := map[string]bool{}
+	var claims []claimUse
+	for _, pod := range pods.Items {
+		for _, vol := range pod.Spec.Volumes {
+			if vol.PersistentVolumeClaim == nil || seen[vol.PersistentVolumeClaim.ClaimName] {
+				continue
+			}
+			seen[vol.PersistentVolumeClaim.ClaimName] = true
+
+			pvc := &corev1.PersistentVolumeClaim{}
+			key := client.ObjectKey{Namespace: namespace, Name: vol.PersistentVolumeClaim.ClaimName}
+			if err := c.Client.Get(ctx, key, pvc); err != nil {
+				return nil, fmt.Errorf("failed to get PVC %s: %w", key.Name, err)
+			}
+			claims = append(claims, claimUse{pvc: pvc, nodeName: pod.Spec.NodeName})
+		}
+	}
+	sort.Slice(claims, func(i, j int) bool { return claims[i].pvc.Name < claims[j].pvc.Name })
+	return claims, nil
+}
+
+// restoredClaim builds the PVC a volume is restored into from the shape
+// recorded on its backup Job.
+func (c *ResticClient) restoredClaim(backupJob *batchv1.Job, volume, namespace string) (*corev1.PersistentVolumeClaim, error) {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: volume, Namespace: namespace},
+	}
+	for _, mode := range strings.Split(backupJob.Annotations[pvcAccessModesAnnotation], ",") {
+		if mode != "" {
+			pvc.Spec.AccessModes = append(pvc.Spec.AccessModes, corev1.PersistentVolumeAccessMode(mode))
+		}
+	}
+	if len(pvc.Spec.AccessModes) == 0 {
+		pvc.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	}
+
+	size := backupJob.Annotations[pvcSizeAnnotation]
+	if size == "" {
+		return nil, fmt.Errorf("backup job %s does not record the size of volume %s", backupJob.Name, volume)
+	}
+	quantity, err := resource.ParseQuantity(size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid size of volume %s: %w", volume, err)
+	}
+	pvc.Spec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: quantity}
+
+	if c.Spec.StorageClassName != nil {
+		pvc.Spec.StorageClassName = c.Spec.StorageClassName
+	} else if sc, ok := backupJob.Annotations[pvcStorageClassAnnotation]; ok {
+		pvc.Spec.StorageClassName = &sc
+	}
+	return pvc, nil
+}
+
+func (c *ResticClient) ensureNamespace(ctx context.Context, namespace string) error {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+	if err := c.Client.Create(ctx, ns); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create namespace %s: %w", namespace, err)
+	}
+	return nil
+}
+
+// copyCredentials makes the repository Secret available to restore Jobs in namespace.
+func (c *ResticClient) copyCredentials(ctx context.Context, from, to string) error {
+	name := c.Spec.CredentialsSecretRef.Name
+	src := &corev1.Secret{}
+	if err := c.Reader.Get(ctx, client.ObjectKey{Namespace: from, Name: name}, src); err != nil {
+		return fmt.Errorf("failed to get restic credentials secret %s: %w", name, err)
+	}
+	dst := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: to},
+		Type:       src.Type,
+		Data:       src.Data,
+	}
+	if err := c.Client.Create(ctx, dst); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to copy restic credentials secret %s: %w", name, err)
+	}
+	return nil
+}
+
+func (c *ResticClient) listJobs(ctx context.Context, namespace, label, value string) ([]batchv1.Job, error) {
+	jobs := &batchv1.JobList{}
+	if err := c.Client.List(ctx, jobs, client.InNamespace(namespace), client.MatchingLabels{label: value}); err != nil {
+		return nil, fmt.Errorf("failed to list restic jobs: %w", err)
+	}
+	sort.Slice(jobs.Items, func(i, j int) bool { return jobs.Items[i].Name < jobs.Items[j].Name })
+	return jobs.Items, nil
+}
+
+// jobSummary reads the restic summary a backup Job's pod left in its
+// termination message. It returns nil if no pod has finished successfully.
+func (c *ResticClient) jobSummary(ctx context.Context, job *batchv1.Job) (*resticSummary, error) {
+	pods := &corev1.PodList{}
+	if err := c.Client.List(ctx, pods, client.InNamespace(job.Namespace), client.MatchingLabels{jobNameLabel: job.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list pods of job %s: %w", job.Name, err)
+	}
+	for _, pod := range pods.Items {
+		for _, cs := range pod.Status.ContainerStatuses {
+			term := cs.State.Terminated
+			if term == nil || term.ExitCode != 0 || term.Message == "" {
+				continue
+			}
+			summary := &resticSummary{}
+			if err := json.Unmarshal([]byte(strings.TrimSpace(term.Message)), summary); err != nil {
+				return nil, fmt.Errorf("invalid restic summary from pod %s: %w", pod.Name, err)
+			}
+			return summary, nil
+		}
+	}
+	return nil, nil
+}
+
+// newJob builds a restic Job running script with args, with the claim (if any)
+// mounted under dataMountRoot.
+func (c *ResticClient) newJob(name, namespace, claim string, labels map[string]string, script string, args ...string) *batchv1.Job {
+	backoffLimit := int32(2)
+	container := corev1.Container{
+		Name:    "restic",
+		Image:   c.Spec.Image,
+		Command: append([]string{"/bin/sh", "-c", script, "restic"}, args...),
+		Env:     []corev1.EnvVar{{Name: "RESTIC_REPOSITORY", Value: c.Spec.Repository}},
+		EnvFrom: []corev1.EnvFromSource{{
+			SecretRef: &corev1.SecretEnvSource{LocalObjectReference: c.Spec.CredentialsSecretRef},
+		}},
+	}
+	podSpec := corev1.PodSpec{RestartPolicy: corev1.RestartPolicyNever}
+	if claim != "" {
+		container.VolumeMounts = []corev1.VolumeMount{{Name: "data", MountPath: path.Join(dataMountRoot, claim)}}
+		podSpec.Volumes = []corev1.Volume{{
+			Name: "data",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim},
+			},
+		}}
+	}
+	podSpec.Containers = []corev1.Container{container}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       podSpec,
+			},
+		},
+	}
+}
+
+// jobsStatus summarizes a set of Jobs as a single operation, one item per Job.
+func jobsStatus(jobs []batchv1.Job) (*Status, error) {
+	if len(jobs) == 0 {
+		return nil, fmt.Errorf("no restic jobs found")
+	}
+	st := &Status{Phase: "InProgress", TotalItems: len(jobs)}
+	finished := 0
+	for i := range jobs {
+		job := &jobs[i]
+		if start := job.Status.StartTime; start != nil && (st.StartTime == nil || start.Time.Before(*st.StartTime)) {
+			t := start.Time
+			st.StartTime = &t
+		}
+		switch {
+		case jobConditionTrue(job, batchv1.JobComplete):
+			finished++
+			st.ItemsCompleted++
+			if end := job.Status.CompletionTime; end != nil && (st.CompletionTime == nil || end.Time.After(*st.CompletionTime)) {
+				t := end.Time
+				st.CompletionTime = &t
+			}
+		case jobConditionTrue(job, batchv1.JobFailed):
+			finished++
+			st.Errors++
+			st.Failed = true
+			st.Messages = append(st.Messages, fmt.Sprintf("job %s failed: %s", job.Name, jobConditionMessage(job, batchv1.JobFailed)))
+		}
+	}
+
+	if finished == len(jobs) {
+		st.Done = true
+		st.Phase = "Completed"
+		if st.Failed {
+			st.Phase = "Failed"
+		}
+	}
+	return st, nil
+}
+
+func jobConditionTrue(job *batchv1.Job, condType batchv1.JobConditionType) bool {
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == condType && cond.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func jobConditionMessage(job *batchv1.Job, condType batchv1.JobConditionType) string {
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == condType {
+			return cond.Message
+		}
+	}
+	return ""
+}
+
+// NodeAffinity pins a pod to the node nodeName, which a pod mounting a
+// ReadWriteOnce volume in use must run on.
+func NodeAffinity(nodeName string) *corev1.Affinity {
+	return &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchFields: []corev1.NodeSelectorRequirement{{
+						Key:      "metadata.name",
+						Operator: corev1.NodeSelectorOpIn,
+						Values:   []string{nodeName},
+					}},
+				}},
+			},
+		},
+	}
+}
+
+func joinAccessModes(modes []corev1.PersistentVolumeAccessMode) string {
+	parts := make([]string, len(modes))
+	for i, mode := range modes {
+		parts[i] = string(mode)
+	}
+	return strings.Join(parts, ",")
+}
+
+// jobName joins prefix and suffix into a name that also fits the 63 character
+// job-name pod label, hashing the tail of overly long names.
+func jobName(prefix, suffix string) string {
+	name := prefix + "-" + suffix
+	if len(name) <= 63 {
+		return name
+	}
+	sum := sha256.Sum256([]byte(name))
+	return name[:54] + "-" + hex.EncodeToString(sum[:])[:8]
+}
@@ -0,0 +1,200 @@
+package backup
+
+import (
+	"context"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+func newResticTestClient(t *testing.T) (*ResticClient, client.Client) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "redis-0", Namespace: "default", Labels: map[string]string{"app": "redis"}},
+		Spec: corev1.PodSpec{
+			NodeName: "node-1",
+			Volumes: []corev1.Volume{{
+				Name: "data",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "redis-data"},
+				},
+			}},
+		},
+	}
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "redis-data", Namespace: "default"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "restic-creds", Namespace: "default"},
+		Data:       map[string][]byte{"RESTIC_PASSWORD": []byte("secret")},
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pod, pvc, secret).Build()
+	return NewResticClient(cl, cl, chaosdrv1.ResticSpec{
+		Repository:           "s3:http://minio:9000/backups/restic",
+		CredentialsSecretRef: corev1.LocalObjectReference{Name: "restic-creds"},
+	}), cl
+}
+
+// completeJob marks a Job complete and leaves summary in its pod's termination message.
+func completeJob(t *testing.T, cl client.Client, name, namespace, summary string) {
+	t.Helper()
+	job := &batchv1.Job{}
+	if err := cl.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: name}, job); err != nil {
+		t.Fatalf("Failed to get job %s: %v", name, err)
+	}
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	if err := cl.Status().Update(context.Background(), job); err != nil {
+		t.Fatalf("Failed to update job %s: %v", name, err)
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name + "-abcde", Namespace: namespace, Labels: map[string]string{jobNameLabel: name}},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "restic",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0, Message: summary}},
+			}},
+		},
+	}
+	if err := cl.Create(context.Background(), pod); err != nil {
+		t.Fatalf("Failed to create pod for job %s: %v", name, err)
+	}
+}
+
+func TestResticCreateBackup(t *testing.T) {
+	c, cl := newResticTestClient(t)
+
+	ref, err := c.CreateBackup(context.Background(), "dr-backup", "default", map[string]string{"app": "redis"})
+	if err != nil {
+		t.Fatalf("CreateBackup failed: %v", err)
+	}
+	if ref.Namespace != "default" {
+		t.Errorf("Expected backup ref in namespace default, got %q", ref.Namespace)
+	}
+
+	job := &batchv1.Job{}
+	if err := cl.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "dr-backup-redis-data"}, job); err != nil {
+		t.Fatalf("Failed to get backup job: %v", err)
+	}
+	podSpec := job.Spec.Template.Spec
+	if podSpec.Volumes[0].PersistentVolumeClaim.ClaimName != "redis-data" {
+		t.Errorf("Expected job to mount redis-data, got %+v", podSpec.Volumes)
+	}
+	if podSpec.Containers[0].EnvFrom[0].SecretRef.Name != "restic-creds" {
+		t.Errorf("Expected credentials from restic-creds, got %+v", podSpec.Containers[0].EnvFrom)
+	}
+	if podSpec.Affinity == nil {
+		t.Error("Expected job to be pinned to the node using the volume")
+	}
+	if job.Annotations[pvcSizeAnnotation] != "1Gi" {
+		t.Errorf("Expected PVC size 1Gi to be recorded, got %q", job.Annotations[pvcSizeAnnotation])
+	}
+}
+
+func TestResticCreateBackup_NoVolumes(t *testing.T) {
+	c, _ := newResticTestClient(t)
+
+	_, err := c.CreateBackup(context.Background(), "dr-backup", "default", map[string]string{"app": "nginx"})
+	if err == nil {
+		t.Fatal("Expected error when no selected pod mounts a PVC, got nil")
+	}
+}
+
+func TestResticBackupAndRestore(t *testing.T) {
+	c, cl := newResticTestClient(t)
+	ctx := context.Background()
+
+	ref, err := c.CreateBackup(ctx, "dr-backup", "default", map[string]string{"app": "redis"})
+	if err != nil {
+		t.Fatalf("CreateBackup failed: %v", err)
+	}
+
+	st, err := c.GetBackupStatus(ctx, ref)
+	if err != nil {
+		t.Fatalf("GetBackupStatus failed: %v", err)
+	}
+	if st.Done {
+		t.Error("Expected backup to be in progress")
+	}
+
+	completeJob(t, cl, "dr-backup-redis-data", "default",
+		`{"message_type":"summary","snapshot_id":"4f6e1c2a","total_bytes_processed":2048}`)
+
+	st, err = c.GetBackupStatus(ctx, ref)
+	if err != nil {
+		t.Fatalf("GetBackupStatus failed: %v", err)
+	}
+	if !st.Done || st.Failed {
+		t.Fatalf("Expected backup to be completed, got %+v", st)
+	}
+	if len(st.Snapshots) != 1 || st.Snapshots[0].ID != "4f6e1c2a" || st.Snapshots[0].Volume != "redis-data" {
+		t.Errorf("Expected snapshot 4f6e1c2a of redis-data, got %+v", st.Snapshots)
+	}
+	if st.SizeBytes != 2048 {
+		t.Errorf("Expected 2048 bytes, got %d", st.SizeBytes)
+	}
+
+	restoreRef, err := c.CreateRestore(ctx, "dr-restore", ref, "sandbox-test")
+	if err != nil {
+		t.Fatalf("CreateRestore failed: %v", err)
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: "sandbox-test", Name: "redis-data"}, pvc); err != nil {
+		t.Fatalf("Failed to get restored PVC: %v", err)
+	}
+	if size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; size.String() != "1Gi" {
+		t.Errorf("Expected restored PVC of 1Gi, got %s", size.String())
+	}
+	secret := &corev1.Secret{}
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: "sandbox-test", Name: "restic-creds"}, secret); err != nil {
+		t.Errorf("Expected credentials to be copied into the sandbox: %v", err)
+	}
+
+	job := &batchv1.Job{}
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: "sandbox-test", Name: "dr-restore-redis-data"}, job); err != nil {
+		t.Fatalf("Failed to get restore job: %v", err)
+	}
+	command := job.Spec.Template.Spec.Containers[0].Command
+	if command[len(command)-1] != "4f6e1c2a" {
+		t.Errorf("Expected restore of snapshot 4f6e1c2a, got command %v", command)
+	}
+
+	completeJob(t, cl, "dr-restore-redis-data", "sandbox-test", "")
+	st, err = c.GetRestoreStatus(ctx, restoreRef)
+	if err != nil {
+		t.Fatalf("GetRestoreStatus failed: %v", err)
+	}
+	if !st.Done || st.Failed || st.ItemsCompleted != 1 {
+		t.Errorf("Expected restore to be completed, got %+v", st)
+	}
+}
+
+func TestJobName(t *testing.T) {
+	long := jobName("dr-backup-a-very-long-chaos-dr-test-name-for-production", "postgres-data-volume")
+	if len(long) != 63 {
+		t.Errorf("Expected name truncated to 63 characters, got %d: %s", len(long), long)
+	}
+	if got := jobName("dr-backup", "redis-data"); got != "dr-backup-redis-data" {
+		t.Errorf("Expected dr-backup-redis-data, got %s", got)
+	}
+}
@@ -3,27 +3,100 @@ package chaos
 import (
 	"context"
 	"fmt"
+	"time"
 
 	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
 )
 
-// ApplyChaosExperiment applies a chaos experiment to the specified application.
-func ApplyChaosExperiment(ctx context.Context, cl client.Client, cr *chaosdrv1.ChaosDRTest, chaosName, chaosType string) error {
-	switch chaosType {
-	case "pod-delete":
-		return applyPodDeleteChaos(ctx, cl, cr, chaosName)
-	case "network-delay":
-		return applyNetworkDelayChaos(ctx, cl, cr, chaosName)
+// DefaultDuration is how long chaos runs when the test sets no spec.chaosDuration
+const DefaultDuration = 30 * time.Second
+
+// Token bucket sizes used by network-bandwidth when the test sets none
+const (
+	defaultBandwidthLimit  = 20971520
+	defaultBandwidthBuffer = 10000
+)
+
+// defaultIOPercent injects every matching filesystem call when the test sets no percent
+const defaultIOPercent = 100
+
+// ExperimentState summarizes the Status.Experiment records of a chaos object
+type ExperimentState string
+
+const (
+	// ExperimentPending means no target has been injected yet
+	ExperimentPending ExperimentState = "Pending"
+	// ExperimentInjected means at least one target is under chaos
+	ExperimentInjected ExperimentState = "Injected"
+	// ExperimentRecovered means the experiment stopped and every target was recovered
+	ExperimentRecovered ExperimentState = "Recovered"
+	// ExperimentFailed means Chaos Mesh could not run the experiment
+	ExperimentFailed ExperimentState = "Failed"
+)
+
+// ExperimentStatus is the progress of a chaos experiment as reported by Chaos Mesh
+type ExperimentStatus struct {
+	State ExperimentState
+	// InjectedTargets counts the targets currently injected, out of TotalTargets
+	InjectedTargets int
+	TotalTargets    int
+	Message         string
+}
+
+// Duration returns how long the chaos of cr should run.
+func Duration(cr *chaosdrv1.ChaosDRTest) time.Duration {
+	if cr.Spec.ChaosDuration == nil || cr.Spec.ChaosDuration.Duration <= 0 {
+		return DefaultDuration
+	}
+	return cr.Spec.ChaosDuration.Duration
+}
+
+// durationSpec formats the chaos duration of cr for a Chaos Mesh spec.
+func durationSpec(cr *chaosdrv1.ChaosDRTest) *string {
+	d := Duration(cr).String()
+	return &d
+}
+
+// ApplyChaosExperiment applies the chaos described by spec to the app selected by cr.
+func ApplyChaosExperiment(ctx context.Context, cl client.Client, cr *chaosdrv1.ChaosDRTest, chaosName string, spec *chaosdrv1.ChaosSpec) error {
+	if err := ValidateSpec(spec); err != nil {
+		return err
+	}
+	switch spec.Type {
+	case chaosdrv1.ChaosTypePodDelete:
+		return applyPodDeleteChaos(ctx, cl, cr, chaosName, spec)
+	case chaosdrv1.ChaosTypeNetworkDelay:
+		return applyNetworkDelayChaos(ctx, cl, cr, chaosName, spec)
+	case chaosdrv1.ChaosTypeNetworkLoss:
+		return applyNetworkLossChaos(ctx, cl, cr, chaosName, spec)
+	case chaosdrv1.ChaosTypeNetworkPartition:
+		return applyNetworkPartitionChaos(ctx, cl, cr, chaosName, spec)
+	case chaosdrv1.ChaosTypeNetworkCorrupt:
+		return applyNetworkCorruptChaos(ctx, cl, cr, chaosName, spec)
+	case chaosdrv1.ChaosTypeNetworkDuplicate:
+		return applyNetworkDuplicateChaos(ctx, cl, cr, chaosName, spec)
+	case chaosdrv1.ChaosTypeNetworkBandwidth:
+		return applyNetworkBandwidthChaos(ctx, cl, cr, chaosName, spec)
+	case chaosdrv1.ChaosTypeCPUStress, chaosdrv1.ChaosTypeMemoryStress:
+		return applyStressChaos(ctx, cl, cr, chaosName, spec)
+	case chaosdrv1.ChaosTypeIOLatency, chaosdrv1.ChaosTypeIOFault, chaosdrv1.ChaosTypeIOAttrOverride:
+		return applyIOChaos(ctx, cl, cr, chaosName, spec)
+	case chaosdrv1.ChaosTypeTimeSkew:
+		return applyTimeSkewChaos(ctx, cl, cr, chaosName, spec)
+	case chaosdrv1.ChaosTypeDNSError, chaosdrv1.ChaosTypeDNSRandom:
+		return applyDNSChaos(ctx, cl, cr, chaosName, spec)
 	default:
-		return fmt.Errorf("unsupported chaosType: %s", chaosType)
+		return fmt.Errorf("unsupported chaosType: %s", spec.Type)
 	}
 }
 
-func applyPodDeleteChaos(ctx context.Context, cl client.Client, cr *chaosdrv1.ChaosDRTest, chaosName string) error {
+func applyPodDeleteChaos(ctx context.Context, cl client.Client, cr *chaosdrv1.ChaosDRTest, chaosName string, spec *chaosdrv1.ChaosSpec) error {
+	params := spec.PodDelete
 	chaos := &chaosmeshv1alpha1.PodChaos{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      chaosName,
@@ -31,98 +104,395 @@ func applyPodDeleteChaos(ctx context.Context, cl client.Client, cr *chaosdrv1.Ch
 		},
 		Spec: chaosmeshv1alpha1.PodChaosSpec{
 			ContainerSelector: chaosmeshv1alpha1.ContainerSelector{
-				PodSelector: chaosmeshv1alpha1.PodSelector{
-					Selector: chaosmeshv1alpha1.PodSelectorSpec{
-						GenericSelectorSpec: chaosmeshv1alpha1.GenericSelectorSpec{
-							Namespaces:     []string{cr.Namespace},
-							LabelSelectors: cr.Spec.AppSelector,
-						},
-					},
-					Mode: chaosmeshv1alpha1.OneMode,
+				PodSelector: podSelector(cr, spec),
+			},
+			Action:   chaosmeshv1alpha1.PodKillAction,
+			Duration: durationSpec(cr),
+		},
+	}
+	if params != nil {
+		chaos.Spec.GracePeriod = params.GracePeriod
+	}
+
+	if err := cl.Create(ctx, chaos); err != nil {
+		return fmt.Errorf("failed to create pod chaos: %w", err)
+	}
+
+	return nil
+}
+
+func applyNetworkDelayChaos(ctx context.Context, cl client.Client, cr *chaosdrv1.ChaosDRTest, chaosName string, spec *chaosdrv1.ChaosSpec) error {
+	params := spec.NetworkDelay
+	return createNetworkChaos(ctx, cl, cr, chaosName, spec, chaosmeshv1alpha1.NetworkChaosSpec{
+		Action: chaosmeshv1alpha1.DelayAction,
+		TcParameter: chaosmeshv1alpha1.TcParameter{
+			Delay: &chaosmeshv1alpha1.DelaySpec{
+				Latency:     params.Latency,
+				Jitter:      params.Jitter,
+				Correlation: params.Correlation,
+			},
+		},
+	})
+}
+
+func applyNetworkLossChaos(ctx context.Context, cl client.Client, cr *chaosdrv1.ChaosDRTest, chaosName string, spec *chaosdrv1.ChaosSpec) error {
+	params := spec.NetworkLoss
+	return createNetworkChaos(ctx, cl, cr, chaosName, spec, chaosmeshv1alpha1.NetworkChaosSpec{
+		Action: chaosmeshv1alpha1.LossAction,
+		TcParameter: chaosmeshv1alpha1.TcParameter{
+			Loss: &chaosmeshv1alpha1.LossSpec{
+				Loss:        params.Loss,
+				Correlation: params.Correlation,
+			},
+		},
+	})
+}
+
+func applyNetworkPartitionChaos(ctx context.Context, cl client.Client, cr *chaosdrv1.ChaosDRTest, chaosName string, spec *chaosdrv1.ChaosSpec) error {
+	params := spec.NetworkPartition
+	networkSpec := chaosmeshv1alpha1.NetworkChaosSpec{
+		Action:          chaosmeshv1alpha1.PartitionAction,
+		Direction:       chaosmeshv1alpha1.Both,
+		ExternalTargets: params.ExternalTargets,
+	}
+	if params.Direction != "" {
+		networkSpec.Direction = chaosmeshv1alpha1.Direction(params.Direction)
+	}
+	if params.Target != nil {
+		namespace := params.Target.Namespace
+		if namespace == "" {
+			namespace = cr.Namespace
+		}
+		networkSpec.Target = &chaosmeshv1alpha1.PodSelector{
+			Selector: chaosmeshv1alpha1.PodSelectorSpec{
+				GenericSelectorSpec: chaosmeshv1alpha1.GenericSelectorSpec{
+					Namespaces:     []string{namespace},
+					LabelSelectors: params.Target.Selector,
 				},
 			},
-			Action: chaosmeshv1alpha1.PodKillAction,
+			Mode: chaosmeshv1alpha1.AllMode,
+		}
+	}
+	return createNetworkChaos(ctx, cl, cr, chaosName, spec, networkSpec)
+}
+
+func applyNetworkCorruptChaos(ctx context.Context, cl client.Client, cr *chaosdrv1.ChaosDRTest, chaosName string, spec *chaosdrv1.ChaosSpec) error {
+	params := spec.NetworkCorrupt
+	return createNetworkChaos(ctx, cl, cr, chaosName, spec, chaosmeshv1alpha1.NetworkChaosSpec{
+		Action: chaosmeshv1alpha1.CorruptAction,
+		TcParameter: chaosmeshv1alpha1.TcParameter{
+			Corrupt: &chaosmeshv1alpha1.CorruptSpec{
+				Corrupt:     params.Corrupt,
+				Correlation: params.Correlation,
+			},
+		},
+	})
+}
+
+func applyNetworkDuplicateChaos(ctx context.Context, cl client.Client, cr *chaosdrv1.ChaosDRTest, chaosName string, spec *chaosdrv1.ChaosSpec) error {
+	params := spec.NetworkDuplicate
+	return createNetworkChaos(ctx, cl, cr, chaosName, spec, chaosmeshv1alpha1.NetworkChaosSpec{
+		Action: chaosmeshv1alpha1.DuplicateAction,
+		TcParameter: chaosmeshv1alpha1.TcParameter{
+			Duplicate: &chaosmeshv1alpha1.DuplicateSpec{
+				Duplicate:   params.Duplicate,
+				Correlation: params.Correlation,
+			},
+		},
+	})
+}
+
+func applyNetworkBandwidthChaos(ctx context.Context, cl client.Client, cr *chaosdrv1.ChaosDRTest, chaosName string, spec *chaosdrv1.ChaosSpec) error {
+	params := spec.NetworkBandwidth
+	bandwidth := &chaosmeshv1alpha1.BandwidthSpec{
+		Rate:     params.Rate,
+		Limit:    params.Limit,
+		Buffer:   params.Buffer,
+		Peakrate: params.Peakrate,
+		Minburst: params.Minburst,
+	}
+	if bandwidth.Limit == 0 {
+		bandwidth.Limit = defaultBandwidthLimit
+	}
+	if bandwidth.Buffer == 0 {
+		bandwidth.Buffer = defaultBandwidthBuffer
+	}
+	return createNetworkChaos(ctx, cl, cr, chaosName, spec, chaosmeshv1alpha1.NetworkChaosSpec{
+		Action:      chaosmeshv1alpha1.BandwidthAction,
+		TcParameter: chaosmeshv1alpha1.TcParameter{Bandwidth: bandwidth},
+	})
+}
+
+// createNetworkChaos creates a NetworkChaos from networkSpec, applied to the pods spec selects.
+func createNetworkChaos(ctx context.Context, cl client.Client, cr *chaosdrv1.ChaosDRTest, chaosName string, spec *chaosdrv1.ChaosSpec, networkSpec chaosmeshv1alpha1.NetworkChaosSpec) error {
+	networkSpec.PodSelector = podSelector(cr, spec)
+	networkSpec.Duration = durationSpec(cr)
+	chaos := &chaosmeshv1alpha1.NetworkChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      chaosName,
+			Namespace: cr.Namespace,
 		},
+		Spec: networkSpec,
 	}
 
 	if err := cl.Create(ctx, chaos); err != nil {
-		return fmt.Errorf("failed to create pod chaos: %v", err)
+		return fmt.Errorf("failed to create network chaos: %w", err)
 	}
 
 	return nil
 }
 
-func applyNetworkDelayChaos(ctx context.Context, cl client.Client, cr *chaosdrv1.ChaosDRTest, chaosName string) error {
-	// Validate required parameters
-	delay, ok := cr.Spec.ChaosParameters["delay"]
-	if !ok || delay == "" {
-		return fmt.Errorf("network-delay requires 'delay' parameter (e.g., '100ms')")
+func applyStressChaos(ctx context.Context, cl client.Client, cr *chaosdrv1.ChaosDRTest, chaosName string, spec *chaosdrv1.ChaosSpec) error {
+	params := spec.Stress
+	workers := 1
+	if params != nil && params.Workers > 0 {
+		workers = params.Workers
 	}
 
-	// Build DelaySpec
-	delaySpec := &chaosmeshv1alpha1.DelaySpec{
-		Latency: delay,
+	stressors := &chaosmeshv1alpha1.Stressors{}
+	if spec.Type == chaosdrv1.ChaosTypeCPUStress {
+		stressors.CPUStressor = &chaosmeshv1alpha1.CPUStressor{
+			Stressor: chaosmeshv1alpha1.Stressor{Workers: workers},
+		}
+		if params != nil {
+			stressors.CPUStressor.Load = params.Load
+		}
+	} else {
+		stressors.MemoryStressor = &chaosmeshv1alpha1.MemoryStressor{
+			Stressor: chaosmeshv1alpha1.Stressor{Workers: workers},
+		}
+		if params != nil {
+			stressors.MemoryStressor.Size = params.MemorySize
+		}
 	}
 
-	// Add optional jitter if provided
-	if jitter, ok := cr.Spec.ChaosParameters["jitter"]; ok && jitter != "" {
-		delaySpec.Jitter = jitter
+	chaos := &chaosmeshv1alpha1.StressChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      chaosName,
+			Namespace: cr.Namespace,
+		},
+		Spec: chaosmeshv1alpha1.StressChaosSpec{
+			ContainerSelector: chaosmeshv1alpha1.ContainerSelector{
+				PodSelector: podSelector(cr, spec),
+			},
+			Stressors: stressors,
+			Duration:  durationSpec(cr),
+		},
 	}
 
-	// Add optional correlation if provided
-	if correlation, ok := cr.Spec.ChaosParameters["correlation"]; ok && correlation != "" {
-		delaySpec.Correlation = correlation
+	if err := cl.Create(ctx, chaos); err != nil {
+		return fmt.Errorf("failed to create stress chaos: %w", err)
 	}
 
-	chaos := &chaosmeshv1alpha1.NetworkChaos{
+	return nil
+}
+
+func applyIOChaos(ctx context.Context, cl client.Client, cr *chaosdrv1.ChaosDRTest, chaosName string, spec *chaosdrv1.ChaosSpec) error {
+	params := spec.IO
+	volumePath, containers, err := resolveVolumeMount(ctx, cl, cr, params)
+	if err != nil {
+		return err
+	}
+
+	ioSpec := chaosmeshv1alpha1.IOChaosSpec{
+		ContainerSelector: chaosmeshv1alpha1.ContainerSelector{
+			PodSelector:    podSelector(cr, spec),
+			ContainerNames: containers,
+		},
+		VolumePath: volumePath,
+		Path:       params.Path,
+		Percent:    params.Percent,
+		Duration:   durationSpec(cr),
+	}
+	if ioSpec.Percent == 0 {
+		ioSpec.Percent = defaultIOPercent
+	}
+	for _, method := range params.Methods {
+		ioSpec.Methods = append(ioSpec.Methods, chaosmeshv1alpha1.IoMethod(method))
+	}
+
+	switch spec.Type {
+	case chaosdrv1.ChaosTypeIOLatency:
+		ioSpec.Action = chaosmeshv1alpha1.IoLatency
+		ioSpec.Delay = params.Delay
+	case chaosdrv1.ChaosTypeIOFault:
+		ioSpec.Action = chaosmeshv1alpha1.IoFaults
+		ioSpec.Errno = params.Errno
+	case chaosdrv1.ChaosTypeIOAttrOverride:
+		ioSpec.Action = chaosmeshv1alpha1.IoAttrOverride
+		ioSpec.Attr = ioAttrOverride(params.Attr)
+	}
+
+	chaos := &chaosmeshv1alpha1.IOChaos{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      chaosName,
 			Namespace: cr.Namespace,
 		},
-		Spec: chaosmeshv1alpha1.NetworkChaosSpec{
-			PodSelector: chaosmeshv1alpha1.PodSelector{
-				Selector: chaosmeshv1alpha1.PodSelectorSpec{
-					GenericSelectorSpec: chaosmeshv1alpha1.GenericSelectorSpec{
-						Namespaces:     []string{cr.Namespace},
-						LabelSelectors: cr.Spec.AppSelector,
-					},
-				},
-				Mode: chaosmeshv1alpha1.AllMode,
+		Spec: ioSpec,
+	}
+
+	if err := cl.Create(ctx, chaos); err != nil {
+		return fmt.Errorf("failed to create io chaos: %w", err)
+	}
+
+	return nil
+}
+
+// ioAttrOverride converts the attributes of an io-attr-override to Chaos Mesh's.
+func ioAttrOverride(attr *chaosdrv1.IOAttrOverrideSpec) *chaosmeshv1alpha1.AttrOverrideSpec {
+	override := &chaosmeshv1alpha1.AttrOverrideSpec{
+		Perm:   attr.Perm,
+		Size:   attr.Size,
+		Blocks: attr.Blocks,
+		Nlink:  attr.Nlink,
+		UID:    attr.UID,
+		GID:    attr.GID,
+	}
+	if attr.Kind != "" {
+		kind := chaosmeshv1alpha1.FileType(attr.Kind)
+		override.Kind = &kind
+	}
+	return override
+}
+
+func applyTimeSkewChaos(ctx context.Context, cl client.Client, cr *chaosdrv1.ChaosDRTest, chaosName string, spec *chaosdrv1.ChaosSpec) error {
+	params := spec.TimeSkew
+	chaos := &chaosmeshv1alpha1.TimeChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      chaosName,
+			Namespace: cr.Namespace,
+		},
+		Spec: chaosmeshv1alpha1.TimeChaosSpec{
+			ContainerSelector: chaosmeshv1alpha1.ContainerSelector{
+				PodSelector: podSelector(cr, spec),
 			},
-			Action: chaosmeshv1alpha1.DelayAction,
-			TcParameter: chaosmeshv1alpha1.TcParameter{
-				Delay: delaySpec,
+			TimeOffset: params.Offset,
+			ClockIds:   params.ClockIDs,
+			Duration:   durationSpec(cr),
+		},
+	}
+
+	if err := cl.Create(ctx, chaos); err != nil {
+		return fmt.Errorf("failed to create time chaos: %w", err)
+	}
+
+	return nil
+}
+
+func applyDNSChaos(ctx context.Context, cl client.Client, cr *chaosdrv1.ChaosDRTest, chaosName string, spec *chaosdrv1.ChaosSpec) error {
+	params := spec.DNS
+	action := chaosmeshv1alpha1.ErrorAction
+	if spec.Type == chaosdrv1.ChaosTypeDNSRandom {
+		action = chaosmeshv1alpha1.RandomAction
+	}
+
+	chaos := &chaosmeshv1alpha1.DNSChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      chaosName,
+			Namespace: cr.Namespace,
+		},
+		Spec: chaosmeshv1alpha1.DNSChaosSpec{
+			Action: action,
+			ContainerSelector: chaosmeshv1alpha1.ContainerSelector{
+				PodSelector: podSelector(cr, spec),
 			},
+			Duration: durationSpec(cr),
 		},
 	}
+	if params != nil {
+		chaos.Spec.DomainNamePatterns = params.Patterns
+	}
 
 	if err := cl.Create(ctx, chaos); err != nil {
-		return fmt.Errorf("failed to create network chaos: %v", err)
+		return fmt.Errorf("failed to create dns chaos: %w", err)
 	}
 
 	return nil
 }
 
-// CleanupChaosExperiment removes a chaos experiment
-func CleanupChaosExperiment(ctx context.Context, cl client.Client, namespace, chaosName, chaosType string) error {
-	switch chaosType {
-	case "pod-delete":
-		chaos := &chaosmeshv1alpha1.PodChaos{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      chaosName,
-				Namespace: namespace,
+// podSelector selects the pods of cr's app in its namespace, narrowed down
+// by the mode of spec.
+func podSelector(cr *chaosdrv1.ChaosDRTest, spec *chaosdrv1.ChaosSpec) chaosmeshv1alpha1.PodSelector {
+	return chaosmeshv1alpha1.PodSelector{
+		Selector: chaosmeshv1alpha1.PodSelectorSpec{
+			GenericSelectorSpec: chaosmeshv1alpha1.GenericSelectorSpec{
+				Namespaces:     []string{cr.Namespace},
+				LabelSelectors: cr.Spec.AppSelector,
 			},
+		},
+		Mode:  chaosmeshv1alpha1.SelectorMode(Mode(spec)),
+		Value: spec.Value,
+	}
+}
+
+// GetChaosExperimentStatus reads the experiment records Chaos Mesh keeps on a
+// chaos object and reports whether its targets are injected or recovered.
+func GetChaosExperimentStatus(ctx context.Context, cl client.Client, namespace, chaosName, chaosType string) (*ExperimentStatus, error) {
+	obj, err := newChaosObject(chaosType)
+	if err != nil {
+		return nil, err
+	}
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: chaosName}, obj); err != nil {
+		return nil, fmt.Errorf("failed to get chaos %s: %w", chaosName, err)
+	}
+	return experimentStatus(obj.GetStatus()), nil
+}
+
+func experimentStatus(status *chaosmeshv1alpha1.ChaosStatus) *ExperimentStatus {
+	st := &ExperimentStatus{State: ExperimentPending, TotalTargets: len(status.Experiment.Records)}
+	for _, record := range status.Experiment.Records {
+		if record.Phase == chaosmeshv1alpha1.Injected {
+			st.InjectedTargets++
 		}
-		return client.IgnoreNotFound(cl.Delete(ctx, chaos))
-	case "network-delay":
-		chaos := &chaosmeshv1alpha1.NetworkChaos{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      chaosName,
-				Namespace: namespace,
-			},
+	}
+
+	for _, cond := range status.Conditions {
+		// Selected is False without a reason until the targets are first selected
+		if cond.Type == chaosmeshv1alpha1.ConditionSelected && cond.Status == corev1.ConditionFalse && cond.Reason != "" {
+			st.State = ExperimentFailed
+			st.Message = fmt.Sprintf("no targets selected: %s", cond.Reason)
+			return st
 		}
-		return client.IgnoreNotFound(cl.Delete(ctx, chaos))
+	}
+
+	switch {
+	case st.TotalTargets == 0:
+	case st.InjectedTargets > 0:
+		st.State = ExperimentInjected
+	case status.Experiment.DesiredPhase == chaosmeshv1alpha1.StoppedPhase:
+		st.State = ExperimentRecovered
+	}
+	return st
+}
+
+// CleanupChaosExperiment removes a chaos experiment
+func CleanupChaosExperiment(ctx context.Context, cl client.Client, namespace, chaosName, chaosType string) error {
+	obj, err := newChaosObject(chaosType)
+	if err != nil {
+		return err
+	}
+	obj.SetName(chaosName)
+	obj.SetNamespace(namespace)
+	return client.IgnoreNotFound(cl.Delete(ctx, obj))
+}
+
+// newChaosObject returns an empty Chaos Mesh object of the kind chaosType creates.
+func newChaosObject(chaosType string) (chaosmeshv1alpha1.StatefulObject, error) {
+	switch chaosType {
+	case chaosdrv1.ChaosTypePodDelete:
+		return &chaosmeshv1alpha1.PodChaos{}, nil
+	case chaosdrv1.ChaosTypeNetworkDelay, chaosdrv1.ChaosTypeNetworkLoss, chaosdrv1.ChaosTypeNetworkPartition,
+		chaosdrv1.ChaosTypeNetworkCorrupt, chaosdrv1.ChaosTypeNetworkDuplicate, chaosdrv1.ChaosTypeNetworkBandwidth:
+		return &chaosmeshv1alpha1.NetworkChaos{}, nil
+	case chaosdrv1.ChaosTypeCPUStress, chaosdrv1.ChaosTypeMemoryStress:
+		return &chaosmeshv1alpha1.StressChaos{}, nil
+	case chaosdrv1.ChaosTypeIOLatency, chaosdrv1.ChaosTypeIOFault, chaosdrv1.ChaosTypeIOAttrOverride:
+		return &chaosmeshv1alpha1.IOChaos{}, nil
+	case chaosdrv1.ChaosTypeTimeSkew:
+		return &chaosmeshv1alpha1.TimeChaos{}, nil
+	case chaosdrv1.ChaosTypeDNSError, chaosdrv1.ChaosTypeDNSRandom:
+		return &chaosmeshv1alpha1.DNSChaos{}, nil
 	default:
-		return fmt.Errorf("unsupported chaosType: %s", chaosType)
+		return nil, fmt.Errorf("unsupported chaosType: %s", chaosType)
 	}
 }
@@ -3,8 +3,11 @@ package chaos
 import (
 	"context"
 	"testing"
+	"time"
 
 	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -31,7 +34,7 @@ func TestApplyPodDeleteChaos(t *testing.T) {
 		},
 	}
 
-	err := applyPodDeleteChaos(context.Background(), cl, cr, "test-chaos")
+	err := applyPodDeleteChaos(context.Background(), cl, cr, "test-chaos", &chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypePodDelete, PodDelete: &chaosdrv1.PodDeleteSpec{GracePeriod: 5}})
 	if err != nil {
 		t.Fatalf("applyPodDeleteChaos failed: %v", err)
 	}
@@ -53,6 +56,9 @@ func TestApplyPodDeleteChaos(t *testing.T) {
 	if chaos.Spec.Mode != "one" {
 		t.Errorf("Expected mode 'one', got %s", chaos.Spec.Mode)
 	}
+	if chaos.Spec.GracePeriod != 5 {
+		t.Errorf("Expected grace period 5, got %d", chaos.Spec.GracePeriod)
+	}
 }
 
 func TestApplyNetworkDelayChaos(t *testing.T) {
@@ -69,17 +75,19 @@ func TestApplyNetworkDelayChaos(t *testing.T) {
 		},
 		Spec: chaosdrv1.ChaosDRTestSpec{
 			AppSelector: map[string]string{"app": "redis"},
-			ChaosType:   "network-delay",
-			ChaosParameters: map[string]string{
-				"delay":  "100ms",
-				"jitter": "10ms",
+			Chaos: &chaosdrv1.ChaosSpec{
+				Type: chaosdrv1.ChaosTypeNetworkDelay,
+				NetworkDelay: &chaosdrv1.NetworkDelaySpec{
+					Latency: "100ms",
+					Jitter:  "10ms",
+				},
 			},
 		},
 	}
 
-	err := applyNetworkDelayChaos(context.Background(), cl, cr, "test-chaos")
+	err := ApplyChaosExperiment(context.Background(), cl, cr, "test-chaos", cr.Spec.Chaos)
 	if err != nil {
-		t.Fatalf("applyNetworkDelayChaos failed: %v", err)
+		t.Fatalf("ApplyChaosExperiment failed: %v", err)
 	}
 
 	// Verify NetworkChaos was created
@@ -114,15 +122,726 @@ func TestApplyNetworkDelayChaos_MissingDelay(t *testing.T) {
 			Namespace: "default",
 		},
 		Spec: chaosdrv1.ChaosDRTestSpec{
-			AppSelector:     map[string]string{"app": "redis"},
-			ChaosType:       "network-delay",
-			ChaosParameters: map[string]string{}, // Missing delay parameter
+			AppSelector: map[string]string{"app": "redis"},
+			Chaos: &chaosdrv1.ChaosSpec{
+				Type:         chaosdrv1.ChaosTypeNetworkDelay,
+				NetworkDelay: &chaosdrv1.NetworkDelaySpec{}, // Missing latency
+			},
 		},
 	}
 
-	err := applyNetworkDelayChaos(context.Background(), cl, cr, "test-chaos")
+	err := ApplyChaosExperiment(context.Background(), cl, cr, "test-chaos", cr.Spec.Chaos)
 	if err == nil {
-		t.Fatal("Expected error for missing delay parameter, got nil")
+		t.Fatal("Expected error for missing latency, got nil")
+	}
+}
+
+func TestApplyNetworkLossChaos(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = chaosmeshv1alpha1.AddToScheme(scheme)
+	_ = chaosdrv1.AddToScheme(scheme)
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	cr := &chaosdrv1.ChaosDRTest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-dr",
+			Namespace: "default",
+		},
+		Spec: chaosdrv1.ChaosDRTestSpec{
+			AppSelector: map[string]string{"app": "redis"},
+			Chaos: &chaosdrv1.ChaosSpec{
+				Type:        chaosdrv1.ChaosTypeNetworkLoss,
+				NetworkLoss: &chaosdrv1.NetworkLossSpec{Loss: "25", Correlation: "50"},
+			},
+		},
+	}
+
+	err := ApplyChaosExperiment(context.Background(), cl, cr, "test-chaos", cr.Spec.Chaos)
+	if err != nil {
+		t.Fatalf("ApplyChaosExperiment failed: %v", err)
+	}
+
+	chaos := &chaosmeshv1alpha1.NetworkChaos{}
+	err = cl.Get(context.Background(), client.ObjectKey{
+		Name:      "test-chaos",
+		Namespace: "default",
+	}, chaos)
+	if err != nil {
+		t.Fatalf("Failed to get created NetworkChaos: %v", err)
+	}
+
+	if chaos.Spec.Action != chaosmeshv1alpha1.LossAction {
+		t.Errorf("Expected action loss, got %s", chaos.Spec.Action)
+	}
+	if chaos.Spec.Loss == nil || chaos.Spec.Loss.Loss != "25" || chaos.Spec.Loss.Correlation != "50" {
+		t.Errorf("Expected loss 25 with correlation 50, got %+v", chaos.Spec.Loss)
+	}
+}
+
+func TestApplyNetworkPartitionChaos(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = chaosmeshv1alpha1.AddToScheme(scheme)
+	_ = chaosdrv1.AddToScheme(scheme)
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	cr := &chaosdrv1.ChaosDRTest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-dr",
+			Namespace: "default",
+		},
+		Spec: chaosdrv1.ChaosDRTestSpec{
+			AppSelector: map[string]string{"app": "redis", "role": "primary"},
+			Chaos: &chaosdrv1.ChaosSpec{
+				Type: chaosdrv1.ChaosTypeNetworkPartition,
+				NetworkPartition: &chaosdrv1.NetworkPartitionSpec{
+					Direction: "to",
+					Target:    &chaosdrv1.NetworkTarget{Selector: map[string]string{"app": "redis", "role": "replica"}},
+				},
+			},
+		},
+	}
+
+	err := ApplyChaosExperiment(context.Background(), cl, cr, "test-chaos", cr.Spec.Chaos)
+	if err != nil {
+		t.Fatalf("ApplyChaosExperiment failed: %v", err)
+	}
+
+	chaos := &chaosmeshv1alpha1.NetworkChaos{}
+	err = cl.Get(context.Background(), client.ObjectKey{
+		Name:      "test-chaos",
+		Namespace: "default",
+	}, chaos)
+	if err != nil {
+		t.Fatalf("Failed to get created NetworkChaos: %v", err)
+	}
+
+	if chaos.Spec.Action != chaosmeshv1alpha1.PartitionAction {
+		t.Errorf("Expected action partition, got %s", chaos.Spec.Action)
+	}
+	if chaos.Spec.Direction != chaosmeshv1alpha1.To {
+		t.Errorf("Expected direction to, got %s", chaos.Spec.Direction)
+	}
+	if chaos.Spec.Target == nil {
+		t.Fatal("Expected partition target to be set")
+	}
+	target := chaos.Spec.Target.Selector
+	if target.LabelSelectors["role"] != "replica" || len(target.Namespaces) != 1 || target.Namespaces[0] != "default" {
+		t.Errorf("Expected replicas in default to be targeted, got %+v", target.GenericSelectorSpec)
+	}
+}
+
+func TestApplyNetworkChaosActions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = chaosmeshv1alpha1.AddToScheme(scheme)
+	_ = chaosdrv1.AddToScheme(scheme)
+
+	tests := []struct {
+		spec   chaosdrv1.ChaosSpec
+		action chaosmeshv1alpha1.NetworkChaosAction
+		check  func(tc chaosmeshv1alpha1.TcParameter) bool
+	}{
+		{
+			spec: chaosdrv1.ChaosSpec{
+				Type:           chaosdrv1.ChaosTypeNetworkCorrupt,
+				NetworkCorrupt: &chaosdrv1.NetworkCorruptSpec{Corrupt: "10"},
+			},
+			action: chaosmeshv1alpha1.CorruptAction,
+			check:  func(tc chaosmeshv1alpha1.TcParameter) bool { return tc.Corrupt != nil && tc.Corrupt.Corrupt == "10" },
+		},
+		{
+			spec: chaosdrv1.ChaosSpec{
+				Type:             chaosdrv1.ChaosTypeNetworkDuplicate,
+				NetworkDuplicate: &chaosdrv1.NetworkDuplicateSpec{Duplicate: "5", Correlation: "20"},
+			},
+			action: chaosmeshv1alpha1.DuplicateAction,
+			check: func(tc chaosmeshv1alpha1.TcParameter) bool {
+				return tc.Duplicate != nil && tc.Duplicate.Duplicate == "5" && tc.Duplicate.Correlation == "20"
+			},
+		},
+		{
+			spec: chaosdrv1.ChaosSpec{
+				Type:             chaosdrv1.ChaosTypeNetworkBandwidth,
+				NetworkBandwidth: &chaosdrv1.NetworkBandwidthSpec{Rate: "1mbps"},
+			},
+			action: chaosmeshv1alpha1.BandwidthAction,
+			check: func(tc chaosmeshv1alpha1.TcParameter) bool {
+				// Unset bucket sizes fall back to the defaults
+				return tc.Bandwidth != nil && tc.Bandwidth.Rate == "1mbps" &&
+					tc.Bandwidth.Limit == defaultBandwidthLimit && tc.Bandwidth.Buffer == defaultBandwidthBuffer
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec.Type, func(t *testing.T) {
+			cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+			cr := &chaosdrv1.ChaosDRTest{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-dr", Namespace: "default"},
+				Spec:       chaosdrv1.ChaosDRTestSpec{AppSelector: map[string]string{"app": "redis"}, Chaos: &tt.spec},
+			}
+
+			if err := ApplyChaosExperiment(context.Background(), cl, cr, "test-chaos", cr.Spec.Chaos); err != nil {
+				t.Fatalf("ApplyChaosExperiment failed: %v", err)
+			}
+
+			chaos := &chaosmeshv1alpha1.NetworkChaos{}
+			if err := cl.Get(context.Background(), client.ObjectKey{Name: "test-chaos", Namespace: "default"}, chaos); err != nil {
+				t.Fatalf("Failed to get created NetworkChaos: %v", err)
+			}
+			if chaos.Spec.Action != tt.action {
+				t.Errorf("Expected action %s, got %s", tt.action, chaos.Spec.Action)
+			}
+			if !tt.check(chaos.Spec.TcParameter) {
+				t.Errorf("Unexpected tc parameters %+v", chaos.Spec.TcParameter)
+			}
+			if chaos.Spec.Mode != chaosmeshv1alpha1.AllMode {
+				t.Errorf("Expected mode all, got %s", chaos.Spec.Mode)
+			}
+		})
+	}
+}
+
+func TestApplyCPUStressChaos(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = chaosmeshv1alpha1.AddToScheme(scheme)
+	_ = chaosdrv1.AddToScheme(scheme)
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	cr := &chaosdrv1.ChaosDRTest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-dr",
+			Namespace: "default",
+		},
+		Spec: chaosdrv1.ChaosDRTestSpec{
+			AppSelector: map[string]string{"app": "redis"},
+			ChaosType:   "cpu-stress",
+			ChaosParameters: map[string]string{
+				"workers": "2",
+				"load":    "80",
+			},
+			ChaosDuration: &metav1.Duration{Duration: time.Minute},
+		},
+	}
+
+	// The legacy chaosType and chaosParameters are converted to a typed spec
+	spec, err := ResolveSpec(cr)
+	if err != nil {
+		t.Fatalf("ResolveSpec failed: %v", err)
+	}
+	err = ApplyChaosExperiment(context.Background(), cl, cr, "test-chaos", spec)
+	if err != nil {
+		t.Fatalf("ApplyChaosExperiment failed: %v", err)
+	}
+
+	// Verify StressChaos was created
+	chaos := &chaosmeshv1alpha1.StressChaos{}
+	err = cl.Get(context.Background(), client.ObjectKey{
+		Name:      "test-chaos",
+		Namespace: "default",
+	}, chaos)
+	if err != nil {
+		t.Fatalf("Failed to get created StressChaos: %v", err)
+	}
+
+	// Verify spec
+	cpu := chaos.Spec.Stressors.CPUStressor
+	if cpu == nil {
+		t.Fatal("Expected CPU stressor to be set")
+	}
+	if cpu.Workers != 2 {
+		t.Errorf("Expected 2 workers, got %d", cpu.Workers)
+	}
+	if cpu.Load == nil || *cpu.Load != 80 {
+		t.Errorf("Expected load 80, got %v", cpu.Load)
+	}
+	if chaos.Spec.Stressors.MemoryStressor != nil {
+		t.Error("Expected no memory stressor")
+	}
+	if chaos.Spec.Duration == nil || *chaos.Spec.Duration != "1m0s" {
+		t.Errorf("Expected duration 1m0s, got %v", chaos.Spec.Duration)
+	}
+}
+
+func TestApplyMemoryStressChaos(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = chaosmeshv1alpha1.AddToScheme(scheme)
+	_ = chaosdrv1.AddToScheme(scheme)
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	cr := &chaosdrv1.ChaosDRTest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-dr",
+			Namespace: "default",
+		},
+		Spec: chaosdrv1.ChaosDRTestSpec{
+			AppSelector: map[string]string{"app": "redis"},
+			ChaosType:   "memory-stress",
+			ChaosParameters: map[string]string{
+				"size": "256MB",
+			},
+		},
+	}
+
+	// The legacy chaosType and chaosParameters are converted to a typed spec
+	spec, err := ResolveSpec(cr)
+	if err != nil {
+		t.Fatalf("ResolveSpec failed: %v", err)
+	}
+	err = ApplyChaosExperiment(context.Background(), cl, cr, "test-chaos", spec)
+	if err != nil {
+		t.Fatalf("ApplyChaosExperiment failed: %v", err)
+	}
+
+	chaos := &chaosmeshv1alpha1.StressChaos{}
+	err = cl.Get(context.Background(), client.ObjectKey{
+		Name:      "test-chaos",
+		Namespace: "default",
+	}, chaos)
+	if err != nil {
+		t.Fatalf("Failed to get created StressChaos: %v", err)
+	}
+
+	memory := chaos.Spec.Stressors.MemoryStressor
+	if memory == nil {
+		t.Fatal("Expected memory stressor to be set")
+	}
+	if memory.Workers != 1 {
+		t.Errorf("Expected default of 1 worker, got %d", memory.Workers)
+	}
+	if memory.Size != "256MB" {
+		t.Errorf("Expected size 256MB, got %s", memory.Size)
+	}
+	if chaos.Spec.Duration == nil || *chaos.Spec.Duration != DefaultDuration.String() {
+		t.Errorf("Expected default duration %s, got %v", DefaultDuration, chaos.Spec.Duration)
+	}
+}
+
+func TestApplyTimeSkewChaos(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = chaosmeshv1alpha1.AddToScheme(scheme)
+	_ = chaosdrv1.AddToScheme(scheme)
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	cr := &chaosdrv1.ChaosDRTest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-dr",
+			Namespace: "default",
+		},
+		Spec: chaosdrv1.ChaosDRTestSpec{
+			AppSelector: map[string]string{"app": "etcd"},
+			Chaos: &chaosdrv1.ChaosSpec{
+				Type: chaosdrv1.ChaosTypeTimeSkew,
+				TimeSkew: &chaosdrv1.TimeSkewSpec{
+					Offset:   "-10m",
+					ClockIDs: []string{"CLOCK_REALTIME", "CLOCK_MONOTONIC"},
+				},
+			},
+		},
+	}
+
+	if err := ApplyChaosExperiment(context.Background(), cl, cr, "test-chaos", cr.Spec.Chaos); err != nil {
+		t.Fatalf("ApplyChaosExperiment failed: %v", err)
+	}
+
+	chaos := &chaosmeshv1alpha1.TimeChaos{}
+	if err := cl.Get(context.Background(), client.ObjectKey{Name: "test-chaos", Namespace: "default"}, chaos); err != nil {
+		t.Fatalf("Failed to get created TimeChaos: %v", err)
+	}
+	if chaos.Spec.TimeOffset != "-10m" {
+		t.Errorf("Expected offset -10m, got %q", chaos.Spec.TimeOffset)
+	}
+	if len(chaos.Spec.ClockIds) != 2 || chaos.Spec.ClockIds[1] != "CLOCK_MONOTONIC" {
+		t.Errorf("Expected realtime and monotonic clocks, got %v", chaos.Spec.ClockIds)
+	}
+	if chaos.Spec.Mode != chaosmeshv1alpha1.AllMode || chaos.Spec.Selector.LabelSelectors["app"] != "etcd" {
+		t.Errorf("Expected all etcd pods to be selected, got %+v", chaos.Spec.PodSelector)
+	}
+}
+
+func TestApplyDNSChaos(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = chaosmeshv1alpha1.AddToScheme(scheme)
+	_ = chaosdrv1.AddToScheme(scheme)
+
+	tests := []struct {
+		spec     chaosdrv1.ChaosSpec
+		action   chaosmeshv1alpha1.DNSChaosAction
+		patterns []string
+	}{
+		{
+			spec: chaosdrv1.ChaosSpec{
+				Type: chaosdrv1.ChaosTypeDNSError,
+				DNS:  &chaosdrv1.DNSSpec{Patterns: []string{"postgres.*", "redis-?.cache"}},
+			},
+			action:   chaosmeshv1alpha1.ErrorAction,
+			patterns: []string{"postgres.*", "redis-?.cache"},
+		},
+		{
+			// Without patterns every domain is affected
+			spec:   chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypeDNSRandom},
+			action: chaosmeshv1alpha1.RandomAction,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec.Type, func(t *testing.T) {
+			cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+			cr := &chaosdrv1.ChaosDRTest{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-dr", Namespace: "default"},
+				Spec:       chaosdrv1.ChaosDRTestSpec{AppSelector: map[string]string{"app": "api"}, Chaos: &tt.spec},
+			}
+
+			if err := ApplyChaosExperiment(context.Background(), cl, cr, "test-chaos", cr.Spec.Chaos); err != nil {
+				t.Fatalf("ApplyChaosExperiment failed: %v", err)
+			}
+
+			chaos := &chaosmeshv1alpha1.DNSChaos{}
+			if err := cl.Get(context.Background(), client.ObjectKey{Name: "test-chaos", Namespace: "default"}, chaos); err != nil {
+				t.Fatalf("Failed to get created DNSChaos: %v", err)
+			}
+			if chaos.Spec.Action != tt.action {
+				t.Errorf("Expected action %s, got %s", tt.action, chaos.Spec.Action)
+			}
+			if len(chaos.Spec.DomainNamePatterns) != len(tt.patterns) {
+				t.Errorf("Expected patterns %v, got %v", tt.patterns, chaos.Spec.DomainNamePatterns)
+			}
+			if chaos.Spec.Duration == nil || *chaos.Spec.Duration != DefaultDuration.String() {
+				t.Errorf("Expected default duration, got %v", chaos.Spec.Duration)
+			}
+		})
+	}
+}
+
+// newIOTestClient returns a client holding a redis pod whose redis container
+// mounts the redis-data PVC at /data, next to a sidecar that does not.
+func newIOTestClient(extra ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = chaosmeshv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "redis-0", Namespace: "default", Labels: map[string]string{"app": "redis"}},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "exporter"},
+				{Name: "redis", VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: "/data"}}},
+			},
+			Volumes: []corev1.Volume{{
+				Name: "data",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "redis-data"},
+				},
+			}},
+		},
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(extra, pod)...).Build()
+}
+
+func TestApplyIOLatencyChaos(t *testing.T) {
+	cl := newIOTestClient()
+	cr := &chaosdrv1.ChaosDRTest{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-dr", Namespace: "default"},
+		Spec: chaosdrv1.ChaosDRTestSpec{
+			AppSelector: map[string]string{"app": "redis"},
+			Chaos: &chaosdrv1.ChaosSpec{
+				Type: chaosdrv1.ChaosTypeIOLatency,
+				IO: &chaosdrv1.IOSpec{
+					Delay:   "100ms",
+					Path:    "/data/**/*.rdb",
+					Methods: []string{"read", "write"},
+				},
+			},
+		},
+	}
+
+	if err := ApplyChaosExperiment(context.Background(), cl, cr, "test-chaos", cr.Spec.Chaos); err != nil {
+		t.Fatalf("ApplyChaosExperiment failed: %v", err)
+	}
+
+	chaos := &chaosmeshv1alpha1.IOChaos{}
+	if err := cl.Get(context.Background(), client.ObjectKey{Name: "test-chaos", Namespace: "default"}, chaos); err != nil {
+		t.Fatalf("Failed to get created IOChaos: %v", err)
+	}
+	if chaos.Spec.Action != chaosmeshv1alpha1.IoLatency || chaos.Spec.Delay != "100ms" {
+		t.Errorf("Expected latency of 100ms, got %s %q", chaos.Spec.Action, chaos.Spec.Delay)
+	}
+	if chaos.Spec.VolumePath != "/data" {
+		t.Errorf("Expected volume path resolved to /data, got %q", chaos.Spec.VolumePath)
+	}
+	if len(chaos.Spec.ContainerNames) != 1 || chaos.Spec.ContainerNames[0] != "redis" {
+		t.Errorf("Expected the redis container to be injected, got %v", chaos.Spec.ContainerNames)
+	}
+	if chaos.Spec.Path != "/data/**/*.rdb" || len(chaos.Spec.Methods) != 2 || chaos.Spec.Methods[1] != chaosmeshv1alpha1.Write {
+		t.Errorf("Expected path and method filters to be kept, got %q %v", chaos.Spec.Path, chaos.Spec.Methods)
+	}
+	if chaos.Spec.Percent != 100 {
+		t.Errorf("Expected every call to be injected, got %d%%", chaos.Spec.Percent)
+	}
+}
+
+func TestApplyIOFaultAndAttrOverrideChaos(t *testing.T) {
+	perm := uint16(0o444)
+	tests := []struct {
+		spec  chaosdrv1.ChaosSpec
+		check func(spec chaosmeshv1alpha1.IOChaosSpec) bool
+	}{
+		{
+			spec: chaosdrv1.ChaosSpec{
+				Type: chaosdrv1.ChaosTypeIOFault,
+				IO:   &chaosdrv1.IOSpec{Errno: 28, Percent: 50},
+			},
+			check: func(spec chaosmeshv1alpha1.IOChaosSpec) bool {
+				return spec.Action == chaosmeshv1alpha1.IoFaults && spec.Errno == 28 && spec.Percent == 50
+			},
+		},
+		{
+			spec: chaosdrv1.ChaosSpec{
+				Type: chaosdrv1.ChaosTypeIOAttrOverride,
+				IO:   &chaosdrv1.IOSpec{Attr: &chaosdrv1.IOAttrOverrideSpec{Perm: &perm, Kind: "regularFile"}},
+			},
+			check: func(spec chaosmeshv1alpha1.IOChaosSpec) bool {
+				return spec.Action == chaosmeshv1alpha1.IoAttrOverride && spec.Attr != nil &&
+					*spec.Attr.Perm == perm && *spec.Attr.Kind == chaosmeshv1alpha1.RegularFile
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec.Type, func(t *testing.T) {
+			cl := newIOTestClient()
+			cr := &chaosdrv1.ChaosDRTest{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-dr", Namespace: "default"},
+				Spec:       chaosdrv1.ChaosDRTestSpec{AppSelector: map[string]string{"app": "redis"}, Chaos: &tt.spec},
+			}
+
+			if err := ApplyChaosExperiment(context.Background(), cl, cr, "test-chaos", cr.Spec.Chaos); err != nil {
+				t.Fatalf("ApplyChaosExperiment failed: %v", err)
+			}
+
+			chaos := &chaosmeshv1alpha1.IOChaos{}
+			if err := cl.Get(context.Background(), client.ObjectKey{Name: "test-chaos", Namespace: "default"}, chaos); err != nil {
+				t.Fatalf("Failed to get created IOChaos: %v", err)
+			}
+			if !tt.check(chaos.Spec) {
+				t.Errorf("Unexpected io chaos spec %+v", chaos.Spec)
+			}
+		})
+	}
+}
+
+func TestApplyIOChaos_VolumeResolution(t *testing.T) {
+	// A second pod of the app mounts another PVC at another path
+	other := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "redis-1", Namespace: "default", Labels: map[string]string{"app": "redis"}},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "redis", VolumeMounts: []corev1.VolumeMount{{Name: "logs", MountPath: "/logs"}}},
+			},
+			Volumes: []corev1.Volume{{
+				Name: "logs",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "redis-logs"},
+				},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		selector map[string]string
+		io       chaosdrv1.IOSpec
+		path     string
+		wantErr  bool
+	}{
+		{"several paths", map[string]string{"app": "redis"}, chaosdrv1.IOSpec{}, "", true},
+		{"volume selects the PVC", map[string]string{"app": "redis"}, chaosdrv1.IOSpec{Volume: "redis-logs"}, "/logs", false},
+		{"volume path overrides", map[string]string{"app": "redis"}, chaosdrv1.IOSpec{VolumePath: "/tmp"}, "/tmp", false},
+		{"unknown volume", map[string]string{"app": "redis"}, chaosdrv1.IOSpec{Volume: "missing"}, "", true},
+		{"no PVC", map[string]string{"app": "nginx"}, chaosdrv1.IOSpec{}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cl := newIOTestClient(other)
+			tt.io.Errno = 5
+			cr := &chaosdrv1.ChaosDRTest{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-dr", Namespace: "default"},
+				Spec: chaosdrv1.ChaosDRTestSpec{
+					AppSelector: tt.selector,
+					Chaos:       &chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypeIOFault, IO: &tt.io},
+				},
+			}
+
+			err := ApplyChaosExperiment(context.Background(), cl, cr, "test-chaos", cr.Spec.Chaos)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ApplyChaosExperiment failed: %v", err)
+			}
+
+			chaos := &chaosmeshv1alpha1.IOChaos{}
+			if err := cl.Get(context.Background(), client.ObjectKey{Name: "test-chaos", Namespace: "default"}, chaos); err != nil {
+				t.Fatalf("Failed to get created IOChaos: %v", err)
+			}
+			if chaos.Spec.VolumePath != tt.path {
+				t.Errorf("Expected volume path %s, got %s", tt.path, chaos.Spec.VolumePath)
+			}
+		})
+	}
+}
+
+func TestResolveSpec_LegacyParameters(t *testing.T) {
+	cr := &chaosdrv1.ChaosDRTest{
+		Spec: chaosdrv1.ChaosDRTestSpec{
+			ChaosType:       "network-delay",
+			ChaosParameters: map[string]string{"delay": "100ms", "correlation": "25"},
+		},
+	}
+
+	spec, err := ResolveSpec(cr)
+	if err != nil {
+		t.Fatalf("ResolveSpec failed: %v", err)
+	}
+	if spec.Type != chaosdrv1.ChaosTypeNetworkDelay || spec.NetworkDelay == nil {
+		t.Fatalf("Expected network-delay spec, got %+v", spec)
+	}
+	if spec.NetworkDelay.Latency != "100ms" || spec.NetworkDelay.Correlation != "25" {
+		t.Errorf("Expected latency 100ms with correlation 25, got %+v", spec.NetworkDelay)
+	}
+
+	cr.Spec.ChaosType = "network-partition"
+	cr.Spec.ChaosParameters = map[string]string{"direction": "from", "target": "app=redis,role=replica", "targetNamespace": "cache"}
+	spec, err = ResolveSpec(cr)
+	if err != nil {
+		t.Fatalf("ResolveSpec failed: %v", err)
+	}
+	partition := spec.NetworkPartition
+	if partition == nil || partition.Direction != "from" || partition.Target == nil {
+		t.Fatalf("Expected partition from a target, got %+v", partition)
+	}
+	if partition.Target.Selector["role"] != "replica" || partition.Target.Namespace != "cache" {
+		t.Errorf("Expected replicas in cache to be targeted, got %+v", partition.Target)
+	}
+
+	cr.Spec.ChaosType = "io-attr-override"
+	cr.Spec.ChaosParameters = map[string]string{"volume": "redis-data", "methods": "open,read", "perm": "0444"}
+	spec, err = ResolveSpec(cr)
+	if err != nil {
+		t.Fatalf("ResolveSpec failed: %v", err)
+	}
+	if spec.IO == nil || spec.IO.Volume != "redis-data" || len(spec.IO.Methods) != 2 {
+		t.Fatalf("Expected io on redis-data for two methods, got %+v", spec.IO)
+	}
+	if spec.IO.Attr == nil || spec.IO.Attr.Perm == nil || *spec.IO.Attr.Perm != 0o444 {
+		t.Errorf("Expected octal permission 0444, got %+v", spec.IO.Attr)
+	}
+
+	cr.Spec.ChaosType = "time-skew"
+	cr.Spec.ChaosParameters = map[string]string{"offset": "2h45m", "clockIds": "CLOCK_REALTIME,CLOCK_BOOTTIME"}
+	spec, err = ResolveSpec(cr)
+	if err != nil {
+		t.Fatalf("ResolveSpec failed: %v", err)
+	}
+	if spec.TimeSkew == nil || spec.TimeSkew.Offset != "2h45m" || len(spec.TimeSkew.ClockIDs) != 2 {
+		t.Errorf("Expected a 2h45m skew of two clocks, got %+v", spec.TimeSkew)
+	}
+
+	cr.Spec.ChaosType = "dns-error"
+	cr.Spec.ChaosParameters = map[string]string{"patterns": "postgres.*,redis.cache"}
+	spec, err = ResolveSpec(cr)
+	if err != nil {
+		t.Fatalf("ResolveSpec failed: %v", err)
+	}
+	if spec.DNS == nil || len(spec.DNS.Patterns) != 2 || spec.DNS.Patterns[0] != "postgres.*" {
+		t.Errorf("Expected two domain patterns, got %+v", spec.DNS)
+	}
+
+	cr.Spec.ChaosType = "node-drain"
+	cr.Spec.ChaosParameters = map[string]string{"maxNodes": "2"}
+	spec, err = ResolveSpec(cr)
+	if err != nil {
+		t.Fatalf("ResolveSpec failed: %v", err)
+	}
+	if spec.NodeDrain == nil || spec.NodeDrain.MaxNodes != 2 {
+		t.Errorf("Expected a drain of up to two nodes, got %+v", spec.NodeDrain)
+	}
+
+	// A typed spec takes precedence over the legacy fields
+	cr.Spec.Chaos = &chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypePodDelete}
+	spec, err = ResolveSpec(cr)
+	if err != nil {
+		t.Fatalf("ResolveSpec failed: %v", err)
+	}
+	if spec.Type != chaosdrv1.ChaosTypePodDelete {
+		t.Errorf("Expected pod-delete, got %s", spec.Type)
+	}
+}
+
+func TestResolveSpec_Invalid(t *testing.T) {
+	load := 150
+	tests := []struct {
+		name string
+		spec chaosdrv1.ChaosDRTestSpec
+	}{
+		{"typo in parameter", chaosdrv1.ChaosDRTestSpec{ChaosType: "network-delay", ChaosParameters: map[string]string{"dealy": "100ms"}}},
+		{"missing delay", chaosdrv1.ChaosDRTestSpec{ChaosType: "network-delay"}},
+		{"zero workers", chaosdrv1.ChaosDRTestSpec{ChaosType: "cpu-stress", ChaosParameters: map[string]string{"workers": "0"}}},
+		{"load above 100", chaosdrv1.ChaosDRTestSpec{ChaosType: "cpu-stress", ChaosParameters: map[string]string{"load": "150"}}},
+		{"bad memory size", chaosdrv1.ChaosDRTestSpec{ChaosType: "memory-stress", ChaosParameters: map[string]string{"size": "a lot"}}},
+		{"unsupported type", chaosdrv1.ChaosDRTestSpec{ChaosType: "io-stress"}},
+		{"no chaos", chaosdrv1.ChaosDRTestSpec{}},
+		{"partition without target", chaosdrv1.ChaosDRTestSpec{ChaosType: "network-partition", ChaosParameters: map[string]string{"direction": "both"}}},
+		{"bad partition target", chaosdrv1.ChaosDRTestSpec{ChaosType: "network-partition", ChaosParameters: map[string]string{"target": "app"}}},
+		{"bad partition direction", chaosdrv1.ChaosDRTestSpec{ChaosType: "network-partition", ChaosParameters: map[string]string{"target": "app=redis", "direction": "sideways"}}},
+		{"missing corrupt", chaosdrv1.ChaosDRTestSpec{ChaosType: "network-corrupt", ChaosParameters: map[string]string{"correlation": "10"}}},
+		{"duplicate above 100", chaosdrv1.ChaosDRTestSpec{ChaosType: "network-duplicate", ChaosParameters: map[string]string{"duplicate": "150"}}},
+		{"bad bandwidth rate", chaosdrv1.ChaosDRTestSpec{ChaosType: "network-bandwidth", ChaosParameters: map[string]string{"rate": "1 megabit"}}},
+		{"missing io delay", chaosdrv1.ChaosDRTestSpec{ChaosType: "io-latency", ChaosParameters: map[string]string{"path": "/data/*"}}},
+		{"zero errno", chaosdrv1.ChaosDRTestSpec{ChaosType: "io-fault", ChaosParameters: map[string]string{"errno": "0"}}},
+		{"unknown io method", chaosdrv1.ChaosDRTestSpec{ChaosType: "io-fault", ChaosParameters: map[string]string{"errno": "5", "methods": "read,wirte"}}},
+		{"io percent above 100", chaosdrv1.ChaosDRTestSpec{ChaosType: "io-fault", ChaosParameters: map[string]string{"errno": "5", "percent": "150"}}},
+		{"empty attr override", chaosdrv1.ChaosDRTestSpec{ChaosType: "io-attr-override"}},
+		{"errno on io-latency", chaosdrv1.ChaosDRTestSpec{Chaos: &chaosdrv1.ChaosSpec{
+			Type: chaosdrv1.ChaosTypeIOLatency,
+			IO:   &chaosdrv1.IOSpec{Delay: "10ms", Errno: 5},
+		}}},
+		{"missing offset", chaosdrv1.ChaosDRTestSpec{ChaosType: "time-skew"}},
+		{"zero offset", chaosdrv1.ChaosDRTestSpec{ChaosType: "time-skew", ChaosParameters: map[string]string{"offset": "0s"}}},
+		{"bad offset", chaosdrv1.ChaosDRTestSpec{ChaosType: "time-skew", ChaosParameters: map[string]string{"offset": "ten minutes"}}},
+		{"unknown clock", chaosdrv1.ChaosDRTestSpec{ChaosType: "time-skew", ChaosParameters: map[string]string{"offset": "1h", "clockIds": "CLOCK_WALL"}}},
+		{"wildcard inside pattern", chaosdrv1.ChaosDRTestSpec{ChaosType: "dns-error", ChaosParameters: map[string]string{"patterns": "chaos-*.org"}}},
+		{"empty pattern", chaosdrv1.ChaosDRTestSpec{ChaosType: "dns-random", ChaosParameters: map[string]string{"patterns": "a.org,"}}},
+		{"zero max nodes", chaosdrv1.ChaosDRTestSpec{ChaosType: "node-drain", ChaosParameters: map[string]string{"maxNodes": "0"}}},
+		{"parameters on scale-to-zero", chaosdrv1.ChaosDRTestSpec{ChaosType: "scale-to-zero", ChaosParameters: map[string]string{"replicas": "1"}}},
+		{"bad bandwidth limit", chaosdrv1.ChaosDRTestSpec{ChaosType: "network-bandwidth", ChaosParameters: map[string]string{"rate": "1mbps", "limit": "-1"}}},
+		{"wrong member", chaosdrv1.ChaosDRTestSpec{Chaos: &chaosdrv1.ChaosSpec{
+			Type:         chaosdrv1.ChaosTypePodDelete,
+			NetworkDelay: &chaosdrv1.NetworkDelaySpec{Latency: "100ms"},
+		}}},
+		{"typed load above 100", chaosdrv1.ChaosDRTestSpec{Chaos: &chaosdrv1.ChaosSpec{
+			Type:   chaosdrv1.ChaosTypeCPUStress,
+			Stress: &chaosdrv1.StressSpec{Workers: 1, Load: &load},
+		}}},
+		{"bad latency", chaosdrv1.ChaosDRTestSpec{Chaos: &chaosdrv1.ChaosSpec{
+			Type:         chaosdrv1.ChaosTypeNetworkDelay,
+			NetworkDelay: &chaosdrv1.NetworkDelaySpec{Latency: "100"},
+		}}},
+	}
+
+	for _, tt := range tests {
+		cr := &chaosdrv1.ChaosDRTest{Spec: tt.spec}
+		if _, err := ResolveSpec(cr); err == nil {
+			t.Errorf("%s: expected error, got nil", tt.name)
+		}
 	}
 }
 
@@ -154,3 +873,173 @@ func TestCleanupChaosExperiment(t *testing.T) {
 		t.Fatal("Expected PodChaos to be deleted")
 	}
 }
+
+func TestGetChaosExperimentStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = chaosmeshv1alpha1.AddToScheme(scheme)
+
+	tests := []struct {
+		name       string
+		status     chaosmeshv1alpha1.ChaosStatus
+		wantState  ExperimentState
+		wantTarget int
+	}{
+		{
+			name:      "not selected yet",
+			status:    chaosmeshv1alpha1.ChaosStatus{Conditions: []chaosmeshv1alpha1.ChaosCondition{{Type: chaosmeshv1alpha1.ConditionSelected, Status: corev1.ConditionFalse}}},
+			wantState: ExperimentPending,
+		},
+		{
+			name: "injected",
+			status: chaosmeshv1alpha1.ChaosStatus{Experiment: chaosmeshv1alpha1.ExperimentStatus{
+				DesiredPhase: chaosmeshv1alpha1.RunningPhase,
+				Records: []*chaosmeshv1alpha1.Record{
+					{Id: "default/redis-0", Phase: chaosmeshv1alpha1.Injected},
+					{Id: "default/redis-1", Phase: chaosmeshv1alpha1.NotInjected},
+				},
+			}},
+			wantState:  ExperimentInjected,
+			wantTarget: 1,
+		},
+		{
+			name: "recovered",
+			status: chaosmeshv1alpha1.ChaosStatus{Experiment: chaosmeshv1alpha1.ExperimentStatus{
+				DesiredPhase: chaosmeshv1alpha1.StoppedPhase,
+				Records:      []*chaosmeshv1alpha1.Record{{Id: "default/redis-0", Phase: chaosmeshv1alpha1.NotInjected}},
+			}},
+			wantState: ExperimentRecovered,
+		},
+		{
+			name:      "selection failed",
+			status:    chaosmeshv1alpha1.ChaosStatus{Conditions: []chaosmeshv1alpha1.ChaosCondition{{Type: chaosmeshv1alpha1.ConditionSelected, Status: corev1.ConditionFalse, Reason: "no pod is selected"}}},
+			wantState: ExperimentFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chaos := &chaosmeshv1alpha1.NetworkChaos{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-chaos", Namespace: "default"},
+				Status:     chaosmeshv1alpha1.NetworkChaosStatus{ChaosStatus: tt.status},
+			}
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(chaos).Build()
+
+			st, err := GetChaosExperimentStatus(context.Background(), cl, "default", "test-chaos", "network-delay")
+			if err != nil {
+				t.Fatalf("GetChaosExperimentStatus failed: %v", err)
+			}
+			if st.State != tt.wantState || st.InjectedTargets != tt.wantTarget {
+				t.Errorf("Expected state %s with %d injected, got %+v", tt.wantState, tt.wantTarget, st)
+			}
+		})
+	}
+}
+
+func TestGetChaosExperimentStatus_NotFound(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = chaosmeshv1alpha1.AddToScheme(scheme)
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	_, err := GetChaosExperimentStatus(context.Background(), cl, "default", "test-chaos", "pod-delete")
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("Expected NotFound error, got %v", err)
+	}
+}
+
+func TestCleanupChaosExperiment_Stress(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = chaosmeshv1alpha1.AddToScheme(scheme)
+
+	chaos := &chaosmeshv1alpha1.StressChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-chaos",
+			Namespace: "default",
+		},
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(chaos).Build()
+
+	for _, chaosType := range []string{"cpu-stress", "memory-stress"} {
+		// The second cleanup finds nothing left, which is not an error
+		if err := CleanupChaosExperiment(context.Background(), cl, "default", "test-chaos", chaosType); err != nil {
+			t.Fatalf("CleanupChaosExperiment(%s) failed: %v", chaosType, err)
+		}
+	}
+
+	err := cl.Get(context.Background(), client.ObjectKey{
+		Name:      "test-chaos",
+		Namespace: "default",
+	}, chaos)
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("Expected StressChaos to be deleted, got %v", err)
+	}
+}
+
+func TestCleanupChaosExperiment_Network(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = chaosmeshv1alpha1.AddToScheme(scheme)
+
+	for _, chaosType := range []string{"network-loss", "network-partition", "network-corrupt", "network-duplicate", "network-bandwidth"} {
+		chaos := &chaosmeshv1alpha1.NetworkChaos{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-chaos",
+				Namespace: "default",
+			},
+		}
+		cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(chaos).Build()
+
+		if err := CleanupChaosExperiment(context.Background(), cl, "default", "test-chaos", chaosType); err != nil {
+			t.Fatalf("CleanupChaosExperiment(%s) failed: %v", chaosType, err)
+		}
+		err := cl.Get(context.Background(), client.ObjectKey{Name: "test-chaos", Namespace: "default"}, chaos)
+		if !apierrors.IsNotFound(err) {
+			t.Errorf("Expected NetworkChaos of %s to be deleted, got %v", chaosType, err)
+		}
+	}
+}
+
+func TestCleanupChaosExperiment_IO(t *testing.T) {
+	chaos := &chaosmeshv1alpha1.IOChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-chaos",
+			Namespace: "default",
+		},
+	}
+	cl := newIOTestClient(chaos)
+
+	if err := CleanupChaosExperiment(context.Background(), cl, "default", "test-chaos", "io-fault"); err != nil {
+		t.Fatalf("CleanupChaosExperiment failed: %v", err)
+	}
+	err := cl.Get(context.Background(), client.ObjectKey{Name: "test-chaos", Namespace: "default"}, chaos)
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("Expected IOChaos to be deleted, got %v", err)
+	}
+}
+
+func TestCleanupChaosExperiment_TimeAndDNS(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = chaosmeshv1alpha1.AddToScheme(scheme)
+
+	tests := []struct {
+		chaosType string
+		obj       client.Object
+	}{
+		{"time-skew", &chaosmeshv1alpha1.TimeChaos{}},
+		{"dns-error", &chaosmeshv1alpha1.DNSChaos{}},
+		{"dns-random", &chaosmeshv1alpha1.DNSChaos{}},
+	}
+
+	for _, tt := range tests {
+		tt.obj.SetName("test-chaos")
+		tt.obj.SetNamespace("default")
+		cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.obj).Build()
+
+		if err := CleanupChaosExperiment(context.Background(), cl, "default", "test-chaos", tt.chaosType); err != nil {
+			t.Fatalf("CleanupChaosExperiment(%s) failed: %v", tt.chaosType, err)
+		}
+		err := cl.Get(context.Background(), client.ObjectKey{Name: "test-chaos", Namespace: "default"}, tt.obj)
+		if !apierrors.IsNotFound(err) {
+			t.Errorf("Expected chaos of %s to be deleted, got %v", tt.chaosType, err)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+// Mode returns the selection mode of spec, defaulting to one pod for
+// pod-delete and all pods for the other types.
+func Mode(spec *chaosdrv1.ChaosSpec) string {
+	switch {
+	case spec.Mode != "":
+		return spec.Mode
+	case spec.Type == chaosdrv1.ChaosTypePodDelete:
+		return chaosdrv1.ChaosModeOne
+	default:
+		return chaosdrv1.ChaosModeAll
+	}
+}
+
+// validateMode checks that spec's value fits its mode.
+func validateMode(spec *chaosdrv1.ChaosSpec) error {
+	if spec.MaxAffectedPods < 0 {
+		return fmt.Errorf("maxAffectedPods must be at least 1, got %d", spec.MaxAffectedPods)
+	}
+	switch spec.Mode {
+	case "", chaosdrv1.ChaosModeOne, chaosdrv1.ChaosModeAll:
+		if spec.Value != "" {
+			return fmt.Errorf("value is only allowed for modes fixed, fixed-percent and random-max-percent")
+		}
+	case chaosdrv1.ChaosModeFixed:
+		if n, err := strconv.Atoi(spec.Value); err != nil || n < 1 {
+			return fmt.Errorf("mode fixed needs a pod count of at least 1 as value, got %q", spec.Value)
+		}
+	case chaosdrv1.ChaosModeFixedPercent, chaosdrv1.ChaosModeRandomMaxPercent:
+		if n, err := strconv.Atoi(spec.Value); err != nil || n < 1 || n > 100 {
+			return fmt.Errorf("mode %s needs a percentage between 1 and 100 as value, got %q", spec.Mode, spec.Value)
+		}
+	default:
+		return fmt.Errorf("unknown mode %q", spec.Mode)
+	}
+	if spec.Mode != "" && spec.Type == chaosdrv1.ChaosTypeScaleToZero {
+		return fmt.Errorf("mode is not allowed for type scale-to-zero, which scales every workload of the app")
+	}
+	return nil
+}
+
+// AffectedPods returns how many of matching pods the mode of spec targets at
+// most. Percentages round down like Chaos Mesh does; random-max-percent
+// counts its upper bound.
+func AffectedPods(spec *chaosdrv1.ChaosSpec, matching int) int {
+	if spec.Type == chaosdrv1.ChaosTypeScaleToZero {
+		return matching
+	}
+	value, _ := strconv.Atoi(spec.Value)
+	switch Mode(spec) {
+	case chaosdrv1.ChaosModeOne:
+		return min(1, matching)
+	case chaosdrv1.ChaosModeFixed:
+		return min(value, matching)
+	case chaosdrv1.ChaosModeFixedPercent, chaosdrv1.ChaosModeRandomMaxPercent:
+		return matching * value / 100
+	default:
+		return matching
+	}
+}
+
+// CheckBlastRadius counts the pods of cr's app that spec would target and
+// returns an error when they exceed spec.maxAffectedPods. A node-drain evicts
+// every pod on the drained nodes, so it counts the most pods from any
+// namespace a drain of the app's nodes could evict.
+func CheckBlastRadius(ctx context.Context, cl client.Client, cr *chaosdrv1.ChaosDRTest, spec *chaosdrv1.ChaosSpec) (int, error) {
+	pods, err := targetPods(ctx, cl, cr)
+	if err != nil {
+		return 0, err
+	}
+	affected := AffectedPods(spec, len(pods))
+	if spec.Type == chaosdrv1.ChaosTypeNodeDrain {
+		drained, err := drainBlastRadius(ctx, cl, pods, min(drainMaxNodes(spec), affected))
+		if err != nil {
+			return 0, err
+		}
+		if spec.MaxAffectedPods > 0 && drained > spec.MaxAffectedPods {
+			return drained, fmt.Errorf("node-drain could evict %d pods from the nodes running pods matching %v, more than maxAffectedPods %d",
+				drained, cr.Spec.AppSelector, spec.MaxAffectedPods)
+		}
+		return drained, nil
+	}
+	if spec.MaxAffectedPods > 0 && affected > spec.MaxAffectedPods {
+		return affected, fmt.Errorf("%s in mode %s would affect %d of %d pods matching %v, more than maxAffectedPods %d",
+			spec.Type, Mode(spec), affected, len(pods), cr.Spec.AppSelector, spec.MaxAffectedPods)
+	}
+	return affected, nil
+}
+
+// drainBlastRadius returns the most pods a drain of up to maxNodes of the
+// nodes running pods would evict.
+func drainBlastRadius(ctx context.Context, cl client.Client, pods []corev1.Pod, maxNodes int) (int, error) {
+	var nodes []string
+	for _, pod := range pods {
+		if pod.Spec.NodeName != "" && !slices.Contains(nodes, pod.Spec.NodeName) {
+			nodes = append(nodes, pod.Spec.NodeName)
+		}
+	}
+	evicted, err := evictablePods(ctx, cl, nodes)
+	if err != nil {
+		return 0, err
+	}
+	perNode := map[string]int{}
+	for _, pod := range evicted {
+		perNode[pod.Spec.NodeName]++
+	}
+	counts := make([]int, 0, len(perNode))
+	for _, n := range perNode {
+		counts = append(counts, n)
+	}
+	slices.Sort(counts)
+	slices.Reverse(counts)
+	total := 0
+	for _, n := range counts[:min(maxNodes, len(counts))] {
+		total += n
+	}
+	return total, nil
+}
+
+// targetPods lists the pods of cr's app that chaos can target: those not
+// finished or being deleted.
+func targetPods(ctx context.Context, cl client.Client, cr *chaosdrv1.ChaosDRTest) ([]corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	if err := cl.List(ctx, pods, client.InNamespace(cr.Namespace), client.MatchingLabels(cr.Spec.AppSelector)); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	var selected []corev1.Pod
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp == nil && pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+			selected = append(selected, pod)
+		}
+	}
+	return selected, nil
+}
+
+// pickPods chooses the pods the mode of spec targets, at random, the same
+// way Chaos Mesh does for its selectors.
+func pickPods(pods []corev1.Pod, spec *chaosdrv1.ChaosSpec) []corev1.Pod {
+	n := AffectedPods(spec, len(pods))
+	if Mode(spec) == chaosdrv1.ChaosModeRandomMaxPercent && n > 0 {
+		n = rand.IntN(n + 1)
+	}
+	if n >= len(pods) {
+		return pods
+	}
+	picked := make([]corev1.Pod, len(pods))
+	copy(picked, pods)
+	rand.Shuffle(len(picked), func(i, j int) { picked[i], picked[j] = picked[j], picked[i] })
+	return picked[:n]
+}
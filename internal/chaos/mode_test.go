@@ -0,0 +1,140 @@
+package chaos
+
+import (
+	"context"
+	"testing"
+
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+func TestAffectedPods(t *testing.T) {
+	tests := []struct {
+		spec     chaosdrv1.ChaosSpec
+		matching int
+		want     int
+	}{
+		{chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypePodDelete}, 5, 1},
+		{chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypePodDelete}, 0, 0},
+		{chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypeNetworkDelay}, 5, 5},
+		{chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypePodDelete, Mode: chaosdrv1.ChaosModeAll}, 5, 5},
+		{chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypeNetworkDelay, Mode: chaosdrv1.ChaosModeOne}, 5, 1},
+		{chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypePodDelete, Mode: chaosdrv1.ChaosModeFixed, Value: "3"}, 5, 3},
+		{chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypePodDelete, Mode: chaosdrv1.ChaosModeFixed, Value: "3"}, 2, 2},
+		{chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypePodDelete, Mode: chaosdrv1.ChaosModeFixedPercent, Value: "50"}, 5, 2},
+		{chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypePodDelete, Mode: chaosdrv1.ChaosModeRandomMaxPercent, Value: "40"}, 10, 4},
+		{chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypeScaleToZero}, 4, 4},
+	}
+	for _, tt := range tests {
+		if got := AffectedPods(&tt.spec, tt.matching); got != tt.want {
+			t.Errorf("%s %s %s of %d pods: expected %d, got %d", tt.spec.Type, tt.spec.Mode, tt.spec.Value, tt.matching, tt.want, got)
+		}
+	}
+}
+
+func TestValidateSpec_Mode(t *testing.T) {
+	invalid := []chaosdrv1.ChaosSpec{
+		{Type: chaosdrv1.ChaosTypePodDelete, Mode: chaosdrv1.ChaosModeFixed},
+		{Type: chaosdrv1.ChaosTypePodDelete, Mode: chaosdrv1.ChaosModeFixed, Value: "0"},
+		{Type: chaosdrv1.ChaosTypePodDelete, Mode: chaosdrv1.ChaosModeFixedPercent, Value: "101"},
+		{Type: chaosdrv1.ChaosTypePodDelete, Mode: chaosdrv1.ChaosModeAll, Value: "3"},
+		{Type: chaosdrv1.ChaosTypePodDelete, Value: "3"},
+		{Type: chaosdrv1.ChaosTypePodDelete, Mode: "half"},
+		{Type: chaosdrv1.ChaosTypePodDelete, MaxAffectedPods: -1},
+		{Type: chaosdrv1.ChaosTypeScaleToZero, Mode: chaosdrv1.ChaosModeOne},
+	}
+	for _, spec := range invalid {
+		if err := ValidateSpec(&spec); err == nil {
+			t.Errorf("Expected %+v to be rejected", spec)
+		}
+	}
+
+	valid := chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypePodDelete, Mode: chaosdrv1.ChaosModeRandomMaxPercent, Value: "30", MaxAffectedPods: 2}
+	if err := ValidateSpec(&valid); err != nil {
+		t.Errorf("Expected %+v to be valid, got %v", valid, err)
+	}
+}
+
+func TestValidateSpec_Members(t *testing.T) {
+	spec := chaosdrv1.ChaosSpec{
+		Type:         chaosdrv1.ChaosTypePodDelete,
+		NetworkDelay: &chaosdrv1.NetworkDelaySpec{},
+		NetworkLoss:  &chaosdrv1.NetworkLossSpec{},
+		NodeDrain:    &chaosdrv1.NodeDrainSpec{},
+	}
+	want := "chaos of type pod-delete must not configure network-delay"
+	for range 20 {
+		if err := ValidateSpec(&spec); err == nil || err.Error() != want {
+			t.Fatalf("Expected %q, got %v", want, err)
+		}
+	}
+}
+
+func TestApplyChaosExperiment_Mode(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = chaosmeshv1alpha1.AddToScheme(scheme)
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	spec := &chaosdrv1.ChaosSpec{
+		Type:         chaosdrv1.ChaosTypeNetworkDelay,
+		Mode:         chaosdrv1.ChaosModeFixedPercent,
+		Value:        "30",
+		NetworkDelay: &chaosdrv1.NetworkDelaySpec{Latency: "100ms"},
+	}
+	cr := newNativeTestCR(spec)
+
+	if err := ApplyChaosExperiment(context.Background(), cl, cr, "test-chaos", spec); err != nil {
+		t.Fatalf("ApplyChaosExperiment failed: %v", err)
+	}
+	chaos := &chaosmeshv1alpha1.NetworkChaos{}
+	if err := cl.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "test-chaos"}, chaos); err != nil {
+		t.Fatalf("Failed to get NetworkChaos: %v", err)
+	}
+	if chaos.Spec.Mode != chaosmeshv1alpha1.FixedPercentMode || chaos.Spec.Value != "30" {
+		t.Errorf("Expected fixed-percent 30, got mode %s value %q", chaos.Spec.Mode, chaos.Spec.Value)
+	}
+}
+
+func TestCheckBlastRadius(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	finished := newAppPod("redis-job", "node-a")
+	finished.Status.Phase = corev1.PodSucceeded
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newAppPod("redis-0", "node-a"),
+		newAppPod("redis-1", "node-a"),
+		newAppPod("redis-2", "node-b"),
+		finished,
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "postgres-0", Namespace: "default", Labels: map[string]string{"app": "postgres"}}, Spec: corev1.PodSpec{NodeName: "node-a"}},
+	).Build()
+
+	spec := &chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypeNetworkLoss, MaxAffectedPods: 2}
+	affected, err := CheckBlastRadius(context.Background(), cl, newNativeTestCR(spec), spec)
+	if err == nil {
+		t.Errorf("Expected all 3 running redis pods to exceed the cap of 2, got %d affected", affected)
+	}
+
+	spec.Mode, spec.Value = chaosdrv1.ChaosModeFixed, "2"
+	affected, err = CheckBlastRadius(context.Background(), cl, newNativeTestCR(spec), spec)
+	if err != nil || affected != 2 {
+		t.Errorf("Expected 2 affected pods within the cap, got %d, %v", affected, err)
+	}
+
+	// A drain of node-a, the busier node, evicts postgres-0 too
+	spec = &chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypeNodeDrain, MaxAffectedPods: 2}
+	affected, err = CheckBlastRadius(context.Background(), cl, newNativeTestCR(spec), spec)
+	if err == nil || affected != 3 {
+		t.Errorf("Expected the 3 running pods on node-a to exceed the cap of 2, got %d, %v", affected, err)
+	}
+	spec.MaxAffectedPods = 3
+	affected, err = CheckBlastRadius(context.Background(), cl, newNativeTestCR(spec), spec)
+	if err != nil || affected != 3 {
+		t.Errorf("Expected 3 evicted pods within the cap, got %d, %v", affected, err)
+	}
+}
@@ -0,0 +1,547 @@
+package chaos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+// ProviderNative is the name the native provider is registered under
+const ProviderNative = "native"
+
+// Keys of the ledger ConfigMap in which the native provider records its changes
+const (
+	ledgerLabel        = "chaosdr.io/native-chaos"
+	ledgerTypeKey      = "chaosType"
+	ledgerEndTimeKey   = "endTime"
+	ledgerChangesKey   = "changes"
+	ledgerRecoveredKey = "recovered"
+)
+
+// Actions recorded in a change
+const (
+	actionDeleted  = "deleted"
+	actionEvicted  = "evicted"
+	actionScaled   = "scaled"
+	actionCordoned = "cordoned"
+)
+
+// mirrorPodAnnotation marks static pods, which cannot be evicted
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+// nativeTypes lists the chaos types the native provider runs
+var nativeTypes = []string{
+	chaosdrv1.ChaosTypePodDelete,
+	chaosdrv1.ChaosTypeScaleToZero,
+	chaosdrv1.ChaosTypeNodeDrain,
+	chaosdrv1.ChaosTypePVCDelete,
+}
+
+// change is one modification made by the native provider, with what it takes to undo it
+type change struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Action    string `json:"action"`
+	// Replicas is the replica count of a workload before it was scaled
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Labels and Claim describe a deleted PVC so an empty one can be recreated
+	Labels map[string]string                 `json:"labels,omitempty"`
+	Claim  *corev1.PersistentVolumeClaimSpec `json:"claim,omitempty"`
+}
+
+// NativeProvider injects chaos with plain Kubernetes API calls, for clusters
+// without a chaos framework. Every change is recorded in a ledger ConfigMap
+// named after the chaos before it is made, so it can be undone once the chaos
+// duration has passed or the test is cleaned up, even across operator restarts.
+type NativeProvider struct {
+	Client client.Client
+	// Reader reads the ledgers and the app's Deployments and StatefulSets. It
+	// must not be the manager's cached client: the operator may only list and
+	// watch those in its own namespace.
+	Reader client.Reader
+	// AllowDestructive permits pvc-delete, whose data loss cannot be undone
+	AllowDestructive bool
+}
+
+// NewNativeProvider returns a NativeProvider making its changes through cl.
+func NewNativeProvider(cl client.Client, reader client.Reader, allowDestructive bool) *NativeProvider {
+	return &NativeProvider{Client: cl, Reader: reader, AllowDestructive: allowDestructive}
+}
+
+// NewNativeFactory returns a Factory that rejects tests whose chaos the native
+// provider cannot run before anything is backed up.
+func NewNativeFactory(cl client.Client, reader client.Reader, allowDestructive bool) Factory {
+	p := NewNativeProvider(cl, reader, allowDestructive)
+	return func(cr *chaosdrv1.ChaosDRTest) (ChaosProvider, error) {
+		if cr == nil {
+			return p, nil
+		}
+		spec, err := ResolveSpec(cr)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.supports(spec); err != nil {
+			return nil, err
+		}
+		return p, nil
+	}
+}
+
+// supports checks that spec is valid and one the provider may run.
+func (p *NativeProvider) supports(spec *chaosdrv1.ChaosSpec) error {
+	if err := ValidateSpec(spec); err != nil {
+		return err
+	}
+	if !slices.Contains(nativeTypes, spec.Type) {
+		return fmt.Errorf("chaos provider native does not support chaos type %s", spec.Type)
+	}
+	if spec.Type == chaosdrv1.ChaosTypePVCDelete && !p.AllowDestructive {
+		return fmt.Errorf("pvc-delete destroys the app's data and requires the operator's --allow-destructive-chaos")
+	}
+	return nil
+}
+
+// Apply plans the changes spec makes to the app selected by cr, records them
+// in the ledger and makes them. A ledger left by an interrupted Apply is
+// replayed instead of planning again.
+func (p *NativeProvider) Apply(ctx context.Context, cr *chaosdrv1.ChaosDRTest, chaosName string, spec *chaosdrv1.ChaosSpec) error {
+	if err := p.supports(spec); err != nil {
+		return err
+	}
+
+	var changes []change
+	ledger := &corev1.ConfigMap{}
+	err := p.Reader.Get(ctx, client.ObjectKey{Namespace: cr.Namespace, Name: chaosName}, ledger)
+	switch {
+	case err == nil:
+		if changes, err = ledgerChanges(ledger); err != nil {
+			return err
+		}
+	case apierrors.IsNotFound(err):
+		if changes, err = p.plan(ctx, cr, spec); err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			return fmt.Errorf("%s found nothing to change for appSelector %v", spec.Type, cr.Spec.AppSelector)
+		}
+		if ledger, err = newLedger(cr.Namespace, chaosName, spec.Type, time.Now().Add(Duration(cr)), changes); err != nil {
+			return err
+		}
+		if err := p.Client.Create(ctx, ledger); err != nil {
+			return fmt.Errorf("failed to record native chaos %s: %w", chaosName, err)
+		}
+	default:
+		return fmt.Errorf("failed to get native chaos %s: %w", chaosName, err)
+	}
+
+	for _, c := range changes {
+		if err := p.apply(ctx, c, spec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Status reports the recorded changes as injected until the chaos duration
+// has passed, and then undoes them and reports them recovered.
+func (p *NativeProvider) Status(ctx context.Context, namespace, chaosName, chaosType string) (*ExperimentStatus, error) {
+	ledger := &corev1.ConfigMap{}
+	if err := p.Reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: chaosName}, ledger); err != nil {
+		return nil, fmt.Errorf("failed to get native chaos %s: %w", chaosName, err)
+	}
+	changes, err := ledgerChanges(ledger)
+	if err != nil {
+		return nil, err
+	}
+
+	st := &ExperimentStatus{State: ExperimentRecovered, TotalTargets: len(changes)}
+	if ledger.Data[ledgerRecoveredKey] == "true" {
+		return st, nil
+	}
+	end, err := time.Parse(time.RFC3339, ledger.Data[ledgerEndTimeKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid end time of native chaos %s: %w", chaosName, err)
+	}
+	if time.Now().Before(end) {
+		st.State = ExperimentInjected
+		st.InjectedTargets = len(changes)
+		return st, nil
+	}
+
+	if err := p.revert(ctx, changes); err != nil {
+		return nil, err
+	}
+	ledger.Data[ledgerRecoveredKey] = "true"
+	if err := p.Client.Update(ctx, ledger); err != nil {
+		return nil, fmt.Errorf("failed to record recovery of native chaos %s: %w", chaosName, err)
+	}
+	return st, nil
+}
+
+// Cleanup undoes the recorded changes that were not yet recovered and
+// removes the ledger.
+func (p *NativeProvider) Cleanup(ctx context.Context, namespace, chaosName, chaosType string) error {
+	ledger := &corev1.ConfigMap{}
+	if err := p.Reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: chaosName}, ledger); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if ledger.Data[ledgerRecoveredKey] != "true" {
+		changes, err := ledgerChanges(ledger)
+		if err != nil {
+			return err
+		}
+		if err := p.revert(ctx, changes); err != nil {
+			return err
+		}
+	}
+	return client.IgnoreNotFound(p.Client.Delete(ctx, ledger))
+}
+
+// plan lists the changes spec makes, without making them.
+func (p *NativeProvider) plan(ctx context.Context, cr *chaosdrv1.ChaosDRTest, spec *chaosdrv1.ChaosSpec) ([]change, error) {
+	switch spec.Type {
+	case chaosdrv1.ChaosTypePodDelete:
+		pods, err := p.selectedPods(ctx, cr, spec)
+		if err != nil {
+			return nil, err
+		}
+		var changes []change
+		for _, pod := range pods {
+			changes = append(changes, change{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name, Action: actionDeleted})
+		}
+		return changes, nil
+	case chaosdrv1.ChaosTypeScaleToZero:
+		return p.planScaleToZero(ctx, cr)
+	case chaosdrv1.ChaosTypeNodeDrain:
+		return p.planNodeDrain(ctx, cr, spec, drainMaxNodes(spec))
+	case chaosdrv1.ChaosTypePVCDelete:
+		return p.planPVCDelete(ctx, cr, spec)
+	default:
+		return nil, fmt.Errorf("chaos provider native does not support chaos type %s", spec.Type)
+	}
+}
+
+// planScaleToZero scales every Deployment and StatefulSet whose pods match
+// the app selector down to zero.
+func (p *NativeProvider) planScaleToZero(ctx context.Context, cr *chaosdrv1.ChaosDRTest) ([]change, error) {
+	selector := labels.SelectorFromSet(cr.Spec.AppSelector)
+	var changes []change
+
+	deployments := &appsv1.DeploymentList{}
+	if err := p.Reader.List(ctx, deployments, client.InNamespace(cr.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, d := range deployments.Items {
+		if replicas := replicaCount(d.Spec.Replicas); replicas > 0 && selector.Matches(labels.Set(d.Spec.Template.Labels)) {
+			changes = append(changes, change{Kind: "Deployment", Namespace: d.Namespace, Name: d.Name, Action: actionScaled, Replicas: &replicas})
+		}
+	}
+
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := p.Reader.List(ctx, statefulSets, client.InNamespace(cr.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for _, s := range statefulSets.Items {
+		if replicas := replicaCount(s.Spec.Replicas); replicas > 0 && selector.Matches(labels.Set(s.Spec.Template.Labels)) {
+			changes = append(changes, change{Kind: "StatefulSet", Namespace: s.Namespace, Name: s.Name, Action: actionScaled, Replicas: &replicas})
+		}
+	}
+	return changes, nil
+}
+
+// planNodeDrain cordons up to maxNodes nodes running selected pods and evicts
+// every pod on them that a drain would. Nodes that were already cordoned are
+// drained but left cordoned afterwards. The evictions, from any namespace,
+// may not exceed spec.maxAffectedPods.
+func (p *NativeProvider) planNodeDrain(ctx context.Context, cr *chaosdrv1.ChaosDRTest, spec *chaosdrv1.ChaosSpec, maxNodes int) ([]change, error) {
+	pods, err := p.selectedPods(ctx, cr, spec)
+	if err != nil {
+		return nil, err
+	}
+	var nodes []string
+	for _, pod := range pods {
+		if pod.Spec.NodeName != "" && !slices.Contains(nodes, pod.Spec.NodeName) {
+			nodes = append(nodes, pod.Spec.NodeName)
+		}
+	}
+	slices.Sort(nodes)
+	if len(nodes) > maxNodes {
+		nodes = nodes[:maxNodes]
+	}
+
+	var changes []change
+	for _, name := range nodes {
+		node := &corev1.Node{}
+		if err := p.Client.Get(ctx, client.ObjectKey{Name: name}, node); err != nil {
+			return nil, fmt.Errorf("failed to get node %s: %w", name, err)
+		}
+		if !node.Spec.Unschedulable {
+			changes = append(changes, change{Kind: "Node", Name: name, Action: actionCordoned})
+		}
+	}
+
+	evicted, err := evictablePods(ctx, p.Client, nodes)
+	if err != nil {
+		return nil, err
+	}
+	if spec.MaxAffectedPods > 0 && len(evicted) > spec.MaxAffectedPods {
+		return nil, fmt.Errorf("draining nodes %v would evict %d pods, more than maxAffectedPods %d",
+			nodes, len(evicted), spec.MaxAffectedPods)
+	}
+	for _, pod := range evicted {
+		changes = append(changes, change{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name, Action: actionEvicted})
+	}
+	return changes, nil
+}
+
+// drainMaxNodes returns the most nodes a node-drain of spec drains at once.
+func drainMaxNodes(spec *chaosdrv1.ChaosSpec) int {
+	if spec.NodeDrain != nil && spec.NodeDrain.MaxNodes > 0 {
+		return spec.NodeDrain.MaxNodes
+	}
+	return 1
+}
+
+// evictablePods lists the pods in any namespace on nodes that a drain would evict.
+func evictablePods(ctx context.Context, cl client.Client, nodes []string) ([]corev1.Pod, error) {
+	all := &corev1.PodList{}
+	if err := cl.List(ctx, all); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	var pods []corev1.Pod
+	for _, pod := range all.Items {
+		if slices.Contains(nodes, pod.Spec.NodeName) && evictable(&pod) {
+			pods = append(pods, pod)
+		}
+	}
+	return pods, nil
+}
+
+// planPVCDelete deletes the PVCs mounted by the selected pods, and those pods
+// so the PVCs are released.
+func (p *NativeProvider) planPVCDelete(ctx context.Context, cr *chaosdrv1.ChaosDRTest, spec *chaosdrv1.ChaosSpec) ([]change, error) {
+	pods, err := p.selectedPods(ctx, cr, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes, podChanges []change
+	seen := map[string]bool{}
+	for _, pod := range pods {
+		mounts := false
+		for _, vol := range pod.Spec.Volumes {
+			if vol.PersistentVolumeClaim == nil {
+				continue
+			}
+			mounts = true
+			claimName := vol.PersistentVolumeClaim.ClaimName
+			if seen[claimName] {
+				continue
+			}
+			seen[claimName] = true
+			pvc := &corev1.PersistentVolumeClaim{}
+			if err := p.Client.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: claimName}, pvc); err != nil {
+				return nil, fmt.Errorf("failed to get PVC %s: %w", claimName, err)
+			}
+			changes = append(changes, change{
+				Kind:      "PersistentVolumeClaim",
+				Namespace: pvc.Namespace,
+				Name:      pvc.Name,
+				Action:    actionDeleted,
+				Labels:    pvc.Labels,
+				Claim:     pvc.Spec.DeepCopy(),
+			})
+		}
+		if mounts {
+			podChanges = append(podChanges, change{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name, Action: actionDeleted})
+		}
+	}
+	// The PVCs are deleted first so the pods recreated by their owners cannot mount them again
+	return append(changes, podChanges...), nil
+}
+
+// apply makes one recorded change. Changes already made are skipped, so a
+// ledger can be replayed.
+func (p *NativeProvider) apply(ctx context.Context, c change, spec *chaosdrv1.ChaosSpec) error {
+	switch {
+	case c.Kind == "Pod" && c.Action == actionDeleted:
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: c.Namespace, Name: c.Name}}
+		var opts []client.DeleteOption
+		if spec.PodDelete != nil {
+			opts = append(opts, client.GracePeriodSeconds(spec.PodDelete.GracePeriod))
+		}
+		if err := p.Client.Delete(ctx, pod, opts...); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete pod %s: %w", c.Name, err)
+		}
+	case c.Kind == "Pod" && c.Action == actionEvicted:
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: c.Namespace, Name: c.Name}}
+		eviction := &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Namespace: c.Namespace, Name: c.Name}}
+		err := p.Client.SubResource("eviction").Create(ctx, pod, eviction)
+		if apierrors.IsTooManyRequests(err) {
+			// A PodDisruptionBudget protects the pod; a drain leaves it running too
+			log.FromContext(ctx).Info("Eviction blocked by a disruption budget", "pod", c.Name, "namespace", c.Namespace)
+			return nil
+		}
+		if client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to evict pod %s: %w", c.Name, err)
+		}
+	case c.Action == actionScaled:
+		return p.scale(ctx, c, 0)
+	case c.Kind == "Node" && c.Action == actionCordoned:
+		return p.setUnschedulable(ctx, c.Name, true)
+	case c.Kind == "PersistentVolumeClaim" && c.Action == actionDeleted:
+		pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: c.Namespace, Name: c.Name}}
+		if err := p.Client.Delete(ctx, pvc); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete PVC %s: %w", c.Name, err)
+		}
+	default:
+		return fmt.Errorf("unknown native chaos change %s %s", c.Action, c.Kind)
+	}
+	return nil
+}
+
+// revert undoes the recorded changes. Deleted and evicted pods are left to
+// their owners; a deleted PVC is recreated empty from its recorded spec.
+func (p *NativeProvider) revert(ctx context.Context, changes []change) error {
+	for _, c := range changes {
+		switch {
+		case c.Action == actionScaled:
+			if err := p.scale(ctx, c, *c.Replicas); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+		case c.Kind == "Node" && c.Action == actionCordoned:
+			if err := p.setUnschedulable(ctx, c.Name, false); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+		case c.Kind == "PersistentVolumeClaim" && c.Action == actionDeleted:
+			spec := c.Claim.DeepCopy()
+			// The old volume stays bound to the deleted claim or is gone
+			spec.VolumeName = ""
+			pvc := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Namespace: c.Namespace, Name: c.Name, Labels: c.Labels},
+				Spec:       *spec,
+			}
+			// A claim still terminating or already recreated by a StatefulSet is left alone
+			if err := p.Client.Create(ctx, pvc); client.IgnoreAlreadyExists(err) != nil {
+				return fmt.Errorf("failed to recreate PVC %s: %w", c.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// scale sets the replicas of a recorded Deployment or StatefulSet.
+func (p *NativeProvider) scale(ctx context.Context, c change, replicas int32) error {
+	var obj client.Object
+	switch c.Kind {
+	case "Deployment":
+		obj = &appsv1.Deployment{}
+	case "StatefulSet":
+		obj = &appsv1.StatefulSet{}
+	default:
+		return fmt.Errorf("cannot scale %s %s", c.Kind, c.Name)
+	}
+	if err := p.Reader.Get(ctx, client.ObjectKey{Namespace: c.Namespace, Name: c.Name}, obj); err != nil {
+		return fmt.Errorf("failed to get %s %s: %w", c.Kind, c.Name, err)
+	}
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		o.Spec.Replicas = &replicas
+	case *appsv1.StatefulSet:
+		o.Spec.Replicas = &replicas
+	}
+	if err := p.Client.Patch(ctx, obj, patch); err != nil {
+		return fmt.Errorf("failed to scale %s %s to %d: %w", c.Kind, c.Name, replicas, err)
+	}
+	return nil
+}
+
+// setUnschedulable cordons or uncordons a node.
+func (p *NativeProvider) setUnschedulable(ctx context.Context, name string, unschedulable bool) error {
+	node := &corev1.Node{}
+	if err := p.Client.Get(ctx, client.ObjectKey{Name: name}, node); err != nil {
+		return fmt.Errorf("failed to get node %s: %w", name, err)
+	}
+	patch := client.MergeFrom(node.DeepCopy())
+	node.Spec.Unschedulable = unschedulable
+	if err := p.Client.Patch(ctx, node, patch); err != nil {
+		return fmt.Errorf("failed to set node %s unschedulable=%t: %w", name, unschedulable, err)
+	}
+	return nil
+}
+
+// selectedPods picks the pods of cr's app that the mode of spec targets.
+func (p *NativeProvider) selectedPods(ctx context.Context, cr *chaosdrv1.ChaosDRTest, spec *chaosdrv1.ChaosSpec) ([]corev1.Pod, error) {
+	pods, err := targetPods(ctx, p.Client, cr)
+	if err != nil {
+		return nil, err
+	}
+	return pickPods(pods, spec), nil
+}
+
+// evictable reports whether kubectl drain would evict pod: DaemonSet and
+// static pods stay, and finished pods need no eviction.
+func evictable(pod *corev1.Pod) bool {
+	if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+		return false
+	}
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+	if owner := metav1.GetControllerOf(pod); owner != nil && owner.Kind == "DaemonSet" {
+		return false
+	}
+	return true
+}
+
+// replicaCount returns the replicas of a workload, applying the API default of 1.
+func replicaCount(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+// newLedger records the planned changes of a native chaos and when it ends.
+func newLedger(namespace, chaosName, chaosType string, end time.Time, changes []change) (*corev1.ConfigMap, error) {
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      chaosName,
+			Namespace: namespace,
+			Labels:    map[string]string{ledgerLabel: "true"},
+		},
+		Data: map[string]string{
+			ledgerTypeKey:    chaosType,
+			ledgerEndTimeKey: end.UTC().Format(time.RFC3339),
+			ledgerChangesKey: string(data),
+		},
+	}, nil
+}
+
+// ledgerChanges reads the changes recorded in a ledger.
+func ledgerChanges(ledger *corev1.ConfigMap) ([]change, error) {
+	var changes []change
+	if err := json.Unmarshal([]byte(ledger.Data[ledgerChangesKey]), &changes); err != nil {
+		return nil, fmt.Errorf("invalid changes recorded for native chaos %s: %w", ledger.Name, err)
+	}
+	return changes, nil
+}
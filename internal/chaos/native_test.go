@@ -0,0 +1,373 @@
+package chaos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+func newNativeTestClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = chaosdrv1.AddToScheme(scheme)
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func newNativeTestCR(spec *chaosdrv1.ChaosSpec) *chaosdrv1.ChaosDRTest {
+	return &chaosdrv1.ChaosDRTest{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-dr", Namespace: "default"},
+		Spec: chaosdrv1.ChaosDRTestSpec{
+			AppSelector:   map[string]string{"app": "redis"},
+			Chaos:         spec,
+			ChaosDuration: &metav1.Duration{Duration: time.Minute},
+		},
+	}
+}
+
+func newAppPod(name, node string, volumes ...string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "redis"}},
+		Spec:       corev1.PodSpec{NodeName: node},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	for _, claim := range volumes {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name:         claim,
+			VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim}},
+		})
+	}
+	return pod
+}
+
+// expireLedger moves the end time of a native chaos into the past.
+func expireLedger(t *testing.T, cl client.Client, name string) {
+	t.Helper()
+	ledger := &corev1.ConfigMap{}
+	if err := cl.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: name}, ledger); err != nil {
+		t.Fatalf("Failed to get ledger: %v", err)
+	}
+	ledger.Data[ledgerEndTimeKey] = time.Now().Add(-time.Second).UTC().Format(time.RFC3339)
+	if err := cl.Update(context.Background(), ledger); err != nil {
+		t.Fatalf("Failed to update ledger: %v", err)
+	}
+}
+
+func TestNativePodDelete(t *testing.T) {
+	cl := newNativeTestClient(newAppPod("redis-0", "node-a"))
+	p := NewNativeProvider(cl, cl, false)
+	cr := newNativeTestCR(&chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypePodDelete, PodDelete: &chaosdrv1.PodDeleteSpec{GracePeriod: 5}})
+	ctx := context.Background()
+
+	if err := p.Apply(ctx, cr, "chaos-test-dr", cr.Spec.Chaos); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	err := cl.Get(ctx, client.ObjectKey{Namespace: "default", Name: "redis-0"}, &corev1.Pod{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("Expected redis-0 to be deleted, got %v", err)
+	}
+
+	st, err := p.Status(ctx, "default", "chaos-test-dr", chaosdrv1.ChaosTypePodDelete)
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if st.State != ExperimentInjected || st.InjectedTargets != 1 {
+		t.Errorf("Expected one injected target, got %+v", st)
+	}
+
+	expireLedger(t, cl, "chaos-test-dr")
+	st, err = p.Status(ctx, "default", "chaos-test-dr", chaosdrv1.ChaosTypePodDelete)
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if st.State != ExperimentRecovered {
+		t.Errorf("Expected recovered after the chaos duration, got %+v", st)
+	}
+
+	if err := p.Cleanup(ctx, "default", "chaos-test-dr", chaosdrv1.ChaosTypePodDelete); err != nil {
+		t.Fatalf("Cleanup failed: %v", err)
+	}
+	err = cl.Get(ctx, client.ObjectKey{Namespace: "default", Name: "chaos-test-dr"}, &corev1.ConfigMap{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("Expected ledger to be deleted, got %v", err)
+	}
+}
+
+func TestNativePodDeleteMode(t *testing.T) {
+	cl := newNativeTestClient(newAppPod("redis-0", "node-a"), newAppPod("redis-1", "node-a"), newAppPod("redis-2", "node-b"))
+	p := NewNativeProvider(cl, cl, false)
+	cr := newNativeTestCR(&chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypePodDelete, Mode: chaosdrv1.ChaosModeFixed, Value: "2"})
+
+	if err := p.Apply(context.Background(), cr, "chaos-test-dr", cr.Spec.Chaos); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	pods := &corev1.PodList{}
+	if err := cl.List(context.Background(), pods); err != nil {
+		t.Fatalf("Failed to list pods: %v", err)
+	}
+	if len(pods.Items) != 1 {
+		t.Errorf("Expected two of three pods to be deleted, %d remain", len(pods.Items))
+	}
+}
+
+func TestNativeScaleToZero(t *testing.T) {
+	replicas := int32(3)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "redis", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "redis"}}},
+		},
+	}
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "redis-replica", Namespace: "default"},
+		Spec: appsv1.StatefulSetSpec{
+			Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "redis", "role": "replica"}}},
+		},
+	}
+	other := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "postgres", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "postgres"}}},
+		},
+	}
+	cl := newNativeTestClient(deployment, statefulSet, other)
+	p := NewNativeProvider(cl, cl, false)
+	cr := newNativeTestCR(&chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypeScaleToZero})
+	ctx := context.Background()
+
+	if err := p.Apply(ctx, cr, "chaos-test-dr", cr.Spec.Chaos); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	assertReplicas := func(obj client.Object, want int32) {
+		t.Helper()
+		if err := cl.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			t.Fatalf("Failed to get %s: %v", obj.GetName(), err)
+		}
+		var got *int32
+		switch o := obj.(type) {
+		case *appsv1.Deployment:
+			got = o.Spec.Replicas
+		case *appsv1.StatefulSet:
+			got = o.Spec.Replicas
+		}
+		if got == nil || *got != want {
+			t.Errorf("Expected %s to have %d replicas, got %v", obj.GetName(), want, got)
+		}
+	}
+	assertReplicas(deployment, 0)
+	assertReplicas(statefulSet, 0)
+	assertReplicas(other, 3)
+
+	if err := p.Cleanup(ctx, "default", "chaos-test-dr", chaosdrv1.ChaosTypeScaleToZero); err != nil {
+		t.Fatalf("Cleanup failed: %v", err)
+	}
+	assertReplicas(deployment, 3)
+	// An unset replica count is restored to its default
+	assertReplicas(statefulSet, 1)
+}
+
+func TestNativeNodeDrain(t *testing.T) {
+	isController := true
+	daemon := newAppPod("log-agent", "node-a")
+	daemon.Labels = map[string]string{"app": "log-agent"}
+	daemon.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "DaemonSet", Name: "log-agent", UID: "1", Controller: &isController}}
+	neighbour := newAppPod("web-0", "node-a")
+	neighbour.Namespace = "web"
+	neighbour.Labels = nil
+	cl := newNativeTestClient(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}},
+		newAppPod("redis-0", "node-a"),
+		newAppPod("redis-1", "node-b"),
+		daemon,
+		neighbour,
+	)
+	p := NewNativeProvider(cl, cl, false)
+	cr := newNativeTestCR(&chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypeNodeDrain, NodeDrain: &chaosdrv1.NodeDrainSpec{MaxNodes: 1}})
+	ctx := context.Background()
+
+	if err := p.Apply(ctx, cr, "chaos-test-dr", cr.Spec.Chaos); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	node := &corev1.Node{}
+	for name, cordoned := range map[string]bool{"node-a": true, "node-b": false} {
+		if err := cl.Get(ctx, client.ObjectKey{Name: name}, node); err != nil {
+			t.Fatalf("Failed to get node %s: %v", name, err)
+		}
+		if node.Spec.Unschedulable != cordoned {
+			t.Errorf("Expected %s unschedulable=%t, got %t", name, cordoned, node.Spec.Unschedulable)
+		}
+	}
+	for key, evicted := range map[client.ObjectKey]bool{
+		{Namespace: "default", Name: "redis-0"}:   true,
+		{Namespace: "web", Name: "web-0"}:         true,
+		{Namespace: "default", Name: "log-agent"}: false,
+		{Namespace: "default", Name: "redis-1"}:   false,
+	} {
+		err := cl.Get(ctx, key, &corev1.Pod{})
+		if evicted != apierrors.IsNotFound(err) {
+			t.Errorf("Expected pod %s evicted=%t, got %v", key, evicted, err)
+		}
+	}
+
+	if err := p.Cleanup(ctx, "default", "chaos-test-dr", chaosdrv1.ChaosTypeNodeDrain); err != nil {
+		t.Fatalf("Cleanup failed: %v", err)
+	}
+	if err := cl.Get(ctx, client.ObjectKey{Name: "node-a"}, node); err != nil {
+		t.Fatalf("Failed to get node: %v", err)
+	}
+	if node.Spec.Unschedulable {
+		t.Error("Expected node-a to be uncordoned")
+	}
+}
+
+func TestNativePVCDelete(t *testing.T) {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "redis-data", Namespace: "default", Labels: map[string]string{"app": "redis"}},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+			VolumeName: "pv-1",
+		},
+	}
+	cr := newNativeTestCR(&chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypePVCDelete})
+	ctx := context.Background()
+
+	if _, err := NewNativeFactory(newNativeTestClient(), nil, false)(cr); err == nil {
+		t.Error("Expected pvc-delete to be refused without destructive mode")
+	}
+
+	cl := newNativeTestClient(newAppPod("redis-0", "node-a", "redis-data"), pvc)
+	p, err := NewNativeFactory(cl, cl, true)(cr)
+	if err != nil {
+		t.Fatalf("Factory failed: %v", err)
+	}
+	if err := p.Apply(ctx, cr, "chaos-test-dr", cr.Spec.Chaos); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	err = cl.Get(ctx, client.ObjectKey{Namespace: "default", Name: "redis-data"}, &corev1.PersistentVolumeClaim{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("Expected redis-data to be deleted, got %v", err)
+	}
+	err = cl.Get(ctx, client.ObjectKey{Namespace: "default", Name: "redis-0"}, &corev1.Pod{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("Expected redis-0 to be deleted, got %v", err)
+	}
+
+	// An operator restarted without destructive mode still undoes the chaos
+	cleaner, err := NewNativeFactory(cl, cl, false)(nil)
+	if err != nil {
+		t.Fatalf("Factory failed to return a cleaner: %v", err)
+	}
+	if err := cleaner.Cleanup(ctx, "default", "chaos-test-dr", chaosdrv1.ChaosTypePVCDelete); err != nil {
+		t.Fatalf("Cleanup failed: %v", err)
+	}
+	got := &corev1.PersistentVolumeClaim{}
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: "default", Name: "redis-data"}, got); err != nil {
+		t.Fatalf("Expected redis-data to be recreated: %v", err)
+	}
+	if got.Spec.VolumeName != "" || got.Labels["app"] != "redis" || got.Spec.Resources.Requests.Storage().String() != "1Gi" {
+		t.Errorf("Expected an unbound 1Gi claim labelled app=redis, got %+v", got)
+	}
+}
+
+func TestNativeApplyReplaysLedger(t *testing.T) {
+	replicas := int32(2)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "redis", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "redis"}}},
+		},
+	}
+	// An earlier Apply recorded the change and was interrupted before making it
+	ledger, err := newLedger("default", "chaos-test-dr", chaosdrv1.ChaosTypeScaleToZero, time.Now().Add(time.Minute), []change{
+		{Kind: "Deployment", Namespace: "default", Name: "redis", Action: actionScaled, Replicas: &replicas},
+	})
+	if err != nil {
+		t.Fatalf("newLedger failed: %v", err)
+	}
+	cl := newNativeTestClient(deployment, ledger)
+	p := NewNativeProvider(cl, cl, false)
+	cr := newNativeTestCR(&chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypeScaleToZero})
+
+	if err := p.Apply(context.Background(), cr, "chaos-test-dr", cr.Spec.Chaos); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if err := cl.Get(context.Background(), client.ObjectKeyFromObject(deployment), deployment); err != nil {
+		t.Fatalf("Failed to get deployment: %v", err)
+	}
+	if *deployment.Spec.Replicas != 0 {
+		t.Errorf("Expected the recorded scale to be replayed, got %d replicas", *deployment.Spec.Replicas)
+	}
+}
+
+func TestNativeApplyNothingToChange(t *testing.T) {
+	cl := newNativeTestClient()
+	p := NewNativeProvider(cl, cl, false)
+	cr := newNativeTestCR(&chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypeScaleToZero})
+
+	if err := p.Apply(context.Background(), cr, "chaos-test-dr", cr.Spec.Chaos); err == nil {
+		t.Error("Expected an error when no workload matches the app selector")
+	}
+}
+
+func TestProviderFactoriesRejectUnsupportedChaos(t *testing.T) {
+	cl := newNativeTestClient()
+	chaosMesh := NewChaosMeshFactory(cl)
+	native := NewNativeFactory(cl, cl, true)
+
+	for _, chaosType := range []string{chaosdrv1.ChaosTypeScaleToZero, chaosdrv1.ChaosTypeNodeDrain, chaosdrv1.ChaosTypePVCDelete} {
+		if _, err := chaosMesh(newNativeTestCR(&chaosdrv1.ChaosSpec{Type: chaosType})); err == nil {
+			t.Errorf("Expected chaos-mesh to reject %s", chaosType)
+		}
+	}
+	spec := &chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypeNetworkDelay, NetworkDelay: &chaosdrv1.NetworkDelaySpec{Latency: "100ms"}}
+	if _, err := native(newNativeTestCR(spec)); err == nil {
+		t.Error("Expected native to reject network-delay")
+	}
+	if _, err := chaosMesh(newNativeTestCR(spec)); err != nil {
+		t.Errorf("Expected chaos-mesh to run network-delay, got %v", err)
+	}
+}
+
+func TestNativeNodeDrainRespectsMaxAffectedPods(t *testing.T) {
+	web0, web1 := newAppPod("web-0", "node-a"), newAppPod("web-1", "node-a")
+	web0.Labels, web1.Labels = nil, nil
+	cl := newNativeTestClient(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+		newAppPod("redis-0", "node-a"),
+		web0,
+		web1,
+	)
+	p := NewNativeProvider(cl, cl, false)
+	cr := newNativeTestCR(&chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypeNodeDrain, MaxAffectedPods: 2})
+	ctx := context.Background()
+
+	if err := p.Apply(ctx, cr, "chaos-test-dr", cr.Spec.Chaos); err == nil {
+		t.Fatal("Expected draining node-a to exceed maxAffectedPods with its 3 pods")
+	}
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: "default", Name: "web-0"}, &corev1.Pod{}); err != nil {
+		t.Errorf("Expected no pod to be evicted, got %v", err)
+	}
+	node := &corev1.Node{}
+	if err := cl.Get(ctx, client.ObjectKey{Name: "node-a"}, node); err != nil || node.Spec.Unschedulable {
+		t.Errorf("Expected node-a to stay schedulable, got %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+// ProviderChaosMesh is the name the Chaos Mesh provider is registered under
+const ProviderChaosMesh = "chaos-mesh"
+
+// ChaosProvider injects chaos through one chaos engineering backend
+type ChaosProvider interface {
+	// Apply starts the chaos described by spec against the app selected by cr
+	Apply(ctx context.Context, cr *chaosdrv1.ChaosDRTest, chaosName string, spec *chaosdrv1.ChaosSpec) error
+	// Status reports the progress of a chaos started by Apply
+	Status(ctx context.Context, namespace, chaosName, chaosType string) (*ExperimentStatus, error)
+	// Cleanup stops the chaos and removes what Apply created; it is a no-op
+	// when the chaos is already gone
+	Cleanup(ctx context.Context, namespace, chaosName, chaosType string) error
+}
+
+// Factory returns the ChaosProvider that injects the chaos of one ChaosDRTest,
+// or an error when the provider cannot run it. Called with a nil test it
+// returns the provider without checking any chaos, to clean up what it injected.
+type Factory func(cr *chaosdrv1.ChaosDRTest) (ChaosProvider, error)
+
+// Registry holds the available chaos providers keyed by name.
+type Registry struct {
+	factories map[string]Factory
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{factories: map[string]Factory{}}
+}
+
+// Register makes a provider available under name, replacing any earlier one.
+func (r *Registry) Register(name string, factory Factory) {
+	r.factories[name] = factory
+}
+
+// Has reports whether a provider is registered under name.
+func (r *Registry) Has(name string) bool {
+	_, ok := r.factories[name]
+	return ok
+}
+
+// Names returns the registered provider names in sorted order.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New builds the named provider for cr.
+func (r *Registry) New(name string, cr *chaosdrv1.ChaosDRTest) (ChaosProvider, error) {
+	factory, ok := r.factories[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown chaos provider %q, available providers: %s", name, strings.Join(r.Names(), ", "))
+	}
+	return factory(cr)
+}
+
+// Cleaner returns the named provider without checking any test's chaos, so
+// chaos it injected can be removed after the test's spec has changed or the
+// operator no longer admits it.
+func (r *Registry) Cleaner(name string) (ChaosProvider, error) {
+	factory, ok := r.factories[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown chaos provider %q, available providers: %s", name, strings.Join(r.Names(), ", "))
+	}
+	return factory(nil)
+}
+
+// ChaosMeshProvider injects chaos by creating Chaos Mesh objects.
+type ChaosMeshProvider struct {
+	Client client.Client
+}
+
+// NewChaosMeshProvider returns a ChaosMeshProvider using cl.
+func NewChaosMeshProvider(cl client.Client) *ChaosMeshProvider {
+	return &ChaosMeshProvider{Client: cl}
+}
+
+// Apply creates the Chaos Mesh object of spec's type.
+func (p *ChaosMeshProvider) Apply(ctx context.Context, cr *chaosdrv1.ChaosDRTest, chaosName string, spec *chaosdrv1.ChaosSpec) error {
+	return ApplyChaosExperiment(ctx, p.Client, cr, chaosName, spec)
+}
+
+// Status summarizes the experiment records of the Chaos Mesh object.
+func (p *ChaosMeshProvider) Status(ctx context.Context, namespace, chaosName, chaosType string) (*ExperimentStatus, error) {
+	return GetChaosExperimentStatus(ctx, p.Client, namespace, chaosName, chaosType)
+}
+
+// Cleanup deletes the Chaos Mesh object, which recovers its targets.
+func (p *ChaosMeshProvider) Cleanup(ctx context.Context, namespace, chaosName, chaosType string) error {
+	return CleanupChaosExperiment(ctx, p.Client, namespace, chaosName, chaosType)
+}
+
+// NewChaosMeshFactory returns a Factory that rejects tests whose chaos type
+// has no Chaos Mesh equivalent before anything is backed up.
+func NewChaosMeshFactory(cl client.Client) Factory {
+	p := NewChaosMeshProvider(cl)
+	return func(cr *chaosdrv1.ChaosDRTest) (ChaosProvider, error) {
+		if cr == nil {
+			return p, nil
+		}
+		spec, err := ResolveSpec(cr)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := newChaosObject(spec.Type); err != nil {
+			return nil, fmt.Errorf("chaos provider %s does not support chaos type %s", ProviderChaosMesh, spec.Type)
+		}
+		return p, nil
+	}
+}
@@ -0,0 +1,466 @@
+package chaos
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+// maxStressWorkers is the most workers stress-ng will run
+const maxStressWorkers = 8192
+
+var (
+	// durationPattern matches the tc durations Chaos Mesh accepts, e.g. 100ms
+	durationPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?(ns|us|ms|s|m|h)$`)
+	// percentPattern matches a percentage between 0 and 100
+	percentPattern = regexp.MustCompile(`^(100|[0-9]{1,2})(\.[0-9]+)?$`)
+	// ratePattern matches the bandwidth rates tc accepts, e.g. 1mbps
+	ratePattern = regexp.MustCompile(`^[0-9]+(bps|kbps|mbps|gbps|tbps)$`)
+	// memorySizePattern matches the sizes Chaos Mesh accepts, e.g. 256MB, 1GiB or 50%
+	memorySizePattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?(%|B|KB|KiB|MB|MiB|GB|GiB|TB|TiB)?$`)
+	// domainPattern matches a DNSChaos domain pattern, where * may only end it
+	domainPattern = regexp.MustCompile(`^[A-Za-z0-9?.-]*\*?$`)
+)
+
+// clockIDs lists the clocks time-skew can shift
+var clockIDs = []string{
+	"CLOCK_REALTIME", "CLOCK_MONOTONIC", "CLOCK_PROCESS_CPUTIME_ID", "CLOCK_THREAD_CPUTIME_ID",
+	"CLOCK_MONOTONIC_RAW", "CLOCK_REALTIME_COARSE", "CLOCK_MONOTONIC_COARSE", "CLOCK_BOOTTIME",
+	"CLOCK_REALTIME_ALARM", "CLOCK_BOOTTIME_ALARM",
+}
+
+// ioMethods lists the filesystem calls IOChaos can inject
+var ioMethods = []string{
+	"lookup", "forget", "getattr", "setattr", "readlink", "mknod", "mkdir", "unlink", "rmdir",
+	"symlink", "rename", "link", "open", "read", "write", "flush", "release", "fsync", "opendir",
+	"readdir", "releasedir", "fsyncdir", "statfs", "setxattr", "getxattr", "listxattr",
+	"removexattr", "access", "create", "getlk", "setlk", "bmap",
+}
+
+// ioFileKinds lists the file types io-attr-override can report
+var ioFileKinds = []string{"namedPipe", "charDevice", "blockDevice", "directory", "regularFile", "symlink", "socket"}
+
+// legacyParameters lists the chaosParameters keys each legacy chaosType
+// accepts. The network-partition target is a label selector such as
+// app=redis,role=primary; externalTargets, the io methods, clockIds and the
+// dns patterns are comma-separated lists.
+var legacyParameters = map[string][]string{
+	chaosdrv1.ChaosTypePodDelete:        nil,
+	chaosdrv1.ChaosTypeNetworkDelay:     {"delay", "jitter", "correlation"},
+	chaosdrv1.ChaosTypeNetworkLoss:      {"loss", "correlation"},
+	chaosdrv1.ChaosTypeNetworkPartition: {"direction", "target", "targetNamespace", "externalTargets"},
+	chaosdrv1.ChaosTypeNetworkCorrupt:   {"corrupt", "correlation"},
+	chaosdrv1.ChaosTypeNetworkDuplicate: {"duplicate", "correlation"},
+	chaosdrv1.ChaosTypeNetworkBandwidth: {"rate", "limit", "buffer"},
+	chaosdrv1.ChaosTypeCPUStress:        {"workers", "load"},
+	chaosdrv1.ChaosTypeMemoryStress:     {"workers", "size"},
+	chaosdrv1.ChaosTypeIOLatency:        {"volume", "volumePath", "path", "methods", "percent", "delay"},
+	chaosdrv1.ChaosTypeIOFault:          {"volume", "volumePath", "path", "methods", "percent", "errno"},
+	chaosdrv1.ChaosTypeIOAttrOverride:   {"volume", "volumePath", "path", "methods", "percent", "perm", "size", "kind"},
+	chaosdrv1.ChaosTypeTimeSkew:         {"offset", "clockIds"},
+	chaosdrv1.ChaosTypeDNSError:         {"patterns"},
+	chaosdrv1.ChaosTypeDNSRandom:        {"patterns"},
+	chaosdrv1.ChaosTypeScaleToZero:      nil,
+	chaosdrv1.ChaosTypeNodeDrain:        {"maxNodes"},
+	chaosdrv1.ChaosTypePVCDelete:        nil,
+}
+
+// ResolveSpec returns the validated chaos of cr. A test written against the
+// deprecated chaosType and chaosParameters fields is converted to a ChaosSpec.
+func ResolveSpec(cr *chaosdrv1.ChaosDRTest) (*chaosdrv1.ChaosSpec, error) {
+	spec := cr.Spec.Chaos
+	if spec == nil {
+		var err error
+		if spec, err = convertLegacySpec(cr.Spec.ChaosType, cr.Spec.ChaosParameters); err != nil {
+			return nil, err
+		}
+	}
+	if err := ValidateSpec(spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// ValidateSpec checks that spec carries the member its type needs, and only
+// that member, with valid values. The CRD enforces the same rules; this
+// catches specs that bypassed it.
+func ValidateSpec(spec *chaosdrv1.ChaosSpec) error {
+	if spec == nil {
+		return fmt.Errorf("no chaos specified")
+	}
+
+	// members lists the union in a fixed order so the error names the same
+	// member every time.
+	members := []struct {
+		name string
+		set  bool
+	}{
+		{chaosdrv1.ChaosTypePodDelete, spec.PodDelete != nil},
+		{chaosdrv1.ChaosTypeNetworkDelay, spec.NetworkDelay != nil},
+		{chaosdrv1.ChaosTypeNetworkLoss, spec.NetworkLoss != nil},
+		{chaosdrv1.ChaosTypeNetworkPartition, spec.NetworkPartition != nil},
+		{chaosdrv1.ChaosTypeNetworkCorrupt, spec.NetworkCorrupt != nil},
+		{chaosdrv1.ChaosTypeNetworkDuplicate, spec.NetworkDuplicate != nil},
+		{chaosdrv1.ChaosTypeNetworkBandwidth, spec.NetworkBandwidth != nil},
+		{"stress", spec.Stress != nil},
+		{"io", spec.IO != nil},
+		{chaosdrv1.ChaosTypeTimeSkew, spec.TimeSkew != nil},
+		{"dns", spec.DNS != nil},
+		{chaosdrv1.ChaosTypeScaleToZero, false},
+		{chaosdrv1.ChaosTypeNodeDrain, spec.NodeDrain != nil},
+		{chaosdrv1.ChaosTypePVCDelete, false},
+	}
+	member := spec.Type
+	switch spec.Type {
+	case chaosdrv1.ChaosTypeCPUStress, chaosdrv1.ChaosTypeMemoryStress:
+		member = "stress"
+	case chaosdrv1.ChaosTypeIOLatency, chaosdrv1.ChaosTypeIOFault, chaosdrv1.ChaosTypeIOAttrOverride:
+		member = "io"
+	case chaosdrv1.ChaosTypeDNSError, chaosdrv1.ChaosTypeDNSRandom:
+		member = "dns"
+	}
+	supported := false
+	for _, m := range members {
+		supported = supported || m.name == member
+	}
+	if !supported {
+		return fmt.Errorf("unsupported chaosType: %s", spec.Type)
+	}
+	for _, m := range members {
+		if m.set && m.name != member {
+			return fmt.Errorf("chaos of type %s must not configure %s", spec.Type, m.name)
+		}
+	}
+	if err := validateMode(spec); err != nil {
+		return err
+	}
+
+	switch spec.Type {
+	case chaosdrv1.ChaosTypeNetworkDelay:
+		p := spec.NetworkDelay
+		if p == nil || p.Latency == "" {
+			return fmt.Errorf("network-delay requires a latency (e.g., '100ms')")
+		}
+		if err := checkPattern("latency", p.Latency, durationPattern); err != nil {
+			return err
+		}
+		if err := checkPattern("jitter", p.Jitter, durationPattern); err != nil {
+			return err
+		}
+		return checkPattern("correlation", p.Correlation, percentPattern)
+	case chaosdrv1.ChaosTypeNetworkLoss:
+		p := spec.NetworkLoss
+		if p == nil || p.Loss == "" {
+			return fmt.Errorf("network-loss requires a loss percentage (e.g., '25')")
+		}
+		if err := checkPattern("loss", p.Loss, percentPattern); err != nil {
+			return err
+		}
+		return checkPattern("correlation", p.Correlation, percentPattern)
+	case chaosdrv1.ChaosTypeNetworkPartition:
+		p := spec.NetworkPartition
+		if p == nil || (p.Target == nil && len(p.ExternalTargets) == 0) {
+			return fmt.Errorf("network-partition requires a target or externalTargets")
+		}
+		switch p.Direction {
+		case "", "to", "from", "both":
+		default:
+			return fmt.Errorf("invalid direction %q, expected to, from or both", p.Direction)
+		}
+		if p.Target != nil && len(p.Target.Selector) == 0 {
+			return fmt.Errorf("network-partition target requires a selector")
+		}
+	case chaosdrv1.ChaosTypeNetworkCorrupt:
+		p := spec.NetworkCorrupt
+		if p == nil || p.Corrupt == "" {
+			return fmt.Errorf("network-corrupt requires a corrupt percentage (e.g., '10')")
+		}
+		if err := checkPattern("corrupt", p.Corrupt, percentPattern); err != nil {
+			return err
+		}
+		return checkPattern("correlation", p.Correlation, percentPattern)
+	case chaosdrv1.ChaosTypeNetworkDuplicate:
+		p := spec.NetworkDuplicate
+		if p == nil || p.Duplicate == "" {
+			return fmt.Errorf("network-duplicate requires a duplicate percentage (e.g., '10')")
+		}
+		if err := checkPattern("duplicate", p.Duplicate, percentPattern); err != nil {
+			return err
+		}
+		return checkPattern("correlation", p.Correlation, percentPattern)
+	case chaosdrv1.ChaosTypeNetworkBandwidth:
+		p := spec.NetworkBandwidth
+		if p == nil || p.Rate == "" {
+			return fmt.Errorf("network-bandwidth requires a rate (e.g., '1mbps')")
+		}
+		return checkPattern("rate", p.Rate, ratePattern)
+	case chaosdrv1.ChaosTypeCPUStress, chaosdrv1.ChaosTypeMemoryStress:
+		p := spec.Stress
+		if p == nil {
+			return nil
+		}
+		if p.Workers < 0 || p.Workers > maxStressWorkers {
+			return fmt.Errorf("stress workers must be between 1 and %d, got %d", maxStressWorkers, p.Workers)
+		}
+		if p.Load != nil && (*p.Load < 0 || *p.Load > 100) {
+			return fmt.Errorf("stress load must be a percentage between 0 and 100, got %d", *p.Load)
+		}
+		return checkPattern("memorySize", p.MemorySize, memorySizePattern)
+	case chaosdrv1.ChaosTypeIOLatency, chaosdrv1.ChaosTypeIOFault, chaosdrv1.ChaosTypeIOAttrOverride:
+		return validateIOSpec(spec.Type, spec.IO)
+	case chaosdrv1.ChaosTypeTimeSkew:
+		p := spec.TimeSkew
+		if p == nil || p.Offset == "" {
+			return fmt.Errorf("time-skew requires an offset (e.g., '-10m')")
+		}
+		if offset, err := time.ParseDuration(p.Offset); err != nil || offset == 0 {
+			return fmt.Errorf("invalid offset %q, expected a non-zero duration such as -10m or 2h45m", p.Offset)
+		}
+		for _, id := range p.ClockIDs {
+			if !slices.Contains(clockIDs, id) {
+				return fmt.Errorf("unknown clock id %q, expected one of %v", id, clockIDs)
+			}
+		}
+	case chaosdrv1.ChaosTypeNodeDrain:
+		if spec.NodeDrain != nil && spec.NodeDrain.MaxNodes < 0 {
+			return fmt.Errorf("node-drain maxNodes must be at least 1, got %d", spec.NodeDrain.MaxNodes)
+		}
+	case chaosdrv1.ChaosTypeDNSError, chaosdrv1.ChaosTypeDNSRandom:
+		if spec.DNS == nil {
+			return nil
+		}
+		for _, pattern := range spec.DNS.Patterns {
+			if pattern == "" || !domainPattern.MatchString(pattern) {
+				return fmt.Errorf("invalid domain pattern %q, * may only end a pattern", pattern)
+			}
+		}
+	}
+	return nil
+}
+
+// validateIOSpec checks the filters of an io chaos and that it sets the
+// fault of its own type only.
+func validateIOSpec(chaosType string, p *chaosdrv1.IOSpec) error {
+	if p == nil {
+		return fmt.Errorf("%s requires io", chaosType)
+	}
+	if p.Percent < 0 || p.Percent > 100 {
+		return fmt.Errorf("io percent must be between 1 and 100, got %d", p.Percent)
+	}
+	for _, method := range p.Methods {
+		if !slices.Contains(ioMethods, method) {
+			return fmt.Errorf("unknown io method %q", method)
+		}
+	}
+
+	faults := map[string]bool{
+		chaosdrv1.ChaosTypeIOLatency:      p.Delay != "",
+		chaosdrv1.ChaosTypeIOFault:        p.Errno != 0,
+		chaosdrv1.ChaosTypeIOAttrOverride: p.Attr != nil,
+	}
+	for faultType, set := range faults {
+		if set && faultType != chaosType {
+			return fmt.Errorf("chaos of type %s must not configure the io fault of %s", chaosType, faultType)
+		}
+	}
+
+	switch chaosType {
+	case chaosdrv1.ChaosTypeIOLatency:
+		if p.Delay == "" {
+			return fmt.Errorf("io-latency requires a delay (e.g., '100ms')")
+		}
+		return checkPattern("delay", p.Delay, durationPattern)
+	case chaosdrv1.ChaosTypeIOFault:
+		if p.Errno == 0 {
+			return fmt.Errorf("io-fault requires an errno (e.g., 5 for EIO)")
+		}
+	case chaosdrv1.ChaosTypeIOAttrOverride:
+		a := p.Attr
+		if a == nil || (a.Perm == nil && a.Size == nil && a.Blocks == nil && a.Nlink == nil &&
+			a.UID == nil && a.GID == nil && a.Kind == "") {
+			return fmt.Errorf("io-attr-override requires at least one attribute in attr")
+		}
+		if a.Kind != "" && !slices.Contains(ioFileKinds, a.Kind) {
+			return fmt.Errorf("invalid attr kind %q, expected one of %v", a.Kind, ioFileKinds)
+		}
+	}
+	return nil
+}
+
+// convertLegacySpec builds a ChaosSpec from chaosType and chaosParameters.
+// Unknown parameter keys are rejected so a typo such as "dealy" is reported.
+func convertLegacySpec(chaosType string, params map[string]string) (*chaosdrv1.ChaosSpec, error) {
+	allowed, ok := legacyParameters[chaosType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported chaosType: %s", chaosType)
+	}
+	for key := range params {
+		if !slices.Contains(allowed, key) {
+			return nil, fmt.Errorf("unknown chaosParameters key %q for %s, expected one of %v", key, chaosType, allowed)
+		}
+	}
+
+	spec := &chaosdrv1.ChaosSpec{Type: chaosType}
+	switch chaosType {
+	case chaosdrv1.ChaosTypePodDelete:
+		spec.PodDelete = &chaosdrv1.PodDeleteSpec{}
+	case chaosdrv1.ChaosTypeNetworkDelay:
+		if params["delay"] == "" {
+			return nil, fmt.Errorf("network-delay requires 'delay' parameter (e.g., '100ms')")
+		}
+		spec.NetworkDelay = &chaosdrv1.NetworkDelaySpec{
+			Latency:     params["delay"],
+			Jitter:      params["jitter"],
+			Correlation: params["correlation"],
+		}
+	case chaosdrv1.ChaosTypeNetworkLoss:
+		if params["loss"] == "" {
+			return nil, fmt.Errorf("network-loss requires 'loss' parameter (e.g., '25')")
+		}
+		spec.NetworkLoss = &chaosdrv1.NetworkLossSpec{
+			Loss:        params["loss"],
+			Correlation: params["correlation"],
+		}
+	case chaosdrv1.ChaosTypeNetworkPartition:
+		partition := &chaosdrv1.NetworkPartitionSpec{Direction: params["direction"]}
+		if target := params["target"]; target != "" {
+			selector, err := labels.ConvertSelectorToLabelsMap(target)
+			if err != nil {
+				return nil, fmt.Errorf("network-partition 'target' must be a label selector such as app=redis: %w", err)
+			}
+			partition.Target = &chaosdrv1.NetworkTarget{Selector: selector, Namespace: params["targetNamespace"]}
+		}
+		if external := params["externalTargets"]; external != "" {
+			partition.ExternalTargets = strings.Split(external, ",")
+		}
+		spec.NetworkPartition = partition
+	case chaosdrv1.ChaosTypeNetworkCorrupt:
+		spec.NetworkCorrupt = &chaosdrv1.NetworkCorruptSpec{
+			Corrupt:     params["corrupt"],
+			Correlation: params["correlation"],
+		}
+	case chaosdrv1.ChaosTypeNetworkDuplicate:
+		spec.NetworkDuplicate = &chaosdrv1.NetworkDuplicateSpec{
+			Duplicate:   params["duplicate"],
+			Correlation: params["correlation"],
+		}
+	case chaosdrv1.ChaosTypeNetworkBandwidth:
+		bandwidth := &chaosdrv1.NetworkBandwidthSpec{Rate: params["rate"]}
+		for key, field := range map[string]*uint32{"limit": &bandwidth.Limit, "buffer": &bandwidth.Buffer} {
+			if value := params[key]; value != "" {
+				n, err := strconv.ParseUint(value, 10, 32)
+				if err != nil || n == 0 {
+					return nil, fmt.Errorf("network-bandwidth '%s' must be a positive number of bytes, got %q", key, value)
+				}
+				*field = uint32(n)
+			}
+		}
+		spec.NetworkBandwidth = bandwidth
+	case chaosdrv1.ChaosTypeCPUStress, chaosdrv1.ChaosTypeMemoryStress:
+		stress := &chaosdrv1.StressSpec{Workers: 1, MemorySize: params["size"]}
+		if workers := params["workers"]; workers != "" {
+			n, err := strconv.Atoi(workers)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("%s 'workers' must be between 1 and %d, got %q", chaosType, maxStressWorkers, workers)
+			}
+			stress.Workers = n
+		}
+		if load := params["load"]; load != "" {
+			n, err := strconv.Atoi(load)
+			if err != nil {
+				return nil, fmt.Errorf("cpu-stress 'load' must be a percentage between 0 and 100, got %q", load)
+			}
+			stress.Load = &n
+		}
+		spec.Stress = stress
+	case chaosdrv1.ChaosTypeIOLatency, chaosdrv1.ChaosTypeIOFault, chaosdrv1.ChaosTypeIOAttrOverride:
+		io, err := convertLegacyIOSpec(chaosType, params)
+		if err != nil {
+			return nil, err
+		}
+		spec.IO = io
+	case chaosdrv1.ChaosTypeTimeSkew:
+		spec.TimeSkew = &chaosdrv1.TimeSkewSpec{Offset: params["offset"]}
+		if ids := params["clockIds"]; ids != "" {
+			spec.TimeSkew.ClockIDs = strings.Split(ids, ",")
+		}
+	case chaosdrv1.ChaosTypeNodeDrain:
+		spec.NodeDrain = &chaosdrv1.NodeDrainSpec{MaxNodes: 1}
+		if maxNodes := params["maxNodes"]; maxNodes != "" {
+			n, err := strconv.Atoi(maxNodes)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("node-drain 'maxNodes' must be at least 1, got %q", maxNodes)
+			}
+			spec.NodeDrain.MaxNodes = n
+		}
+	case chaosdrv1.ChaosTypeDNSError, chaosdrv1.ChaosTypeDNSRandom:
+		spec.DNS = &chaosdrv1.DNSSpec{}
+		if patterns := params["patterns"]; patterns != "" {
+			spec.DNS.Patterns = strings.Split(patterns, ",")
+		}
+	}
+	return spec, nil
+}
+
+// convertLegacyIOSpec builds the io member of a legacy io chaos. Numeric
+// values are parsed here so a malformed one names its parameter.
+func convertLegacyIOSpec(chaosType string, params map[string]string) (*chaosdrv1.IOSpec, error) {
+	io := &chaosdrv1.IOSpec{
+		Volume:     params["volume"],
+		VolumePath: params["volumePath"],
+		Path:       params["path"],
+		Delay:      params["delay"],
+	}
+	if methods := params["methods"]; methods != "" {
+		io.Methods = strings.Split(methods, ",")
+	}
+	if percent := params["percent"]; percent != "" {
+		n, err := strconv.Atoi(percent)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("%s 'percent' must be between 1 and 100, got %q", chaosType, percent)
+		}
+		io.Percent = n
+	}
+	if errno := params["errno"]; errno != "" {
+		n, err := strconv.ParseUint(errno, 10, 32)
+		if err != nil || n == 0 {
+			return nil, fmt.Errorf("io-fault 'errno' must be a positive error number, got %q", errno)
+		}
+		io.Errno = uint32(n)
+	}
+	if chaosType == chaosdrv1.ChaosTypeIOAttrOverride {
+		io.Attr = &chaosdrv1.IOAttrOverrideSpec{Kind: params["kind"]}
+		if perm := params["perm"]; perm != "" {
+			// Accept octal modes such as 0444 as well as decimal ones
+			n, err := strconv.ParseUint(perm, 0, 16)
+			if err != nil {
+				return nil, fmt.Errorf("io-attr-override 'perm' must be a file mode such as 0444, got %q", perm)
+			}
+			mode := uint16(n)
+			io.Attr.Perm = &mode
+		}
+		if size := params["size"]; size != "" {
+			n, err := strconv.ParseUint(size, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("io-attr-override 'size' must be a number of bytes, got %q", size)
+			}
+			io.Attr.Size = &n
+		}
+	}
+	return io, nil
+}
+
+// checkPattern reports an error when the optional value does not match pattern.
+func checkPattern(field, value string, pattern *regexp.Regexp) error {
+	if value != "" && !pattern.MatchString(value) {
+		return fmt.Errorf("invalid %s %q", field, value)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+// volumeMount is where a container of a selected pod mounts a PVC
+type volumeMount struct {
+	claim     string
+	path      string
+	container string
+}
+
+// resolveVolumeMount finds the mount path io chaos is injected into and the
+// containers that use it. The path comes from the PVCs mounted by the pods
+// selected by cr, narrowed to params.Volume when set; an explicit VolumePath
+// overrides it. IOChaos only injects the first container unless told
+// otherwise, so the mounting containers are returned for its selector.
+func resolveVolumeMount(ctx context.Context, cl client.Client, cr *chaosdrv1.ChaosDRTest, params *chaosdrv1.IOSpec) (string, []string, error) {
+	mounts, err := claimMounts(ctx, cl, cr.Namespace, cr.Spec.AppSelector)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var paths, containers []string
+	for _, m := range mounts {
+		if params.Volume != "" && m.claim != params.Volume {
+			continue
+		}
+		if params.VolumePath != "" && m.path != params.VolumePath {
+			continue
+		}
+		if !slices.Contains(paths, m.path) {
+			paths = append(paths, m.path)
+		}
+		if !slices.Contains(containers, m.container) {
+			containers = append(containers, m.container)
+		}
+	}
+	slices.Sort(paths)
+	slices.Sort(containers)
+
+	switch {
+	case params.VolumePath != "":
+		// The path may belong to a volume that is not a PVC, such as an emptyDir
+		return params.VolumePath, containers, nil
+	case len(paths) == 0 && params.Volume != "":
+		return "", nil, fmt.Errorf("no pod selected by appSelector mounts PVC %s", params.Volume)
+	case len(paths) == 0:
+		return "", nil, fmt.Errorf("no pod selected by appSelector mounts a PVC")
+	case len(paths) > 1:
+		return "", nil, fmt.Errorf("selected pods mount PVCs at several paths %v, set io.volume or io.volumePath", paths)
+	}
+	return paths[0], containers, nil
+}
+
+// claimMounts lists the PVC mounts of the containers of the pods matching selector.
+func claimMounts(ctx context.Context, cl client.Client, namespace string, selector map[string]string) ([]volumeMount, error) {
+	pods := &corev1.PodList{}
+	if err := cl.List(ctx, pods, client.InNamespace(namespace), client.MatchingLabels(selector)); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	var mounts []volumeMount
+	for _, pod := range pods.Items {
+		claims := map[string]string{}
+		for _, vol := range pod.Spec.Volumes {
+			if vol.PersistentVolumeClaim != nil {
+				claims[vol.Name] = vol.PersistentVolumeClaim.ClaimName
+			}
+		}
+		for _, container := range pod.Spec.Containers {
+			for _, vm := range container.VolumeMounts {
+				if claim, ok := claims[vm.Name]; ok {
+					mounts = append(mounts, volumeMount{claim: claim, path: vm.MountPath, container: container.Name})
+				}
+			}
+		}
+	}
+	return mounts, nil
+}
@@ -0,0 +1,362 @@
+package litmus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+	"github.com/harrisin2037/chaos-dr-validator/internal/chaos"
+)
+
+// DefaultServiceAccount is the service account the Litmus experiments run as
+// unless configured otherwise
+const DefaultServiceAccount = "litmus-admin"
+
+var (
+	chaosEngineGVK = schema.GroupVersionKind{Group: "litmuschaos.io", Version: "v1alpha1", Kind: "ChaosEngine"}
+	chaosResultGVK = schema.GroupVersionKind{Group: "litmuschaos.io", Version: "v1alpha1", Kind: "ChaosResult"}
+)
+
+// memorySizePattern splits a memory-stress size such as 256MB into number and unit
+var memorySizePattern = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?)(%|MB|MiB|GB|GiB)$`)
+
+// engineSpec mirrors the subset of litmuschaos.io/v1alpha1 ChaosEngineSpec the operator sets
+type engineSpec struct {
+	EngineState         string          `json:"engineState"`
+	AppInfo             appInfo         `json:"appinfo"`
+	ChaosServiceAccount string          `json:"chaosServiceAccount"`
+	JobCleanUpPolicy    string          `json:"jobCleanUpPolicy"`
+	Experiments         []experimentRef `json:"experiments"`
+}
+
+// appInfo mirrors litmuschaos.io/v1alpha1 ApplicationParams
+type appInfo struct {
+	AppNS    string `json:"appns"`
+	AppLabel string `json:"applabel"`
+}
+
+// experimentRef names a ChaosExperiment and overrides its environment
+type experimentRef struct {
+	Name string `json:"name"`
+	Spec struct {
+		Components struct {
+			Env []envVar `json:"env"`
+		} `json:"components"`
+	} `json:"spec"`
+}
+
+type envVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// engineStatus mirrors the subset of litmuschaos.io/v1alpha1 ChaosEngineStatus the operator reads
+type engineStatus struct {
+	EngineStatus string `json:"engineStatus,omitempty"`
+	Experiments  []struct {
+		Name    string `json:"name,omitempty"`
+		Status  string `json:"status,omitempty"`
+		Verdict string `json:"verdict,omitempty"`
+	} `json:"experiments,omitempty"`
+}
+
+// LitmusClient injects chaos by creating LitmusChaos ChaosEngines. Each chaos
+// type maps to one generic Litmus experiment, which must be installed in the
+// test's namespace.
+type LitmusClient struct {
+	Client client.Client
+	// ServiceAccount is the chaosServiceAccount of the ChaosEngines
+	ServiceAccount string
+}
+
+// NewLitmusClient returns a LitmusClient running its experiments as serviceAccount.
+func NewLitmusClient(cl client.Client, serviceAccount string) *LitmusClient {
+	if serviceAccount == "" {
+		serviceAccount = DefaultServiceAccount
+	}
+	return &LitmusClient{Client: cl, ServiceAccount: serviceAccount}
+}
+
+// NewLitmusFactory returns a chaos.Factory that rejects tests whose chaos has
+// no Litmus equivalent before anything is backed up.
+func NewLitmusFactory(cl client.Client, serviceAccount string) chaos.Factory {
+	c := NewLitmusClient(cl, serviceAccount)
+	return func(cr *chaosdrv1.ChaosDRTest) (chaos.ChaosProvider, error) {
+		if cr == nil {
+			return c, nil
+		}
+		spec, err := chaos.ResolveSpec(cr)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := experiment(spec, chaos.Duration(cr)); err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+}
+
+// Apply creates a ChaosEngine running the Litmus experiment of spec's type
+// against the app selected by cr.
+func (c *LitmusClient) Apply(ctx context.Context, cr *chaosdrv1.ChaosDRTest, chaosName string, spec *chaosdrv1.ChaosSpec) error {
+	if err := chaos.ValidateSpec(spec); err != nil {
+		return err
+	}
+	exp, err := experiment(spec, chaos.Duration(cr))
+	if err != nil {
+		return err
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&engineSpec{
+		EngineState: "active",
+		AppInfo: appInfo{
+			AppNS:    cr.Namespace,
+			AppLabel: labels.SelectorFromSet(cr.Spec.AppSelector).String(),
+		},
+		ChaosServiceAccount: c.ServiceAccount,
+		JobCleanUpPolicy:    "delete",
+		Experiments:         []experimentRef{*exp},
+	})
+	if err != nil {
+		return err
+	}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": content}}
+	obj.SetGroupVersionKind(chaosEngineGVK)
+	obj.SetName(chaosName)
+	obj.SetNamespace(cr.Namespace)
+	if err := c.Client.Create(ctx, obj); err != nil {
+		return fmt.Errorf("failed to create litmus chaos engine: %w", err)
+	}
+	return nil
+}
+
+// Status maps the experiment status and verdict of the ChaosEngine onto an
+// ExperimentStatus. Litmus reports experiments rather than pods, so the
+// experiment counts as the single target.
+func (c *LitmusClient) Status(ctx context.Context, namespace, chaosName, chaosType string) (*chaos.ExperimentStatus, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(chaosEngineGVK)
+	if err := c.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: chaosName}, obj); err != nil {
+		return nil, fmt.Errorf("failed to get litmus chaos engine %s: %w", chaosName, err)
+	}
+
+	var st engineStatus
+	if raw, ok := obj.Object["status"].(map[string]interface{}); ok {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw, &st); err != nil {
+			return nil, fmt.Errorf("invalid litmus chaos engine %s status: %w", chaosName, err)
+		}
+	}
+
+	status := &chaos.ExperimentStatus{State: chaos.ExperimentPending}
+	if len(st.Experiments) == 0 {
+		return status, nil
+	}
+	exp := st.Experiments[0]
+	status.TotalTargets = 1
+	switch {
+	case exp.Verdict == "Fail":
+		status.State = chaos.ExperimentFailed
+		status.Message = fmt.Sprintf("experiment %s failed", exp.Name)
+		if step := c.failStep(ctx, namespace, chaosName, exp.Name); step != "" {
+			status.Message += ": " + step
+		}
+	case exp.Verdict == "Pass" || exp.Verdict == "Stopped" || st.EngineStatus == "completed" || st.EngineStatus == "stopped":
+		status.State = chaos.ExperimentRecovered
+	case exp.Status == "Running":
+		status.State = chaos.ExperimentInjected
+		status.InjectedTargets = 1
+	}
+	return status, nil
+}
+
+// Cleanup deletes the ChaosEngine, whose runner and experiment pods revert
+// the chaos as they are removed, and the ChaosResult it left behind.
+func (c *LitmusClient) Cleanup(ctx context.Context, namespace, chaosName, chaosType string) error {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(chaosEngineGVK)
+	obj.SetName(chaosName)
+	obj.SetNamespace(namespace)
+	if err := c.Client.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete litmus chaos engine %s: %w", chaosName, err)
+	}
+
+	name, ok := experimentNames[chaosType]
+	if !ok {
+		return nil
+	}
+	result := &unstructured.Unstructured{}
+	result.SetGroupVersionKind(chaosResultGVK)
+	result.SetName(chaosName + "-" + name)
+	result.SetNamespace(namespace)
+	if err := c.Client.Delete(ctx, result); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete litmus chaos result %s: %w", result.GetName(), err)
+	}
+	return nil
+}
+
+// failStep reads why an experiment failed from its ChaosResult, if there is one.
+func (c *LitmusClient) failStep(ctx context.Context, namespace, chaosName, experimentName string) string {
+	result := &unstructured.Unstructured{}
+	result.SetGroupVersionKind(chaosResultGVK)
+	if err := c.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: chaosName + "-" + experimentName}, result); err != nil {
+		return ""
+	}
+	step, _, _ := unstructured.NestedString(result.Object, "status", "experimentStatus", "failStep")
+	return step
+}
+
+// experimentNames maps the chaos types Litmus can run to its generic experiments
+var experimentNames = map[string]string{
+	chaosdrv1.ChaosTypePodDelete:        "pod-delete",
+	chaosdrv1.ChaosTypeNetworkDelay:     "pod-network-latency",
+	chaosdrv1.ChaosTypeNetworkLoss:      "pod-network-loss",
+	chaosdrv1.ChaosTypeNetworkCorrupt:   "pod-network-corruption",
+	chaosdrv1.ChaosTypeNetworkDuplicate: "pod-network-duplication",
+	chaosdrv1.ChaosTypeCPUStress:        "pod-cpu-hog",
+	chaosdrv1.ChaosTypeMemoryStress:     "pod-memory-hog",
+	chaosdrv1.ChaosTypeDNSError:         "pod-dns-error",
+}
+
+// experiment translates spec into the Litmus experiment running it for
+// duration. Options Litmus has no equivalent for are rejected rather than
+// silently dropped.
+func experiment(spec *chaosdrv1.ChaosSpec, duration time.Duration) (*experimentRef, error) {
+	name, ok := experimentNames[spec.Type]
+	if !ok {
+		return nil, fmt.Errorf("chaos provider litmus does not support chaos type %s", spec.Type)
+	}
+
+	env := []envVar{{Name: "TOTAL_CHAOS_DURATION", Value: strconv.Itoa(int(math.Ceil(duration.Seconds())))}}
+	// Litmus targets PODS_AFFECTED_PERC percent of the pods, and one pod for 0
+	switch spec.Mode {
+	case "":
+	case chaosdrv1.ChaosModeOne:
+		env = append(env, envVar{Name: "PODS_AFFECTED_PERC", Value: "0"})
+	case chaosdrv1.ChaosModeAll:
+		env = append(env, envVar{Name: "PODS_AFFECTED_PERC", Value: "100"})
+	case chaosdrv1.ChaosModeFixedPercent:
+		env = append(env, envVar{Name: "PODS_AFFECTED_PERC", Value: spec.Value})
+	default:
+		return nil, fmt.Errorf("chaos provider litmus does not support mode %s", spec.Mode)
+	}
+	switch spec.Type {
+	case chaosdrv1.ChaosTypePodDelete:
+		force := spec.PodDelete == nil || spec.PodDelete.GracePeriod == 0
+		env = append(env, envVar{Name: "FORCE", Value: strconv.FormatBool(force)})
+	case chaosdrv1.ChaosTypeNetworkDelay:
+		p := spec.NetworkDelay
+		if p.Correlation != "" {
+			return nil, fmt.Errorf("chaos provider litmus does not support network-delay correlation")
+		}
+		latency, err := milliseconds(p.Latency)
+		if err != nil {
+			return nil, err
+		}
+		env = append(env, envVar{Name: "NETWORK_LATENCY", Value: latency})
+		if p.Jitter != "" {
+			jitter, err := milliseconds(p.Jitter)
+			if err != nil {
+				return nil, err
+			}
+			env = append(env, envVar{Name: "JITTER", Value: jitter})
+		}
+	case chaosdrv1.ChaosTypeNetworkLoss:
+		if spec.NetworkLoss.Correlation != "" {
+			return nil, fmt.Errorf("chaos provider litmus does not support network-loss correlation")
+		}
+		env = append(env, envVar{Name: "NETWORK_PACKET_LOSS_PERCENTAGE", Value: spec.NetworkLoss.Loss})
+	case chaosdrv1.ChaosTypeNetworkCorrupt:
+		if spec.NetworkCorrupt.Correlation != "" {
+			return nil, fmt.Errorf("chaos provider litmus does not support network-corrupt correlation")
+		}
+		env = append(env, envVar{Name: "NETWORK_PACKET_CORRUPTION_PERCENTAGE", Value: spec.NetworkCorrupt.Corrupt})
+	case chaosdrv1.ChaosTypeNetworkDuplicate:
+		if spec.NetworkDuplicate.Correlation != "" {
+			return nil, fmt.Errorf("chaos provider litmus does not support network-duplicate correlation")
+		}
+		env = append(env, envVar{Name: "NETWORK_PACKET_DUPLICATION_PERCENTAGE", Value: spec.NetworkDuplicate.Duplicate})
+	case chaosdrv1.ChaosTypeCPUStress:
+		workers, load := 1, (*int)(nil)
+		if spec.Stress != nil {
+			workers = max(spec.Stress.Workers, 1)
+			load = spec.Stress.Load
+		}
+		env = append(env, envVar{Name: "CPU_CORES", Value: strconv.Itoa(workers)})
+		if load != nil {
+			env = append(env, envVar{Name: "CPU_LOAD", Value: strconv.Itoa(*load)})
+		}
+	case chaosdrv1.ChaosTypeMemoryStress:
+		workers := 1
+		if spec.Stress != nil {
+			workers = max(spec.Stress.Workers, 1)
+		}
+		env = append(env, envVar{Name: "NUMBER_OF_WORKERS", Value: strconv.Itoa(workers)})
+		if spec.Stress != nil && spec.Stress.MemorySize != "" {
+			memory, err := memoryEnv(spec.Stress.MemorySize)
+			if err != nil {
+				return nil, err
+			}
+			env = append(env, memory)
+		}
+	case chaosdrv1.ChaosTypeDNSError:
+		if spec.DNS != nil && len(spec.DNS.Patterns) > 0 {
+			for _, pattern := range spec.DNS.Patterns {
+				if strings.ContainsAny(pattern, "?*") {
+					return nil, fmt.Errorf("chaos provider litmus matches host names exactly, pattern %q uses a wildcard", pattern)
+				}
+			}
+			hosts, err := json.Marshal(spec.DNS.Patterns)
+			if err != nil {
+				return nil, err
+			}
+			env = append(env,
+				envVar{Name: "TARGET_HOSTNAMES", Value: string(hosts)},
+				envVar{Name: "MATCH_SCHEME", Value: "exact"})
+		}
+	}
+
+	exp := &experimentRef{Name: name}
+	exp.Spec.Components.Env = env
+	return exp, nil
+}
+
+// milliseconds converts a duration such as 1.5s to the whole milliseconds Litmus expects.
+func milliseconds(value string) (string, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid duration %q: %w", value, err)
+	}
+	return strconv.FormatInt(d.Milliseconds(), 10), nil
+}
+
+// memoryEnv converts a memory-stress size to MEMORY_CONSUMPTION in megabytes,
+// or MEMORY_PERCENTAGE for a percentage of the available memory.
+func memoryEnv(size string) (envVar, error) {
+	m := memorySizePattern.FindStringSubmatch(size)
+	if m == nil {
+		return envVar{}, fmt.Errorf("chaos provider litmus supports memory sizes in MB, MiB, GB, GiB or %%, got %q", size)
+	}
+	n, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return envVar{}, err
+	}
+	switch m[2] {
+	case "%":
+		return envVar{Name: "MEMORY_PERCENTAGE", Value: strconv.Itoa(int(n))}, nil
+	case "GB", "GiB":
+		n *= 1024
+	}
+	return envVar{Name: "MEMORY_CONSUMPTION", Value: strconv.Itoa(int(math.Ceil(n)))}, nil
+}
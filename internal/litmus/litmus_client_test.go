@@ -0,0 +1,278 @@
+package litmus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+	"github.com/harrisin2037/chaos-dr-validator/internal/chaos"
+)
+
+func newTestCR(spec *chaosdrv1.ChaosSpec) *chaosdrv1.ChaosDRTest {
+	return &chaosdrv1.ChaosDRTest{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-dr", Namespace: "default"},
+		Spec: chaosdrv1.ChaosDRTestSpec{
+			AppSelector:   map[string]string{"app": "redis", "tier": "cache"},
+			Chaos:         spec,
+			ChaosDuration: &metav1.Duration{Duration: 90 * time.Second},
+		},
+	}
+}
+
+func getEngine(t *testing.T, cl client.Client, name string) *unstructured.Unstructured {
+	t.Helper()
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(chaosEngineGVK)
+	if err := cl.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: name}, obj); err != nil {
+		t.Fatalf("Failed to get chaos engine %s: %v", name, err)
+	}
+	return obj
+}
+
+// experimentEnv returns the env overrides of the engine's only experiment.
+func experimentEnv(t *testing.T, obj *unstructured.Unstructured) (string, map[string]string) {
+	t.Helper()
+	experiments, _, _ := unstructured.NestedSlice(obj.Object, "spec", "experiments")
+	if len(experiments) != 1 {
+		t.Fatalf("Expected one experiment, got %v", experiments)
+	}
+	exp := experiments[0].(map[string]interface{})
+	vars, _, _ := unstructured.NestedSlice(exp, "spec", "components", "env")
+	env := map[string]string{}
+	for _, v := range vars {
+		m := v.(map[string]interface{})
+		env[m["name"].(string)] = m["value"].(string)
+	}
+	return exp["name"].(string), env
+}
+
+func TestApplyPodDelete(t *testing.T) {
+	cl := fake.NewClientBuilder().Build()
+	c := NewLitmusClient(cl, "")
+	cr := newTestCR(&chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypePodDelete, PodDelete: &chaosdrv1.PodDeleteSpec{GracePeriod: 5}})
+
+	if err := c.Apply(context.Background(), cr, "chaos-test-dr", cr.Spec.Chaos); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	obj := getEngine(t, cl, "chaos-test-dr")
+	appLabel, _, _ := unstructured.NestedString(obj.Object, "spec", "appinfo", "applabel")
+	if appLabel != "app=redis,tier=cache" {
+		t.Errorf("Expected applabel app=redis,tier=cache, got %q", appLabel)
+	}
+	account, _, _ := unstructured.NestedString(obj.Object, "spec", "chaosServiceAccount")
+	if account != DefaultServiceAccount {
+		t.Errorf("Expected service account %s, got %q", DefaultServiceAccount, account)
+	}
+	state, _, _ := unstructured.NestedString(obj.Object, "spec", "engineState")
+	if state != "active" {
+		t.Errorf("Expected engineState active, got %q", state)
+	}
+
+	name, env := experimentEnv(t, obj)
+	if name != "pod-delete" {
+		t.Errorf("Expected experiment pod-delete, got %s", name)
+	}
+	if env["TOTAL_CHAOS_DURATION"] != "90" || env["FORCE"] != "false" {
+		t.Errorf("Expected 90s graceful pod-delete, got %v", env)
+	}
+}
+
+func TestApplyExperimentEnv(t *testing.T) {
+	load := 80
+	tests := []struct {
+		spec       chaosdrv1.ChaosSpec
+		experiment string
+		env        map[string]string
+	}{
+		{
+			spec: chaosdrv1.ChaosSpec{
+				Type:         chaosdrv1.ChaosTypeNetworkDelay,
+				NetworkDelay: &chaosdrv1.NetworkDelaySpec{Latency: "1.5s", Jitter: "10ms"},
+			},
+			experiment: "pod-network-latency",
+			env:        map[string]string{"NETWORK_LATENCY": "1500", "JITTER": "10"},
+		},
+		{
+			spec:       chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypeNetworkLoss, NetworkLoss: &chaosdrv1.NetworkLossSpec{Loss: "25"}},
+			experiment: "pod-network-loss",
+			env:        map[string]string{"NETWORK_PACKET_LOSS_PERCENTAGE": "25"},
+		},
+		{
+			spec:       chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypeCPUStress, Stress: &chaosdrv1.StressSpec{Workers: 2, Load: &load}},
+			experiment: "pod-cpu-hog",
+			env:        map[string]string{"CPU_CORES": "2", "CPU_LOAD": "80"},
+		},
+		{
+			spec:       chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypeMemoryStress, Stress: &chaosdrv1.StressSpec{Workers: 1, MemorySize: "1GiB"}},
+			experiment: "pod-memory-hog",
+			env:        map[string]string{"NUMBER_OF_WORKERS": "1", "MEMORY_CONSUMPTION": "1024"},
+		},
+		{
+			spec: chaosdrv1.ChaosSpec{
+				Type:        chaosdrv1.ChaosTypeNetworkLoss,
+				Mode:        chaosdrv1.ChaosModeFixedPercent,
+				Value:       "50",
+				NetworkLoss: &chaosdrv1.NetworkLossSpec{Loss: "10"},
+			},
+			experiment: "pod-network-loss",
+			env:        map[string]string{"PODS_AFFECTED_PERC": "50"},
+		},
+		{
+			spec:       chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypeDNSError, DNS: &chaosdrv1.DNSSpec{Patterns: []string{"postgres.db"}}},
+			experiment: "pod-dns-error",
+			env:        map[string]string{"TARGET_HOSTNAMES": `["postgres.db"]`, "MATCH_SCHEME": "exact"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec.Type, func(t *testing.T) {
+			cl := fake.NewClientBuilder().Build()
+			c := NewLitmusClient(cl, "litmus")
+			cr := newTestCR(&tt.spec)
+
+			if err := c.Apply(context.Background(), cr, "chaos-test-dr", cr.Spec.Chaos); err != nil {
+				t.Fatalf("Apply failed: %v", err)
+			}
+
+			name, env := experimentEnv(t, getEngine(t, cl, "chaos-test-dr"))
+			if name != tt.experiment {
+				t.Errorf("Expected experiment %s, got %s", tt.experiment, name)
+			}
+			for key, want := range tt.env {
+				if env[key] != want {
+					t.Errorf("Expected %s=%s, got %q", key, want, env[key])
+				}
+			}
+		})
+	}
+}
+
+func TestFactoryRejectsUnsupportedChaos(t *testing.T) {
+	factory := NewLitmusFactory(fake.NewClientBuilder().Build(), "")
+
+	tests := []chaosdrv1.ChaosSpec{
+		{Type: chaosdrv1.ChaosTypeIOFault, IO: &chaosdrv1.IOSpec{Errno: 5}},
+		{Type: chaosdrv1.ChaosTypeNetworkLoss, NetworkLoss: &chaosdrv1.NetworkLossSpec{Loss: "25", Correlation: "50"}},
+		{Type: chaosdrv1.ChaosTypeDNSError, DNS: &chaosdrv1.DNSSpec{Patterns: []string{"postgres.*"}}},
+		{Type: chaosdrv1.ChaosTypeMemoryStress, Stress: &chaosdrv1.StressSpec{Workers: 1, MemorySize: "512KB"}},
+		{Type: chaosdrv1.ChaosTypePodDelete, Mode: chaosdrv1.ChaosModeFixed, Value: "2"},
+	}
+	for _, spec := range tests {
+		if _, err := factory(newTestCR(&spec)); err == nil {
+			t.Errorf("Expected litmus to reject %s %+v", spec.Type, spec)
+		}
+	}
+
+	spec := chaosdrv1.ChaosSpec{Type: chaosdrv1.ChaosTypePodDelete, PodDelete: &chaosdrv1.PodDeleteSpec{}}
+	if _, err := factory(newTestCR(&spec)); err != nil {
+		t.Errorf("Expected litmus to run pod-delete, got %v", err)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   map[string]interface{}
+		result   map[string]interface{}
+		state    chaos.ExperimentState
+		injected int
+		message  string
+	}{
+		{
+			name:  "initialized",
+			state: chaos.ExperimentPending,
+		},
+		{
+			name: "running",
+			status: map[string]interface{}{
+				"engineStatus": "initialized",
+				"experiments":  []interface{}{map[string]interface{}{"name": "pod-delete", "status": "Running", "verdict": "Awaited"}},
+			},
+			state:    chaos.ExperimentInjected,
+			injected: 1,
+		},
+		{
+			name: "passed",
+			status: map[string]interface{}{
+				"engineStatus": "completed",
+				"experiments":  []interface{}{map[string]interface{}{"name": "pod-delete", "status": "Completed", "verdict": "Pass"}},
+			},
+			state: chaos.ExperimentRecovered,
+		},
+		{
+			name: "failed",
+			status: map[string]interface{}{
+				"engineStatus": "completed",
+				"experiments":  []interface{}{map[string]interface{}{"name": "pod-delete", "status": "Completed", "verdict": "Fail"}},
+			},
+			result:  map[string]interface{}{"experimentStatus": map[string]interface{}{"failStep": "no target pods found"}},
+			state:   chaos.ExperimentFailed,
+			message: "experiment pod-delete failed: no target pods found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			engine.SetGroupVersionKind(chaosEngineGVK)
+			engine.SetName("chaos-test-dr")
+			engine.SetNamespace("default")
+			if tt.status != nil {
+				engine.Object["status"] = tt.status
+			}
+			objs := []client.Object{engine}
+			if tt.result != nil {
+				result := &unstructured.Unstructured{Object: map[string]interface{}{"status": tt.result}}
+				result.SetGroupVersionKind(chaosResultGVK)
+				result.SetName("chaos-test-dr-pod-delete")
+				result.SetNamespace("default")
+				objs = append(objs, result)
+			}
+			c := NewLitmusClient(fake.NewClientBuilder().WithObjects(objs...).Build(), "")
+
+			st, err := c.Status(context.Background(), "default", "chaos-test-dr", chaosdrv1.ChaosTypePodDelete)
+			if err != nil {
+				t.Fatalf("Status failed: %v", err)
+			}
+			if st.State != tt.state || st.InjectedTargets != tt.injected || st.Message != tt.message {
+				t.Errorf("Expected %s with %d injected and message %q, got %+v", tt.state, tt.injected, tt.message, st)
+			}
+		})
+	}
+}
+
+func TestCleanup(t *testing.T) {
+	engine := &unstructured.Unstructured{}
+	engine.SetGroupVersionKind(chaosEngineGVK)
+	engine.SetName("chaos-test-dr")
+	engine.SetNamespace("default")
+	result := &unstructured.Unstructured{}
+	result.SetGroupVersionKind(chaosResultGVK)
+	result.SetName("chaos-test-dr-pod-network-loss")
+	result.SetNamespace("default")
+	cl := fake.NewClientBuilder().WithObjects(engine, result).Build()
+	c := NewLitmusClient(cl, "")
+
+	if err := c.Cleanup(context.Background(), "default", "chaos-test-dr", chaosdrv1.ChaosTypeNetworkLoss); err != nil {
+		t.Fatalf("Cleanup failed: %v", err)
+	}
+	for _, obj := range []*unstructured.Unstructured{engine, result} {
+		err := cl.Get(context.Background(), client.ObjectKeyFromObject(obj), obj)
+		if !apierrors.IsNotFound(err) {
+			t.Errorf("Expected %s %s to be deleted, got %v", obj.GetKind(), obj.GetName(), err)
+		}
+	}
+
+	// Cleaning up again is a no-op
+	if err := c.Cleanup(context.Background(), "default", "chaos-test-dr", chaosdrv1.ChaosTypeNetworkLoss); err != nil {
+		t.Errorf("Expected repeated cleanup to succeed, got %v", err)
+	}
+}
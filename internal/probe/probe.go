@@ -0,0 +1,219 @@
+package probe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+// DefaultTimeout bounds a probe run when the probe sets no timeout
+const DefaultTimeout = 5 * time.Second
+
+// maxMessage caps the command output kept in a Result
+const maxMessage = 256
+
+// Result is the outcome of one probe run
+type Result struct {
+	Passed bool
+	// Value is what the probe observed, e.g. a status code or query result
+	Value   string
+	Message string
+}
+
+// Run executes probe once. Errors talking to the target are reported as a
+// failed Result, not returned, since an unreachable app is not in its steady state.
+func Run(ctx context.Context, probe *chaosdrv1.ProbeSpec) Result {
+	timeout := DefaultTimeout
+	if probe.Timeout != nil && probe.Timeout.Duration > 0 {
+		timeout = probe.Timeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	switch {
+	case probe.HTTP != nil:
+		return runHTTP(ctx, probe.HTTP)
+	case probe.TCP != nil:
+		return runTCP(ctx, probe.TCP)
+	case probe.Prometheus != nil:
+		return runPrometheus(ctx, probe.Prometheus)
+	case probe.Command != nil:
+		return runCommand(ctx, probe.Command)
+	default:
+		return Result{Message: fmt.Sprintf("probe %s has no http, tcp, prometheus or command", probe.Name)}
+	}
+}
+
+func runHTTP(ctx context.Context, spec *chaosdrv1.HTTPProbe) Result {
+	method := spec.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	expected := spec.ExpectedStatus
+	if expected == 0 {
+		expected = http.StatusOK
+	}
+	req, err := http.NewRequestWithContext(ctx, method, spec.URL, nil)
+	if err != nil {
+		return Result{Message: err.Error()}
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return Result{Message: err.Error()}
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	res := Result{Passed: resp.StatusCode == expected, Value: strconv.Itoa(resp.StatusCode)}
+	if !res.Passed {
+		res.Message = fmt.Sprintf("got status code %d, expected %d", resp.StatusCode, expected)
+	}
+	return res
+}
+
+func runTCP(ctx context.Context, spec *chaosdrv1.TCPProbe) Result {
+	start := time.Now()
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", spec.Address)
+	if err != nil {
+		return Result{Message: err.Error()}
+	}
+	conn.Close()
+	return Result{Passed: true, Value: time.Since(start).Round(time.Millisecond).String()}
+}
+
+func runPrometheus(ctx context.Context, spec *chaosdrv1.PrometheusProbe) Result {
+	value, err := Query(ctx, spec.URL, spec.Query)
+	if err != nil {
+		return Result{Message: err.Error()}
+	}
+	res := Result{Value: strconv.FormatFloat(value, 'g', -1, 64)}
+	res.Passed, err = Compare(value, spec.Comparator, spec.Threshold)
+	switch {
+	case err != nil:
+		res.Message = err.Error()
+	case !res.Passed:
+		res.Message = fmt.Sprintf("%s %s %s is false", res.Value, spec.Comparator, spec.Threshold)
+	}
+	return res
+}
+
+func runCommand(ctx context.Context, spec *chaosdrv1.CommandProbe) Result {
+	output, err := exec.CommandContext(ctx, "/bin/sh", "-c", spec.Command).CombinedOutput()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return Result{Passed: true, Value: "0"}
+	case errors.As(err, &exitErr):
+		return Result{Value: strconv.Itoa(exitErr.ExitCode()), Message: tail(string(output))}
+	default:
+		return Result{Message: err.Error()}
+	}
+}
+
+// queryResponse is the part of the Prometheus /api/v1/query response read by Query
+type queryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// Query runs an instant query against the Prometheus server at serverURL and
+// returns its value: the scalar, or the first sample of a vector.
+func Query(ctx context.Context, serverURL, query string) (float64, error) {
+	u := strings.TrimSuffix(serverURL, "/") + "/api/v1/query?" + url.Values{"query": {query}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query prometheus: %w", err)
+	}
+	defer resp.Body.Close()
+
+	qr := &queryResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(qr); err != nil {
+		return 0, fmt.Errorf("failed to decode prometheus response (status code %d): %w", resp.StatusCode, err)
+	}
+	if qr.Status != "success" {
+		return 0, fmt.Errorf("prometheus query %q failed: %s", query, qr.Error)
+	}
+
+	var sample []any
+	switch qr.Data.ResultType {
+	case "scalar":
+		if err := json.Unmarshal(qr.Data.Result, &sample); err != nil {
+			return 0, fmt.Errorf("failed to decode scalar result: %w", err)
+		}
+	case "vector":
+		var vector []struct {
+			Value []any `json:"value"`
+		}
+		if err := json.Unmarshal(qr.Data.Result, &vector); err != nil {
+			return 0, fmt.Errorf("failed to decode vector result: %w", err)
+		}
+		if len(vector) == 0 {
+			return 0, fmt.Errorf("prometheus query %q returned no samples", query)
+		}
+		sample = vector[0].Value
+	default:
+		return 0, fmt.Errorf("prometheus query %q returned a %s, expected a scalar or vector", query, qr.Data.ResultType)
+	}
+	if len(sample) != 2 {
+		return 0, fmt.Errorf("prometheus query %q returned a malformed sample", query)
+	}
+	s, _ := sample[1].(string)
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("prometheus query %q returned a non-numeric value %v", query, sample[1])
+	}
+	return value, nil
+}
+
+// Compare reports whether value <comparator> threshold holds.
+func Compare(value float64, comparator, threshold string) (bool, error) {
+	t, err := strconv.ParseFloat(threshold, 64)
+	if err != nil {
+		return false, fmt.Errorf("invalid threshold %q: %w", threshold, err)
+	}
+	switch comparator {
+	case chaosdrv1.ComparatorLess:
+		return value < t, nil
+	case chaosdrv1.ComparatorLessEqual:
+		return value <= t, nil
+	case chaosdrv1.ComparatorGreater:
+		return value > t, nil
+	case chaosdrv1.ComparatorGreaterEqual:
+		return value >= t, nil
+	case chaosdrv1.ComparatorEqual:
+		return value == t, nil
+	case chaosdrv1.ComparatorNotEqual:
+		return value != t, nil
+	default:
+		return false, fmt.Errorf("unknown comparator %q", comparator)
+	}
+}
+
+// tail returns the end of s, trimmed to maxMessage bytes.
+func tail(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) > maxMessage {
+		s = "..." + s[len(s)-maxMessage:]
+	}
+	return s
+}
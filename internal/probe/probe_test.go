@@ -0,0 +1,122 @@
+package probe
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+func TestRunHTTP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	res := Run(context.Background(), &chaosdrv1.ProbeSpec{Name: "up", HTTP: &chaosdrv1.HTTPProbe{URL: srv.URL + "/healthz"}})
+	if !res.Passed || res.Value != "200" {
+		t.Errorf("Expected a passing probe with value 200, got %+v", res)
+	}
+	res = Run(context.Background(), &chaosdrv1.ProbeSpec{Name: "down", HTTP: &chaosdrv1.HTTPProbe{URL: srv.URL + "/down"}})
+	if res.Passed || res.Value != "503" {
+		t.Errorf("Expected a failing probe with value 503, got %+v", res)
+	}
+	res = Run(context.Background(), &chaosdrv1.ProbeSpec{Name: "expected", HTTP: &chaosdrv1.HTTPProbe{URL: srv.URL + "/down", ExpectedStatus: 503}})
+	if !res.Passed {
+		t.Errorf("Expected the probe to accept its expected status, got %+v", res)
+	}
+}
+
+func TestRunTCP(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	addr := l.Addr().String()
+
+	if res := Run(context.Background(), &chaosdrv1.ProbeSpec{Name: "redis", TCP: &chaosdrv1.TCPProbe{Address: addr}}); !res.Passed {
+		t.Errorf("Expected the probe to connect, got %+v", res)
+	}
+	l.Close()
+	if res := Run(context.Background(), &chaosdrv1.ProbeSpec{Name: "redis", TCP: &chaosdrv1.TCPProbe{Address: addr}}); res.Passed || res.Message == "" {
+		t.Errorf("Expected the probe to fail with a message once the listener is closed, got %+v", res)
+	}
+}
+
+func TestRunPrometheus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("query") {
+		case "error_rate":
+			fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"0.02"]}]}}`)
+		case "scalar(up)":
+			fmt.Fprint(w, `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"1"]}}`)
+		case "absent":
+			fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[]}}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"status":"error","error":"parse error"}`)
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		query      string
+		comparator string
+		threshold  string
+		passed     bool
+		value      string
+	}{
+		{"below threshold", "error_rate", "<", "0.05", true, "0.02"},
+		{"above threshold", "error_rate", ">=", "0.05", false, "0.02"},
+		{"scalar", "scalar(up)", "==", "1", true, "1"},
+		{"no samples", "absent", "<", "1", false, ""},
+		{"query error", "bad(", "<", "1", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := Run(context.Background(), &chaosdrv1.ProbeSpec{Name: "p", Prometheus: &chaosdrv1.PrometheusProbe{
+				URL: srv.URL + "/", Query: tt.query, Comparator: tt.comparator, Threshold: tt.threshold,
+			}})
+			if res.Passed != tt.passed || res.Value != tt.value {
+				t.Errorf("Expected passed=%v value=%q, got %+v", tt.passed, tt.value, res)
+			}
+			if !res.Passed && res.Message == "" {
+				t.Error("Expected a message on a failing probe")
+			}
+		})
+	}
+}
+
+func TestRunCommand(t *testing.T) {
+	if res := Run(context.Background(), &chaosdrv1.ProbeSpec{Name: "ok", Command: &chaosdrv1.CommandProbe{Command: "true"}}); !res.Passed {
+		t.Errorf("Expected the command to pass, got %+v", res)
+	}
+	res := Run(context.Background(), &chaosdrv1.ProbeSpec{Name: "fail", Command: &chaosdrv1.CommandProbe{Command: "echo redis down; exit 3"}})
+	if res.Passed || res.Value != "3" || !strings.Contains(res.Message, "redis down") {
+		t.Errorf("Expected exit code 3 with the output, got %+v", res)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	for _, c := range []string{"<", "<=", ">", ">=", "==", "!="} {
+		got, err := Compare(2, c, "2")
+		if err != nil {
+			t.Fatalf("Compare with %s failed: %v", c, err)
+		}
+		want := c == "<=" || c == ">=" || c == "=="
+		if got != want {
+			t.Errorf("Expected 2 %s 2 to be %v", c, want)
+		}
+	}
+	if _, err := Compare(1, "~", "2"); err == nil {
+		t.Error("Expected an unknown comparator to be rejected")
+	}
+}
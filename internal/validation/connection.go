@@ -0,0 +1,142 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+const (
+	// databaseTimeout bounds the connection and queries of a database check
+	databaseTimeout = 10 * time.Second
+	// connectRetryTimeout bounds how long the restored database of a check may refuse connections
+	connectRetryTimeout = 5 * time.Minute
+)
+
+// retryConnect reports whether a check that could not connect to its
+// database is reported running, which it is until connectRetryTimeout after
+// the check started: a freshly restored database takes a while to come up.
+func retryConnect(ctx context.Context, cr *chaosdrv1.ChaosDRTest, check *chaosdrv1.ValidationCheck, err error) bool {
+	deadline := startTime(cr, check.Name).Add(connectRetryTimeout)
+	if time.Now().After(deadline) {
+		return false
+	}
+	log.FromContext(ctx).Info("Database check could not connect, retrying", "check", check.Name, "error", err.Error(), "deadline", deadline)
+	return true
+}
+
+// secretKey reads key of the Secret name in namespace.
+func secretKey(ctx context.Context, cl client.Reader, namespace, name, key string) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get secret %s: %w", name, err)
+	}
+	data, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("secret %s has no key %s", name, key)
+	}
+	return data, nil
+}
+
+// sandboxConnection reads the connection string of the source database from
+// ref and points it at the sandbox namespace of cr. Engine is mysql for a
+// MySQL DSN; any other connection string is a URL.
+func sandboxConnection(ctx context.Context, cl client.Reader, cr *chaosdrv1.ChaosDRTest, engine string, ref *corev1.SecretKeySelector) (string, error) {
+	if cr.Status.SandboxNamespace == "" {
+		return "", fmt.Errorf("the test has no sandbox namespace to connect to")
+	}
+	conn, err := secretKey(ctx, cl, cr.Namespace, ref.Name, ref.Key)
+	if err != nil {
+		return "", err
+	}
+	rewrite := func(host string) string { return sandboxHost(host, cr.Namespace, cr.Status.SandboxNamespace) }
+	if engine == chaosdrv1.DatabaseEngineMySQL {
+		return rewriteDSNHost(string(conn), rewrite)
+	}
+	return rewriteURLHosts(string(conn), rewrite)
+}
+
+// sandboxHost moves a host naming a service of namespace from to the same
+// service in namespace to: redis and redis.shop.svc.cluster.local both become
+// redis.sandbox.svc when moving from shop to sandbox. StatefulSet pod names
+// such as mysql-0.mysql.shop.svc move the same way. A two label host is
+// taken as a pod of a service in namespace from unless its second label is
+// from, as the cluster resolver tries that first; a service in another
+// namespace must be qualified with .svc to be left alone. IP addresses and
+// hosts outside namespace from are returned unchanged.
+func sandboxHost(host, from, to string) string {
+	if host == "" || net.ParseIP(host) != nil {
+		return host
+	}
+	rest, qualified := strings.CutSuffix(host, ".svc.cluster.local")
+	if !qualified {
+		rest, qualified = strings.CutSuffix(host, ".svc")
+	}
+	labels := strings.Split(rest, ".")
+	last := len(labels) - 1
+	switch {
+	case qualified && (len(labels) < 2 || len(labels) > 3 || labels[last] != from):
+		return host
+	case qualified:
+		labels = labels[:last]
+	case len(labels) == 2 && labels[1] == from:
+		labels = labels[:1]
+	case len(labels) == 3 && labels[2] == from:
+		labels = labels[:2]
+	case len(labels) > 2:
+		return host
+	}
+	return strings.Join(labels, ".") + "." + to + ".svc"
+}
+
+// rewriteDSNHost applies rewrite to the host of a MySQL DSN.
+func rewriteDSNHost(dsn string, rewrite func(string) string) (string, error) {
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return "", fmt.Errorf("invalid MySQL DSN: %w", err)
+	}
+	if cfg.Net == "tcp" {
+		cfg.Addr = rewriteHostPort(cfg.Addr, rewrite)
+	}
+	return cfg.FormatDSN(), nil
+}
+
+// rewriteURLHosts applies rewrite to every host of a URL. MongoDB URIs may
+// list several comma separated hosts, which net/url does not parse.
+func rewriteURLHosts(uri string, rewrite func(string) string) (string, error) {
+	scheme, rest, ok := strings.Cut(uri, "://")
+	if !ok {
+		return "", fmt.Errorf("connection string is not a URL")
+	}
+	authority, path := rest, ""
+	if i := strings.IndexAny(rest, "/?"); i >= 0 {
+		authority, path = rest[:i], rest[i:]
+	}
+	userinfo, hosts := "", authority
+	if i := strings.LastIndex(authority, "@"); i >= 0 {
+		userinfo, hosts = authority[:i+1], authority[i+1:]
+	}
+	list := strings.Split(hosts, ",")
+	for i, h := range list {
+		list[i] = rewriteHostPort(h, rewrite)
+	}
+	return scheme + "://" + userinfo + strings.Join(list, ",") + path, nil
+}
+
+// rewriteHostPort applies rewrite to the host of hostport, keeping its port.
+func rewriteHostPort(hostport string, rewrite func(string) string) string {
+	host, port, err := net.SplitHostPort(hostport)
+	if err != nil {
+		return rewrite(hostport)
+	}
+	return net.JoinHostPort(rewrite(host), port)
+}
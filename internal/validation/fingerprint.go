@@ -0,0 +1,570 @@
+package validation
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+	"github.com/harrisin2037/chaos-dr-validator/internal/backup"
+)
+
+const (
+	// fingerprintKey holds the source fingerprint in its ConfigMap
+	fingerprintKey = "fingerprint"
+	// maxDiffEntities caps the entities a FingerprintDiff names
+	maxDiffEntities = 20
+	// defaultRedisMaxKeys bounds redis fingerprints that set no maxKeys
+	defaultRedisMaxKeys = 10000
+	// fingerprintJobTimeout bounds the Jobs hashing the files of a claim
+	fingerprintJobTimeout = 30 * time.Minute
+	// fingerprintTimeout bounds fingerprinting a database
+	fingerprintTimeout = 2 * time.Minute
+	// pvcMountPath is where fingerprint Jobs mount the claim
+	pvcMountPath = "/data"
+)
+
+// tablePattern matches the plain or schema qualified table names of sql fingerprints
+var tablePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*(\.[A-Za-z_][A-Za-z0-9_$]*)?$`)
+
+// fingerprint maps the entities of a dataset to a digest of their content
+type fingerprint map[string]string
+
+// FingerprintValidator runs fingerprint checks. It captures the fingerprint
+// of the source data before the backup into a ConfigMap in the test's
+// namespace and compares the restored data with it.
+type FingerprintValidator struct {
+	Client client.Client
+	// Reader reads the connection Secrets of a check and the source
+	// fingerprints. It must not be the manager's cached client: the operator
+	// may not list or watch Secrets, nor ConfigMaps outside its namespace.
+	Reader client.Reader
+	// Pods reads the output of the Jobs fingerprinting claims
+	Pods corev1client.PodsGetter
+	// Test is the ChaosDRTest the checks belong to
+	Test *chaosdrv1.ChaosDRTest
+	// Open opens a SQL database; it defaults to sql.Open with the driver of the engine
+	Open func(engine, dsn string) (*sql.DB, error)
+}
+
+// NewFingerprintFactory returns a Factory for FingerprintValidator.
+func NewFingerprintFactory(cl client.Client, reader client.Reader, pods corev1client.PodsGetter) Factory {
+	return func(cr *chaosdrv1.ChaosDRTest) (Validator, error) {
+		return &FingerprintValidator{Client: cl, Reader: reader, Pods: pods, Test: cr, Open: openSQL}, nil
+	}
+}
+
+// Capture fingerprints the source data of the check unless that was done
+// already. It returns false while a Job fingerprinting a claim is running.
+func (v *FingerprintValidator) Capture(ctx context.Context, check *chaosdrv1.ValidationCheck) (bool, error) {
+	name := objectName("fingerprint", v.Test.Name, check.Name)
+	err := v.Reader.Get(ctx, client.ObjectKey{Namespace: v.Test.Namespace, Name: name}, &corev1.ConfigMap{})
+	if err == nil {
+		return true, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to get configmap %s: %w", name, err)
+	}
+
+	fp, err := v.fingerprint(ctx, check, v.Test.Namespace, false)
+	if err != nil || fp == nil {
+		return false, err
+	}
+	data, err := json.Marshal(fp)
+	if err != nil {
+		return false, err
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: v.Test.Namespace,
+			Labels:    map[string]string{testLabel: v.Test.Name, checkLabel: check.Name},
+		},
+		Data: map[string]string{fingerprintKey: string(data)},
+	}
+	if err := v.Client.Create(ctx, cm); err != nil && !apierrors.IsAlreadyExists(err) {
+		return false, fmt.Errorf("failed to store the fingerprint in configmap %s: %w", name, err)
+	}
+	return true, nil
+}
+
+// Validate fingerprints the restored data in the sandbox namespace and passes
+// when at most TolerancePercent of the source entities are missing or changed.
+func (v *FingerprintValidator) Validate(ctx context.Context, check *chaosdrv1.ValidationCheck) (*Result, error) {
+	spec := check.Fingerprint
+	res := &Result{Expected: fmt.Sprintf("at most %d%% of entities missing or changed", spec.TolerancePercent)}
+	namespace := v.Test.Status.SandboxNamespace
+	if namespace == "" {
+		res.Message = "the test has no sandbox namespace to fingerprint"
+		return res, nil
+	}
+
+	name := objectName("fingerprint", v.Test.Name, check.Name)
+	cm := &corev1.ConfigMap{}
+	err := v.Reader.Get(ctx, client.ObjectKey{Namespace: v.Test.Namespace, Name: name}, cm)
+	if apierrors.IsNotFound(err) {
+		res.Message = "no fingerprint of the source data was captured before the backup"
+		return res, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configmap %s: %w", name, err)
+	}
+	var source fingerprint
+	if err := json.Unmarshal([]byte(cm.Data[fingerprintKey]), &source); err != nil {
+		res.Message = fmt.Sprintf("invalid fingerprint in configmap %s: %v", name, err)
+		return res, nil
+	}
+
+	restored, err := v.fingerprint(ctx, check, namespace, true)
+	if err != nil {
+		res.Message = err.Error()
+		return res, nil
+	}
+	if restored == nil {
+		return nil, nil
+	}
+
+	diff := diffFingerprints(source, restored)
+	res.Diff = diff
+	res.Observed = fmt.Sprintf("%d of %d entities match", diff.Matching, len(source))
+	res.Passed = int(diff.Missing+diff.Changed)*100 <= int(spec.TolerancePercent)*len(source)
+	if diff.Missing+diff.Changed > 0 {
+		res.Message = fmt.Sprintf("%d missing, %d changed", diff.Missing, diff.Changed)
+	}
+	return res, nil
+}
+
+// fingerprint computes the fingerprint of the data check selects in
+// namespace, connecting through the sandbox services if sandbox is set. It
+// returns nil while a Job fingerprinting a claim is running.
+func (v *FingerprintValidator) fingerprint(ctx context.Context, check *chaosdrv1.ValidationCheck, namespace string, sandbox bool) (fingerprint, error) {
+	spec := check.Fingerprint
+	ctx, cancel := context.WithTimeout(ctx, fingerprintTimeout)
+	defer cancel()
+	switch {
+	case spec.SQL != nil:
+		engine := spec.SQL.Engine
+		if engine == "" {
+			engine = chaosdrv1.DatabaseEngineMySQL
+		}
+		dsn, err := v.connection(ctx, engine, &spec.SQL.ConnectionSecretRef, sandbox)
+		if err != nil {
+			return nil, err
+		}
+		db, err := v.Open(engine, dsn)
+		if err != nil {
+			return nil, err
+		}
+		defer db.Close()
+		return sqlFingerprint(ctx, db, spec.SQL.Tables, sandbox)
+	case spec.Redis != nil:
+		uri, err := v.connection(ctx, "", &spec.Redis.ConnectionSecretRef, sandbox)
+		if err != nil {
+			return nil, err
+		}
+		opts, err := redis.ParseURL(uri)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redis URL: %w", err)
+		}
+		rdb := redis.NewClient(opts)
+		defer rdb.Close()
+		match, maxKeys := spec.Redis.Match, int(spec.Redis.MaxKeys)
+		if match == "" {
+			match = "*"
+		}
+		if maxKeys == 0 {
+			maxKeys = defaultRedisMaxKeys
+		}
+		return redisFingerprint(ctx, rdb, match, maxKeys)
+	case spec.PVC != nil:
+		return v.pvcFingerprint(ctx, check, namespace, !sandbox)
+	}
+	return nil, fmt.Errorf("no data to fingerprint")
+}
+
+// connection returns the connection string ref holds, pointed at the sandbox
+// if sandbox is set.
+func (v *FingerprintValidator) connection(ctx context.Context, engine string, ref *corev1.SecretKeySelector, sandbox bool) (string, error) {
+	if sandbox {
+		return sandboxConnection(ctx, v.Reader, v.Test, engine, ref)
+	}
+	conn, err := secretKey(ctx, v.Reader, v.Test.Namespace, ref.Name, ref.Key)
+	return string(conn), err
+}
+
+// sqlFingerprint fingerprints each table by its row count and the sum of
+// the hashes of its rows. A table that cannot be read is an error unless
+// skipFailed is set, in which case it is left out.
+func sqlFingerprint(ctx context.Context, db *sql.DB, tables []string, skipFailed bool) (fingerprint, error) {
+	if err := db.PingContext(ctx); err != nil {
+		return nil, err
+	}
+	fp := fingerprint{}
+	for _, table := range tables {
+		digest, err := tableDigest(ctx, db, table)
+		if err != nil {
+			if skipFailed {
+				continue
+			}
+			return nil, fmt.Errorf("failed to fingerprint table %s: %w", table, err)
+		}
+		fp[table] = digest
+	}
+	return fp, nil
+}
+
+// tableDigest reads every row of table, which validateFingerprintCheck
+// checked to be an identifier.
+func tableDigest(ctx context.Context, db *sql.DB, table string) (string, error) {
+	rows, err := db.QueryContext(ctx, "SELECT * FROM "+table)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+
+	values := make([]any, len(columns))
+	ptrs := make([]any, len(columns))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	var count, sum uint64
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return "", err
+		}
+		h := sha256.New()
+		for _, val := range values {
+			h.Write([]byte(formatValue(val)))
+			h.Write([]byte{0})
+		}
+		// Summing keeps the checksum independent of the row order
+		sum += binary.BigEndian.Uint64(h.Sum(nil))
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("rows=%d checksum=%016x", count, sum), nil
+}
+
+// redisFingerprint fingerprints each key matching match by its type and value.
+func redisFingerprint(ctx context.Context, rdb *redis.Client, match string, maxKeys int) (fingerprint, error) {
+	fp := fingerprint{}
+	iter := rdb.Scan(ctx, 0, match, 1000).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		if _, ok := fp[key]; ok {
+			// SCAN may return a key more than once
+			continue
+		}
+		if len(fp) == maxKeys {
+			return nil, fmt.Errorf("more than %d keys match %q", maxKeys, match)
+		}
+		d, err := redisKeyDigest(ctx, rdb, key)
+		if errors.Is(err, redis.Nil) {
+			// Expired since the scan
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to fingerprint key %s: %w", key, err)
+		}
+		fp[key] = d
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	return fp, nil
+}
+
+// redisKeyDigest hashes the type and value of key. Keys of types other than
+// string, list, set, sorted set and hash are hashed by their type only.
+func redisKeyDigest(ctx context.Context, rdb *redis.Client, key string) (string, error) {
+	typ, err := rdb.Type(ctx, key).Result()
+	if err != nil {
+		return "", err
+	}
+	var parts []string
+	switch typ {
+	case "none":
+		return "", redis.Nil
+	case "string":
+		val, err := rdb.Get(ctx, key).Result()
+		if err != nil {
+			return "", err
+		}
+		parts = []string{val}
+	case "list":
+		if parts, err = rdb.LRange(ctx, key, 0, -1).Result(); err != nil {
+			return "", err
+		}
+	case "set":
+		if parts, err = rdb.SMembers(ctx, key).Result(); err != nil {
+			return "", err
+		}
+		sort.Strings(parts)
+	case "zset":
+		members, err := rdb.ZRangeWithScores(ctx, key, 0, -1).Result()
+		if err != nil {
+			return "", err
+		}
+		for _, m := range members {
+			parts = append(parts, fmt.Sprintf("%v=%v", m.Member, m.Score))
+		}
+	case "hash":
+		fields, err := rdb.HGetAll(ctx, key).Result()
+		if err != nil {
+			return "", err
+		}
+		for field, val := range fields {
+			parts = append(parts, field+"="+val)
+		}
+		sort.Strings(parts)
+	}
+	return digest(append([]string{typ}, parts...)), nil
+}
+
+// digest returns a short hash of parts.
+func digest(parts []string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// pvcFingerprint runs a Job hashing the files of the claim in namespace and
+// reads the hashes from its log once it has finished. It returns nil while
+// the Job is running. With cleanup set the Job is deleted after it is read.
+func (v *FingerprintValidator) pvcFingerprint(ctx context.Context, check *chaosdrv1.ValidationCheck, namespace string, cleanup bool) (fingerprint, error) {
+	name := objectName("fingerprint", v.Test.Name, check.Name)
+	job := &batchv1.Job{}
+	err := v.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, job)
+	if apierrors.IsNotFound(err) {
+		nodeName, err := claimNode(ctx, v.Client, namespace, check.Fingerprint.PVC.ClaimName)
+		if err != nil {
+			return nil, err
+		}
+		job = newFingerprintJob(name, namespace, v.Test.Name, nodeName, check)
+		if err := v.Client.Create(ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("failed to create job %s: %w", name, err)
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job %s: %w", name, err)
+	}
+	if failed := jobCondition(job, batchv1.JobFailed); failed != nil {
+		return nil, fmt.Errorf("job %s failed: %s", name, failed.Message)
+	}
+	if jobCondition(job, batchv1.JobComplete) == nil {
+		return nil, nil
+	}
+
+	pod, err := jobPod(ctx, v.Client, job)
+	if err != nil {
+		return nil, err
+	}
+	if pod == nil {
+		return nil, fmt.Errorf("job %s has no pod to read the fingerprint from", name)
+	}
+	out, err := podLogs(ctx, v.Pods, pod, &corev1.PodLogOptions{Container: "fingerprint"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the log of pod %s: %w", pod.Name, err)
+	}
+	fp := parseFileHashes(out)
+	if cleanup {
+		if err := v.Client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return nil, fmt.Errorf("failed to delete job %s: %w", name, err)
+		}
+	}
+	return fp, nil
+}
+
+// parseFileHashes reads the output of sha256sum into a fingerprint keyed by
+// the path of each file.
+func parseFileHashes(out []byte) fingerprint {
+	fp := fingerprint{}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		sum, file, ok := strings.Cut(scanner.Text(), "  ")
+		if !ok || len(sum) != sha256.Size*2 {
+			continue
+		}
+		fp[strings.TrimPrefix(file, "./")] = sum[:16]
+	}
+	return fp
+}
+
+// claimNode returns the node of a running pod in namespace mounting claim,
+// or "" when no pod mounts it.
+func claimNode(ctx context.Context, cl client.Client, namespace, claim string) (string, error) {
+	pods := &corev1.PodList{}
+	if err := cl.List(ctx, pods, client.InNamespace(namespace)); err != nil {
+		return "", fmt.Errorf("failed to list pods: %w", err)
+	}
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for _, vol := range pod.Spec.Volumes {
+			if vol.PersistentVolumeClaim != nil && vol.PersistentVolumeClaim.ClaimName == claim {
+				return pod.Spec.NodeName, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// newFingerprintJob builds the Job hashing every file of the claim of check,
+// pinned to nodeName when a pod already mounts the claim there.
+func newFingerprintJob(name, namespace, test, nodeName string, check *chaosdrv1.ValidationCheck) *batchv1.Job {
+	spec := check.Fingerprint.PVC
+	image := spec.Image
+	if image == "" {
+		image = DefaultScriptImage
+	}
+	backoffLimit := int32(0)
+	deadline := int64(fingerprintJobTimeout.Seconds())
+	automount := false
+	labels := map[string]string{testLabel: test, checkLabel: check.Name}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          &backoffLimit,
+			ActiveDeadlineSeconds: &deadline,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy:                corev1.RestartPolicyNever,
+					AutomountServiceAccountToken: &automount,
+					Containers: []corev1.Container{{
+						Name:    "fingerprint",
+						Image:   image,
+						Command: []string{"/bin/sh", "-c", `cd "$FINGERPRINT_DIR" && find . -type f -exec sha256sum {} +`},
+						Env: []corev1.EnvVar{{
+							Name:  "FINGERPRINT_DIR",
+							Value: path.Join(pvcMountPath, path.Clean("/"+spec.Path)),
+						}},
+						VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: pvcMountPath, ReadOnly: true}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "data",
+						VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: spec.ClaimName,
+							ReadOnly:  true,
+						}},
+					}},
+				},
+			},
+		},
+	}
+	if nodeName != "" {
+		// A ReadWriteOnce claim can only be mounted on the node already using it
+		job.Spec.Template.Spec.Affinity = backup.NodeAffinity(nodeName)
+	}
+	return job
+}
+
+// diffFingerprints compares the restored fingerprint with the source one.
+func diffFingerprints(source, restored fingerprint) *chaosdrv1.FingerprintDiff {
+	entities := make([]string, 0, len(source))
+	for entity := range source {
+		entities = append(entities, entity)
+	}
+	sort.Strings(entities)
+
+	diff := &chaosdrv1.FingerprintDiff{}
+	for _, entity := range entities {
+		got, ok := restored[entity]
+		switch {
+		case !ok:
+			diff.Missing++
+			if len(diff.MissingEntities) < maxDiffEntities {
+				diff.MissingEntities = append(diff.MissingEntities, entity)
+			}
+		case got != source[entity]:
+			diff.Changed++
+			if len(diff.ChangedEntities) < maxDiffEntities {
+				diff.ChangedEntities = append(diff.ChangedEntities, entity)
+			}
+		default:
+			diff.Matching++
+		}
+	}
+	for entity := range restored {
+		if _, ok := source[entity]; !ok {
+			diff.Added++
+		}
+	}
+	return diff
+}
+
+// validateFingerprintCheck reports an error when spec does not select
+// exactly one dataset or names a table that is not an identifier.
+func validateFingerprintCheck(spec *chaosdrv1.FingerprintCheck) error {
+	n := 0
+	for _, set := range []bool{spec.SQL != nil, spec.Redis != nil, spec.PVC != nil} {
+		if set {
+			n++
+		}
+	}
+	if n != 1 {
+		return fmt.Errorf("exactly one of sql, redis and pvc is required")
+	}
+	if spec.SQL != nil {
+		for _, table := range spec.SQL.Tables {
+			if !tablePattern.MatchString(table) {
+				return fmt.Errorf("invalid table name %q", table)
+			}
+		}
+	}
+	return nil
+}
+
+// DeleteFingerprints removes the source fingerprints of cr, listed through
+// reader, and any Job left fingerprinting its claims in the test's namespace.
+func DeleteFingerprints(ctx context.Context, cl client.Client, reader client.Reader, cr *chaosdrv1.ChaosDRTest) error {
+	selector := client.MatchingLabels{testLabel: cr.Name}
+	cms := &corev1.ConfigMapList{}
+	if err := reader.List(ctx, cms, client.InNamespace(cr.Namespace), selector); err != nil {
+		return fmt.Errorf("failed to list fingerprints: %w", err)
+	}
+	for i := range cms.Items {
+		if err := client.IgnoreNotFound(cl.Delete(ctx, &cms.Items[i])); err != nil {
+			return fmt.Errorf("failed to delete configmap %s: %w", cms.Items[i].Name, err)
+		}
+	}
+	err := cl.DeleteAllOf(ctx, &batchv1.Job{}, client.InNamespace(cr.Namespace), selector,
+		client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil {
+		return fmt.Errorf("failed to delete fingerprint jobs: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package validation
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+func newFingerprintValidator(conn string) *FingerprintValidator {
+	cr := newTestCR(chaosdrv1.ValidationConfig{})
+	cr.Status.SandboxNamespace = "sandbox"
+	cl := newConnectionClient(conn)
+	return &FingerprintValidator{Client: cl, Reader: cl, Pods: kubefake.NewClientset().CoreV1(), Test: cr, Open: openSQL}
+}
+
+func TestFingerprintValidatorRedis(t *testing.T) {
+	mr := miniredis.RunT(t)
+	mr.Set("user:1", "alice")
+	mr.Set("user:2", "bob")
+	mr.Set("user:3", "carol")
+	mr.HSet("user:4", "name", "dave", "plan", "pro")
+	mr.Set("cache:1", "ignored")
+	v := newFingerprintValidator("redis://" + mr.Addr())
+	check := &chaosdrv1.ValidationCheck{Name: "users", Type: "fingerprint", Fingerprint: &chaosdrv1.FingerprintCheck{
+		Redis: &chaosdrv1.RedisFingerprint{ConnectionSecretRef: connectionRef(), Match: "user:*"},
+	}}
+
+	if captured, err := v.Capture(context.Background(), check); err != nil || !captured {
+		t.Fatalf("Expected the source to be captured, got %v, %v", captured, err)
+	}
+	cm := &corev1.ConfigMap{}
+	if err := v.Client.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "fingerprint-redis-dr-test-users"}, cm); err != nil {
+		t.Fatalf("Expected the fingerprint to be stored: %v", err)
+	}
+
+	// The restore lost a key, changed another and has a new one
+	mr.Del("user:1")
+	mr.Set("user:2", "bobby")
+	mr.Set("user:5", "erin")
+	res, err := v.Validate(context.Background(), check)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	want := chaosdrv1.FingerprintDiff{Matching: 2, Missing: 1, Changed: 1, Added: 1, MissingEntities: []string{"user:1"}, ChangedEntities: []string{"user:2"}}
+	if res.Passed || res.Diff == nil || res.Diff.Matching != want.Matching || res.Diff.Missing != want.Missing ||
+		res.Diff.Changed != want.Changed || res.Diff.Added != want.Added ||
+		res.Diff.MissingEntities[0] != "user:1" || res.Diff.ChangedEntities[0] != "user:2" {
+		t.Errorf("Expected a failing check with diff %+v, got %+v %+v", want, res, res.Diff)
+	}
+	if res.Observed != "2 of 4 entities match" {
+		t.Errorf("Expected 2 of 4 entities to match, got %q", res.Observed)
+	}
+
+	check.Fingerprint.TolerancePercent = 50
+	if res, _ := v.Validate(context.Background(), check); !res.Passed {
+		t.Errorf("Expected the check to pass within its tolerance, got %+v", res)
+	}
+}
+
+func TestFingerprintValidatorSQL(t *testing.T) {
+	source, sourceMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	sourceMock.ExpectQuery("SELECT * FROM users").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "alice").AddRow(2, "bob"))
+	sourceMock.ExpectQuery("SELECT * FROM shop.orders").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	// The restore has the same users in another order and lost the orders table
+	restored, restoredMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	restoredMock.ExpectQuery("SELECT * FROM users").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(2, "bob").AddRow(1, "alice"))
+	restoredMock.ExpectQuery("SELECT * FROM shop.orders").WillReturnError(sql.ErrNoRows)
+
+	v := newFingerprintValidator("app:secret@tcp(mysql:3306)/shop")
+	dbs := map[string]*sql.DB{
+		"app:secret@tcp(mysql:3306)/shop":             source,
+		"app:secret@tcp(mysql.sandbox.svc:3306)/shop": restored,
+	}
+	v.Open = func(_, dsn string) (*sql.DB, error) {
+		if db, ok := dbs[dsn]; ok {
+			return db, nil
+		}
+		t.Fatalf("Unexpected connection to %s", dsn)
+		return nil, nil
+	}
+	check := &chaosdrv1.ValidationCheck{Name: "tables", Type: "fingerprint", Fingerprint: &chaosdrv1.FingerprintCheck{
+		SQL: &chaosdrv1.SQLFingerprint{ConnectionSecretRef: connectionRef(), Tables: []string{"users", "shop.orders"}},
+	}}
+
+	if captured, err := v.Capture(context.Background(), check); err != nil || !captured {
+		t.Fatalf("Expected the source to be captured, got %v, %v", captured, err)
+	}
+	res, err := v.Validate(context.Background(), check)
+	if err != nil || res.Diff == nil {
+		t.Fatalf("Expected a diff, got %+v, %v", res, err)
+	}
+	if res.Passed || res.Diff.Matching != 1 || res.Diff.Missing != 1 || res.Diff.MissingEntities[0] != "shop.orders" {
+		t.Errorf("Expected users to match and orders to be missing, got %+v %+v", res, res.Diff)
+	}
+}
+
+func TestFingerprintValidatorPVC(t *testing.T) {
+	v := newFingerprintValidator("")
+	redis := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "redis-0", Namespace: "default"},
+		Spec: corev1.PodSpec{NodeName: "node-b", Volumes: []corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "redis-data"},
+		}}}},
+	}
+	if err := v.Client.Create(context.Background(), redis); err != nil {
+		t.Fatalf("Failed to create pod: %v", err)
+	}
+	check := &chaosdrv1.ValidationCheck{Name: "files", Type: "fingerprint", Fingerprint: &chaosdrv1.FingerprintCheck{
+		PVC: &chaosdrv1.PVCFingerprint{ClaimName: "redis-data", Path: "appendonly"},
+	}}
+
+	if captured, err := v.Capture(context.Background(), check); err != nil || captured {
+		t.Fatalf("Expected the capture to run a job, got %v, %v", captured, err)
+	}
+	job := &batchv1.Job{}
+	key := client.ObjectKey{Namespace: "default", Name: "fingerprint-redis-dr-test-files"}
+	if err := v.Client.Get(context.Background(), key, job); err != nil {
+		t.Fatalf("Expected a fingerprint job in the test's namespace: %v", err)
+	}
+	podSpec := job.Spec.Template.Spec
+	if claim := podSpec.Volumes[0].PersistentVolumeClaim; claim == nil || claim.ClaimName != "redis-data" || !claim.ReadOnly {
+		t.Errorf("Expected the claim to be mounted read-only, got %+v", podSpec.Volumes)
+	}
+	if dir := podSpec.Containers[0].Env[0].Value; dir != "/data/appendonly" {
+		t.Errorf("Expected the job to hash /data/appendonly, got %s", dir)
+	}
+	if aff := podSpec.Affinity; aff == nil || aff.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields[0].Values[0] != "node-b" {
+		t.Errorf("Expected the job to run on node-b, which mounts the claim, got %+v", aff)
+	}
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	if err := v.Client.Status().Update(context.Background(), job); err != nil {
+		t.Fatalf("Failed to update job: %v", err)
+	}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: key.Name + "-abcde", Namespace: "default", Labels: map[string]string{batchv1.JobNameLabel: key.Name}}}
+	if err := v.Client.Create(context.Background(), pod); err != nil {
+		t.Fatalf("Failed to create pod: %v", err)
+	}
+	if captured, err := v.Capture(context.Background(), check); err != nil || !captured {
+		t.Fatalf("Expected the capture to finish with the job, got %v, %v", captured, err)
+	}
+	if err := v.Client.Get(context.Background(), key, &batchv1.Job{}); err == nil {
+		t.Error("Expected the job in the test's namespace to be deleted")
+	}
+}
+
+func TestParseFileHashes(t *testing.T) {
+	sum := strings.Repeat("ab", 32)
+	out := sum + "  ./appendonly.aof\n" + sum + "  ./dir/dump.rdb\nsha256sum: ./locked: Permission denied\n"
+	fp := parseFileHashes([]byte(out))
+	if len(fp) != 2 || fp["appendonly.aof"] != sum[:16] || fp["dir/dump.rdb"] != sum[:16] {
+		t.Errorf("Expected the hashes of two files, got %v", fp)
+	}
+}
+
+func TestDeleteFingerprints(t *testing.T) {
+	v := newFingerprintValidator("")
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "fingerprint-redis-dr-test-files", Namespace: "default", Labels: map[string]string{testLabel: "redis-dr-test"}}}
+	if err := v.Client.Create(context.Background(), cm); err != nil {
+		t.Fatalf("Failed to create configmap: %v", err)
+	}
+	if err := DeleteFingerprints(context.Background(), v.Client, v.Reader, v.Test); err != nil {
+		t.Fatalf("DeleteFingerprints failed: %v", err)
+	}
+	if err := v.Client.Get(context.Background(), client.ObjectKeyFromObject(cm), &corev1.ConfigMap{}); err == nil {
+		t.Error("Expected the fingerprint to be deleted")
+	}
+}
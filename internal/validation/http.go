@@ -0,0 +1,245 @@
+package validation
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+const (
+	// DefaultHTTPTimeout bounds a single request of an http check that sets no timeout
+	DefaultHTTPTimeout = 10 * time.Second
+	// maxBody caps the response body read for assertions
+	maxBody = 1 << 20
+)
+
+// HTTPValidator runs http checks.
+type HTTPValidator struct {
+	// Reader reads the TLS Secrets of a check. It must not be the manager's
+	// cached client: the operator may get Secrets but not list or watch them.
+	Reader client.Reader
+	// HTTPClient sends the requests of checks without TLS settings
+	HTTPClient *http.Client
+	// Test is the ChaosDRTest the checks belong to
+	Test *chaosdrv1.ChaosDRTest
+}
+
+// NewHTTPFactory returns a Factory for HTTPValidator.
+func NewHTTPFactory(reader client.Reader) Factory {
+	httpClient := &http.Client{}
+	return func(cr *chaosdrv1.ChaosDRTest) (Validator, error) {
+		return &HTTPValidator{Reader: reader, HTTPClient: httpClient, Test: cr}, nil
+	}
+}
+
+// Validate sends the request of the check and passes when the response has
+// the expected status code, satisfies every assertion and arrived in time. A
+// failing check with eventually set is reported running until its deadline.
+func (v *HTTPValidator) Validate(ctx context.Context, check *chaosdrv1.ValidationCheck) (*Result, error) {
+	spec := check.HTTP
+	res := v.request(ctx, spec)
+	if !res.Passed && spec.Eventually != nil {
+		if deadline := startTime(v.Test, check.Name).Add(spec.Eventually.Duration); time.Now().Before(deadline) {
+			log.FromContext(ctx).Info("HTTP check failed, retrying", "check", check.Name, "reason", res.Message, "deadline", deadline)
+			return nil, nil
+		}
+	}
+	return res, nil
+}
+
+// request sends one request of spec and evaluates the response.
+func (v *HTTPValidator) request(ctx context.Context, spec *chaosdrv1.HTTPCheck) *Result {
+	expected := spec.ExpectedStatusCode
+	if expected == 0 {
+		expected = http.StatusOK
+	}
+	res := &Result{Expected: fmt.Sprintf("status code %d", expected)}
+	if spec.MaxLatency != nil {
+		res.Expected += fmt.Sprintf(", latency <= %s", spec.MaxLatency.Duration)
+	}
+
+	httpClient := v.HTTPClient
+	if spec.TLS != nil {
+		cfg, err := v.tlsConfig(ctx, spec.TLS)
+		if err != nil {
+			res.Message = err.Error()
+			return res
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = cfg
+		httpClient = &http.Client{Transport: transport}
+	}
+
+	timeout := DefaultHTTPTimeout
+	if spec.Timeout != nil {
+		timeout = spec.Timeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	method := spec.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	var body io.Reader
+	if spec.Body != "" {
+		body = strings.NewReader(spec.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, spec.URL, body)
+	if err != nil {
+		res.Message = err.Error()
+		return res
+	}
+	for k, val := range spec.Headers {
+		req.Header.Set(k, val)
+	}
+
+	start := time.Now()
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		res.Message = err.Error()
+		return res
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxBody))
+	if err != nil {
+		res.Message = fmt.Sprintf("failed to read response body: %v", err)
+		return res
+	}
+	latency := time.Since(start).Round(time.Millisecond)
+
+	res.Observed = fmt.Sprintf("status code %d", resp.StatusCode)
+	if spec.MaxLatency != nil {
+		res.Observed += fmt.Sprintf(", latency %s", latency)
+	}
+	switch {
+	case resp.StatusCode != expected:
+		res.Message = fmt.Sprintf("unexpected status code: got %d, expected %d", resp.StatusCode, expected)
+	case spec.MaxLatency != nil && latency > spec.MaxLatency.Duration:
+		res.Message = fmt.Sprintf("response took %s, more than %s", latency, spec.MaxLatency.Duration)
+	default:
+		for _, a := range spec.Assertions {
+			if err := assertBody(respBody, a); err != nil {
+				res.Message = err.Error()
+				return res
+			}
+		}
+		res.Passed = true
+	}
+	return res
+}
+
+// tlsConfig builds the TLS configuration of an http check from its Secrets.
+func (v *HTTPValidator) tlsConfig(ctx context.Context, spec *chaosdrv1.HTTPTLSConfig) (*tls.Config, error) {
+	cfg := &tls.Config{ServerName: spec.ServerName, InsecureSkipVerify: spec.InsecureSkipVerify}
+	if ref := spec.CASecretRef; ref != nil {
+		ca, err := secretKey(ctx, v.Reader, v.Test.Namespace, ref.Name, ref.Key)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("secret %s key %s holds no PEM certificate", ref.Name, ref.Key)
+		}
+	}
+	if ref := spec.ClientCertSecretRef; ref != nil {
+		cert, err := secretKey(ctx, v.Reader, v.Test.Namespace, ref.Name, corev1.TLSCertKey)
+		if err != nil {
+			return nil, err
+		}
+		key, err := secretKey(ctx, v.Reader, v.Test.Namespace, ref.Name, corev1.TLSPrivateKeyKey)
+		if err != nil {
+			return nil, err
+		}
+		pair, err := tls.X509KeyPair(cert, key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate in secret %s: %w", ref.Name, err)
+		}
+		cfg.Certificates = []tls.Certificate{pair}
+	}
+	return cfg, nil
+}
+
+// assertBody reports an error when body does not satisfy a.
+func assertBody(body []byte, a chaosdrv1.BodyAssertion) error {
+	value := string(body)
+	if a.JSONPath != "" {
+		var err error
+		if value, err = selectJSONPath(body, a.JSONPath); err != nil {
+			return fmt.Errorf("assertion %s: %w", a.JSONPath, err)
+		}
+		if a.Equals != "" && value != a.Equals {
+			return fmt.Errorf("assertion %s: got %q, expected %q", a.JSONPath, value, a.Equals)
+		}
+	}
+	if a.Regex != "" {
+		re, err := regexp.Compile(a.Regex)
+		if err != nil {
+			return fmt.Errorf("invalid regex %q: %w", a.Regex, err)
+		}
+		if !re.MatchString(value) {
+			if a.JSONPath != "" {
+				return fmt.Errorf("assertion %s: %q does not match %q", a.JSONPath, value, a.Regex)
+			}
+			return fmt.Errorf("response body does not match %q", a.Regex)
+		}
+	}
+	return nil
+}
+
+// validateHTTPCheck reports an error when an assertion of spec has an
+// invalid JSONPath or regex.
+func validateHTTPCheck(spec *chaosdrv1.HTTPCheck) error {
+	for _, a := range spec.Assertions {
+		if a.JSONPath != "" {
+			if err := jsonpath.New("assertion").Parse(bracePath(a.JSONPath)); err != nil {
+				return fmt.Errorf("invalid JSONPath %q: %w", a.JSONPath, err)
+			}
+		}
+		if _, err := regexp.Compile(a.Regex); err != nil {
+			return fmt.Errorf("invalid regex %q: %w", a.Regex, err)
+		}
+	}
+	return nil
+}
+
+// selectJSONPath returns the value path selects from the JSON body.
+func selectJSONPath(body []byte, path string) (string, error) {
+	var data any
+	if err := json.Unmarshal(body, &data); err != nil {
+		return "", fmt.Errorf("response body is not JSON: %w", err)
+	}
+	jp := jsonpath.New("assertion")
+	if err := jp.Parse(bracePath(path)); err != nil {
+		return "", fmt.Errorf("invalid JSONPath: %w", err)
+	}
+	var out bytes.Buffer
+	if err := jp.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// bracePath wraps path in the braces of the kubectl JSONPath syntax, which
+// checks may leave out.
+func bracePath(path string) string {
+	if strings.HasPrefix(path, "{") {
+		return path
+	}
+	return "{" + path + "}"
+}
@@ -0,0 +1,159 @@
+package validation
+
+import (
+	"context"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+func newHTTPServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/healthz":
+		case "/status":
+			_, _ = io.WriteString(w, `{"status":"up","replicas":3}`)
+		case "/echo":
+			if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = io.Copy(w, r.Body)
+		case "/slow":
+			time.Sleep(50 * time.Millisecond)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestHTTPValidator(t *testing.T) {
+	srv := newHTTPServer(t)
+	tests := []struct {
+		name     string
+		spec     chaosdrv1.HTTPCheck
+		passed   bool
+		observed string
+		expected string
+	}{
+		{"default status", chaosdrv1.HTTPCheck{URL: srv.URL + "/healthz"}, true, "status code 200", "status code 200"},
+		{"unexpected status", chaosdrv1.HTTPCheck{URL: srv.URL + "/missing"}, false, "status code 404", "status code 200"},
+		{"expected status", chaosdrv1.HTTPCheck{URL: srv.URL + "/missing", ExpectedStatusCode: 404}, true, "status code 404", "status code 404"},
+		{"unreachable", chaosdrv1.HTTPCheck{URL: "http://127.0.0.1:1/healthz"}, false, "", "status code 200"},
+		{"method, headers and body", chaosdrv1.HTTPCheck{
+			URL:        srv.URL + "/echo",
+			Method:     http.MethodPost,
+			Headers:    map[string]string{"Authorization": "Bearer token"},
+			Body:       "restored",
+			Assertions: []chaosdrv1.BodyAssertion{{Regex: "^restored$"}},
+		}, true, "status code 200", "status code 200"},
+		{"jsonpath equals", chaosdrv1.HTTPCheck{
+			URL:        srv.URL + "/status",
+			Assertions: []chaosdrv1.BodyAssertion{{JSONPath: "{.status}", Equals: "up"}, {JSONPath: ".replicas", Regex: "^[1-9]"}},
+		}, true, "status code 200", "status code 200"},
+		{"jsonpath mismatch", chaosdrv1.HTTPCheck{
+			URL:        srv.URL + "/status",
+			Assertions: []chaosdrv1.BodyAssertion{{JSONPath: "{.status}", Equals: "down"}},
+		}, false, "status code 200", "status code 200"},
+		{"jsonpath missing", chaosdrv1.HTTPCheck{
+			URL:        srv.URL + "/status",
+			Assertions: []chaosdrv1.BodyAssertion{{JSONPath: "{.leader}"}},
+		}, false, "status code 200", "status code 200"},
+		{"too slow", chaosdrv1.HTTPCheck{
+			URL:        srv.URL + "/slow",
+			MaxLatency: &metav1.Duration{Duration: time.Millisecond},
+		}, false, "", "status code 200, latency <= 1ms"},
+		{"timeout", chaosdrv1.HTTPCheck{
+			URL:     srv.URL + "/slow",
+			Timeout: &metav1.Duration{Duration: time.Millisecond},
+		}, false, "", "status code 200"},
+	}
+	v := &HTTPValidator{HTTPClient: srv.Client(), Test: newTestCR(chaosdrv1.ValidationConfig{})}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := v.Validate(context.Background(), &chaosdrv1.ValidationCheck{Name: "api", Type: "http", HTTP: &tt.spec})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if res.Passed != tt.passed || res.Expected != tt.expected {
+				t.Errorf("Expected passed=%v expected=%q, got %+v", tt.passed, tt.expected, res)
+			}
+			if tt.observed != "" && res.Observed != tt.observed {
+				t.Errorf("Expected observed=%q, got %+v", tt.observed, res)
+			}
+			if !res.Passed && res.Message == "" {
+				t.Error("Expected a message on a failing check")
+			}
+		})
+	}
+}
+
+func TestHTTPValidatorEventually(t *testing.T) {
+	srv := newHTTPServer(t)
+	cr := newTestCR(chaosdrv1.ValidationConfig{})
+	started := metav1.Now()
+	cr.Status.Validation = []chaosdrv1.ValidationResult{{Name: "api", Type: "http", StartTime: &started}}
+	v := &HTTPValidator{HTTPClient: srv.Client(), Test: cr}
+	check := &chaosdrv1.ValidationCheck{Name: "api", Type: "http", HTTP: &chaosdrv1.HTTPCheck{
+		URL:        srv.URL + "/missing",
+		Eventually: &metav1.Duration{Duration: time.Minute},
+	}}
+
+	if res, err := v.Validate(context.Background(), check); err != nil || res != nil {
+		t.Fatalf("Expected a failing check to be retried before its deadline, got %+v, %v", res, err)
+	}
+
+	started = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	res, err := v.Validate(context.Background(), check)
+	if err != nil || res == nil || res.Passed || res.Observed != "status code 404" {
+		t.Errorf("Expected the check to fail after its deadline, got %+v, %v", res, err)
+	}
+}
+
+func TestHTTPValidatorTLS(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer srv.Close()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-ca", Namespace: "default"},
+		Data:       map[string][]byte{"ca.crt": ca},
+	}).Build()
+	v := &HTTPValidator{Reader: cl, HTTPClient: &http.Client{}, Test: newTestCR(chaosdrv1.ValidationConfig{})}
+
+	check := &chaosdrv1.ValidationCheck{Name: "api", Type: "http", HTTP: &chaosdrv1.HTTPCheck{URL: srv.URL}}
+	if res, _ := v.Validate(context.Background(), check); res.Passed {
+		t.Fatal("Expected the unknown server certificate to be rejected")
+	}
+
+	check.HTTP.TLS = &chaosdrv1.HTTPTLSConfig{CASecretRef: &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "api-ca"},
+		Key:                  "ca.crt",
+	}}
+	res, err := v.Validate(context.Background(), check)
+	if err != nil || !res.Passed {
+		t.Errorf("Expected the server to be trusted through the CA secret, got %+v, %v", res, err)
+	}
+
+	check.HTTP.TLS.ClientCertSecretRef = &corev1.LocalObjectReference{Name: "missing"}
+	if res, _ := v.Validate(context.Background(), check); res.Passed || !strings.Contains(res.Message, "missing") {
+		t.Errorf("Expected a missing client certificate secret to fail the check, got %+v", res)
+	}
+}
@@ -0,0 +1,133 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+// MongoDBValidator runs mongodb checks.
+type MongoDBValidator struct {
+	// Reader reads the connection Secrets of a check. It must not be the
+	// manager's cached client: the operator may get Secrets but not list or
+	// watch them.
+	Reader client.Reader
+	// Test is the ChaosDRTest the checks belong to
+	Test *chaosdrv1.ChaosDRTest
+	// Connect opens a connection to the MongoDB at uri; it defaults to connectMongoDB
+	Connect func(uri string) (MongoDBConn, error)
+}
+
+// MongoDBConn is the part of a MongoDB connection mongodb checks use.
+type MongoDBConn interface {
+	// Ping fails while the server cannot be reached
+	Ping(ctx context.Context) error
+	// CountDocuments counts the documents of database.collection matching filter
+	CountDocuments(ctx context.Context, database, collection string, filter any) (int64, error)
+	Close() error
+}
+
+// NewMongoDBFactory returns a Factory for MongoDBValidator.
+func NewMongoDBFactory(reader client.Reader) Factory {
+	return func(cr *chaosdrv1.ChaosDRTest) (Validator, error) {
+		return &MongoDBValidator{Reader: reader, Test: cr, Connect: connectMongoDB}, nil
+	}
+}
+
+// mongoDBConn is a MongoDBConn backed by the MongoDB driver
+type mongoDBConn struct {
+	client *mongo.Client
+}
+
+// connectMongoDB returns a MongoDBConn to uri. The driver connects lazily,
+// so an unreachable server is reported by Ping.
+func connectMongoDB(uri string) (MongoDBConn, error) {
+	mc, err := mongo.Connect(options.Client().ApplyURI(uri).SetServerSelectionTimeout(databaseTimeout))
+	if err != nil {
+		return nil, err
+	}
+	return &mongoDBConn{client: mc}, nil
+}
+
+func (c *mongoDBConn) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx, nil)
+}
+
+func (c *mongoDBConn) CountDocuments(ctx context.Context, database, collection string, filter any) (int64, error) {
+	return c.client.Database(database).Collection(collection).CountDocuments(ctx, filter)
+}
+
+func (c *mongoDBConn) Close() error {
+	return c.client.Disconnect(context.Background())
+}
+
+// Validate counts the documents matching the filter in the MongoDB of the
+// sandbox and passes when the count is the expected one, or at least one.
+func (v *MongoDBValidator) Validate(ctx context.Context, check *chaosdrv1.ValidationCheck) (*Result, error) {
+	spec := check.MongoDB
+	res := &Result{Expected: "at least 1 document"}
+	if spec.ExpectedCount != nil {
+		res.Expected = fmt.Sprintf("%d documents", *spec.ExpectedCount)
+	}
+
+	filter, err := mongoFilter(spec.Filter)
+	if err != nil {
+		res.Message = err.Error()
+		return res, nil
+	}
+	uri, err := sandboxConnection(ctx, v.Reader, v.Test, "", &spec.ConnectionSecretRef)
+	if err != nil {
+		res.Message = err.Error()
+		return res, nil
+	}
+	conn, err := v.Connect(uri)
+	if err != nil {
+		res.Message = err.Error()
+		return res, nil
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(ctx, databaseTimeout)
+	defer cancel()
+	if err := conn.Ping(ctx); err != nil {
+		if retryConnect(ctx, v.Test, check, err) {
+			return nil, nil
+		}
+		res.Message = err.Error()
+		return res, nil
+	}
+
+	count, err := conn.CountDocuments(ctx, spec.Database, spec.Collection, filter)
+	if err != nil {
+		res.Message = err.Error()
+		return res, nil
+	}
+	res.Observed = fmt.Sprintf("%d documents", count)
+	switch {
+	case spec.ExpectedCount != nil:
+		res.Passed = count == *spec.ExpectedCount
+	default:
+		res.Passed = count > 0
+	}
+	if !res.Passed {
+		res.Message = fmt.Sprintf("unexpected document count in %s.%s: got %d, expected %s", spec.Database, spec.Collection, count, res.Expected)
+	}
+	return res, nil
+}
+
+// mongoFilter parses an extended JSON find filter; an empty one matches every document.
+func mongoFilter(filter string) (bson.D, error) {
+	doc := bson.D{}
+	if filter == "" {
+		return doc, nil
+	}
+	if err := bson.UnmarshalExtJSON([]byte(filter), false, &doc); err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+	return doc, nil
+}
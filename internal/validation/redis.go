@@ -0,0 +1,121 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+// RedisValidator runs redis checks.
+type RedisValidator struct {
+	// Reader reads the connection Secrets of a check. It must not be the
+	// manager's cached client: the operator may get Secrets but not list or
+	// watch them.
+	Reader client.Reader
+	// Test is the ChaosDRTest the checks belong to
+	Test *chaosdrv1.ChaosDRTest
+}
+
+// NewRedisFactory returns a Factory for RedisValidator.
+func NewRedisFactory(reader client.Reader) Factory {
+	return func(cr *chaosdrv1.ChaosDRTest) (Validator, error) {
+		return &RedisValidator{Reader: reader, Test: cr}, nil
+	}
+}
+
+// Validate connects to the Redis of the sandbox and passes when the keys
+// exist, DBSIZE reaches MinDBSize and the sampled keys hold their values.
+func (v *RedisValidator) Validate(ctx context.Context, check *chaosdrv1.ValidationCheck) (*Result, error) {
+	spec := check.Redis
+	res := &Result{Expected: redisExpectation(spec)}
+
+	uri, err := sandboxConnection(ctx, v.Reader, v.Test, "", &spec.ConnectionSecretRef)
+	if err != nil {
+		res.Message = err.Error()
+		return res, nil
+	}
+	opts, err := redis.ParseURL(uri)
+	if err != nil {
+		res.Message = fmt.Sprintf("invalid redis URL: %v", err)
+		return res, nil
+	}
+	rdb := redis.NewClient(opts)
+	defer rdb.Close()
+	ctx, cancel := context.WithTimeout(ctx, databaseTimeout)
+	defer cancel()
+	if err := rdb.Ping(ctx).Err(); err != nil {
+		if retryConnect(ctx, v.Test, check, err) {
+			return nil, nil
+		}
+		res.Message = err.Error()
+		return res, nil
+	}
+
+	size, err := rdb.DBSize(ctx).Result()
+	if err != nil {
+		res.Message = err.Error()
+		return res, nil
+	}
+	res.Observed = fmt.Sprintf("DBSIZE %d", size)
+	if spec.MinDBSize != nil && size < *spec.MinDBSize {
+		res.Message = fmt.Sprintf("DBSIZE is %d, expected at least %d", size, *spec.MinDBSize)
+		return res, nil
+	}
+
+	for _, key := range spec.Keys {
+		n, err := rdb.Exists(ctx, key).Result()
+		if err != nil {
+			res.Message = err.Error()
+			return res, nil
+		}
+		if n == 0 {
+			res.Message = fmt.Sprintf("key %s does not exist", key)
+			return res, nil
+		}
+	}
+
+	keys := make([]string, 0, len(spec.Values))
+	for key := range spec.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		val, err := rdb.Get(ctx, key).Result()
+		if errors.Is(err, redis.Nil) {
+			res.Message = fmt.Sprintf("key %s does not exist", key)
+			return res, nil
+		}
+		if err != nil {
+			res.Message = fmt.Sprintf("key %s: %v", key, err)
+			return res, nil
+		}
+		if val != spec.Values[key] {
+			res.Message = fmt.Sprintf("key %s: got %q, expected %q", key, val, spec.Values[key])
+			return res, nil
+		}
+	}
+	res.Passed = true
+	return res, nil
+}
+
+// redisExpectation summarizes what a redis check expects.
+func redisExpectation(spec *chaosdrv1.RedisCheck) string {
+	var parts []string
+	if spec.MinDBSize != nil {
+		parts = append(parts, fmt.Sprintf("DBSIZE >= %d", *spec.MinDBSize))
+	}
+	if len(spec.Keys) > 0 {
+		parts = append(parts, fmt.Sprintf("%d keys exist", len(spec.Keys)))
+	}
+	if len(spec.Values) > 0 {
+		parts = append(parts, fmt.Sprintf("%d values match", len(spec.Values)))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,71 @@
+package validation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+func TestRedisValidator(t *testing.T) {
+	mr := miniredis.RunT(t)
+	mr.Set("session:1", "alice")
+	mr.Set("session:2", "bob")
+	cr := newTestCR(chaosdrv1.ValidationConfig{})
+	cr.Status.SandboxNamespace = "sandbox"
+	v := &RedisValidator{Reader: newConnectionClient("redis://" + mr.Addr() + "/0"), Test: cr}
+
+	two, three := int64(2), int64(3)
+	tests := []struct {
+		name   string
+		spec   chaosdrv1.RedisCheck
+		passed bool
+	}{
+		{"all hold", chaosdrv1.RedisCheck{Keys: []string{"session:1"}, MinDBSize: &two, Values: map[string]string{"session:2": "bob"}}, true},
+		{"too few keys", chaosdrv1.RedisCheck{MinDBSize: &three}, false},
+		{"missing key", chaosdrv1.RedisCheck{Keys: []string{"session:3"}}, false},
+		{"changed value", chaosdrv1.RedisCheck{Values: map[string]string{"session:1": "bob"}}, false},
+		{"missing value", chaosdrv1.RedisCheck{Values: map[string]string{"session:3": "carol"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.spec.ConnectionSecretRef = connectionRef()
+			res, err := v.Validate(context.Background(), &chaosdrv1.ValidationCheck{Name: "sessions", Type: "redis", Redis: &tt.spec})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if res.Passed != tt.passed || res.Observed != "DBSIZE 2" {
+				t.Errorf("Expected passed=%v with DBSIZE 2, got %+v", tt.passed, res)
+			}
+			if !res.Passed && res.Message == "" {
+				t.Error("Expected a message on a failing check")
+			}
+		})
+	}
+}
+
+func TestRedisValidatorRetriesConnection(t *testing.T) {
+	mr := miniredis.RunT(t)
+	addr := mr.Addr()
+	mr.Close()
+	cr := newTestCR(chaosdrv1.ValidationConfig{})
+	cr.Status.SandboxNamespace = "sandbox"
+	v := &RedisValidator{Reader: newConnectionClient("redis://" + addr + "/0"), Test: cr}
+	check := &chaosdrv1.ValidationCheck{Name: "sessions", Type: "redis", Redis: &chaosdrv1.RedisCheck{ConnectionSecretRef: connectionRef()}}
+
+	res, err := v.Validate(context.Background(), check)
+	if err != nil || res != nil {
+		t.Fatalf("Expected the check to keep running while redis is unreachable, got %+v, %v", res, err)
+	}
+
+	started := metav1.NewTime(time.Now().Add(-connectRetryTimeout - time.Second))
+	cr.Status.Validation = []chaosdrv1.ValidationResult{{Name: "sessions", Type: "redis", StartTime: &started}}
+	res, err = v.Validate(context.Background(), check)
+	if err != nil || res == nil || res.Passed || res.Message == "" {
+		t.Fatalf("Expected the check to fail after the retry deadline, got %+v, %v", res, err)
+	}
+}
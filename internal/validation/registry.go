@@ -0,0 +1,70 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+// Factory returns the Validator that runs one type of check for one ChaosDRTest
+type Factory func(cr *chaosdrv1.ChaosDRTest) (Validator, error)
+
+// Registry holds the available validators keyed by check type.
+type Registry struct {
+	factories map[string]Factory
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{factories: map[string]Factory{}}
+}
+
+// Register makes a validator available for checks of checkType, replacing any earlier one.
+func (r *Registry) Register(checkType string, factory Factory) {
+	r.factories[checkType] = factory
+}
+
+// Has reports whether a validator is registered for checkType.
+func (r *Registry) Has(checkType string) bool {
+	_, ok := r.factories[checkType]
+	return ok
+}
+
+// Names returns the registered check types in sorted order.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New builds the validator of checkType for cr.
+func (r *Registry) New(checkType string, cr *chaosdrv1.ChaosDRTest) (Validator, error) {
+	factory, ok := r.factories[checkType]
+	if !ok {
+		return nil, fmt.Errorf("unknown validation check type %q, available types: %s", checkType, strings.Join(r.Names(), ", "))
+	}
+	return factory(cr)
+}
+
+// NewDefaultRegistry returns a Registry with the built-in validators. Script
+// and fingerprint checks run Jobs created through cl, and pods reads their
+// logs; Secrets are read through reader, which must not be the manager's
+// cached client.
+func NewDefaultRegistry(cl client.Client, reader client.Reader, pods corev1client.PodsGetter) *Registry {
+	r := NewRegistry()
+	r.Register(chaosdrv1.ValidationTypeScript, NewScriptFactory(cl, pods))
+	r.Register(chaosdrv1.ValidationTypeHTTP, NewHTTPFactory(reader))
+	r.Register(chaosdrv1.ValidationTypeSQL, NewSQLFactory(reader))
+	r.Register(chaosdrv1.ValidationTypeRedis, NewRedisFactory(reader))
+	r.Register(chaosdrv1.ValidationTypeMongoDB, NewMongoDBFactory(reader))
+	r.Register(chaosdrv1.ValidationTypeFingerprint, NewFingerprintFactory(cl, reader, pods))
+	return r
+}
@@ -0,0 +1,361 @@
+package validation
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+const (
+	// defaultRPOTable holds the markers of an sql RPO probe that names no table
+	defaultRPOTable = "chaosdr_rpo_markers"
+	// defaultRPOJSONPath selects the marker from the read URL of an http RPO probe
+	defaultRPOJSONPath = "{.marker}"
+)
+
+// RPOMarkers writes the markers of the RPO probe of a test to the source app
+// and reads them back from the restored app in the sandbox. Markers have a
+// precision of one second.
+type RPOMarkers struct {
+	// Reader reads the connection Secrets of the probe. It must not be the
+	// manager's cached client: the operator may get Secrets but not list or
+	// watch them.
+	Reader client.Reader
+	// HTTPClient sends the requests of an http probe
+	HTTPClient *http.Client
+	// Test is the ChaosDRTest the probe belongs to
+	Test *chaosdrv1.ChaosDRTest
+	// Open opens the database of an sql probe; it defaults to sql.Open with the driver of the engine
+	Open func(engine, dsn string) (*sql.DB, error)
+}
+
+// NewRPOMarkers returns the RPOMarkers of cr.
+func NewRPOMarkers(reader client.Reader, cr *chaosdrv1.ChaosDRTest) *RPOMarkers {
+	return &RPOMarkers{Reader: reader, HTTPClient: &http.Client{Timeout: DefaultHTTPTimeout}, Test: cr, Open: openSQL}
+}
+
+// Write stores a marker for time t in the source app.
+func (m *RPOMarkers) Write(ctx context.Context, t time.Time) error {
+	spec := m.Test.Spec.RPOProbe
+	t = t.UTC().Truncate(time.Second)
+	switch {
+	case spec.HTTP != nil:
+		return m.writeHTTP(ctx, spec.HTTP, t)
+	case spec.SQL != nil:
+		return m.writeSQL(ctx, spec.SQL, t)
+	case spec.Redis != nil:
+		return m.writeRedis(ctx, spec.Redis, t)
+	}
+	return fmt.Errorf("the rpo probe has no target")
+}
+
+// Newest returns the time of the newest marker in the restored app, or the
+// zero time if no marker survived.
+func (m *RPOMarkers) Newest(ctx context.Context) (time.Time, error) {
+	spec := m.Test.Spec.RPOProbe
+	switch {
+	case spec.HTTP != nil:
+		return m.newestHTTP(ctx, spec.HTTP)
+	case spec.SQL != nil:
+		return m.newestSQL(ctx, spec.SQL)
+	case spec.Redis != nil:
+		return m.newestRedis(ctx, spec.Redis)
+	}
+	return time.Time{}, fmt.Errorf("the rpo probe has no target")
+}
+
+// Delete removes the markers of the test from the source app. Markers
+// written through an http probe are left to the app.
+func (m *RPOMarkers) Delete(ctx context.Context) error {
+	spec := m.Test.Spec.RPOProbe
+	switch {
+	case spec.SQL != nil:
+		db, err := m.openSQL(ctx, spec.SQL, false)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		ctx, cancel := context.WithTimeout(ctx, databaseTimeout)
+		defer cancel()
+		stmt := fmt.Sprintf("DELETE FROM %s WHERE test = %s", rpoTable(spec.SQL), placeholder(spec.SQL.Engine, 1))
+		_, err = db.ExecContext(ctx, stmt, m.Test.Name)
+		return err
+	case spec.Redis != nil:
+		rdb, err := m.redis(ctx, spec.Redis, false)
+		if err != nil {
+			return err
+		}
+		defer rdb.Close()
+		ctx, cancel := context.WithTimeout(ctx, databaseTimeout)
+		defer cancel()
+		return rdb.Del(ctx, redisKey(m.Test, spec.Redis)).Err()
+	}
+	return nil
+}
+
+func (m *RPOMarkers) writeHTTP(ctx context.Context, spec *chaosdrv1.RPOHTTPTarget, t time.Time) error {
+	body, err := json.Marshal(map[string]string{"test": m.Test.Name, "marker": t.Format(time.RFC3339)})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, spec.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	_, err = m.do(req, spec.Headers)
+	return err
+}
+
+func (m *RPOMarkers) newestHTTP(ctx context.Context, spec *chaosdrv1.RPOHTTPTarget) (time.Time, error) {
+	if m.Test.Status.SandboxNamespace == "" {
+		return time.Time{}, fmt.Errorf("the test has no sandbox namespace to connect to")
+	}
+	url, err := rewriteURLHosts(spec.ReadURL, func(host string) string {
+		return sandboxHost(host, m.Test.Namespace, m.Test.Status.SandboxNamespace)
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	body, err := m.do(req, spec.Headers)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return newestMarker(body, rpoJSONPath(spec))
+}
+
+// do sends req with headers and returns the body of a successful response.
+func (m *RPOMarkers) do(req *http.Request, headers map[string]string) ([]byte, error) {
+	for k, val := range headers {
+		req.Header.Set(k, val)
+	}
+	resp, err := m.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s %s returned status code %d", req.Method, req.URL.Redacted(), resp.StatusCode)
+	}
+	return body, nil
+}
+
+// newestMarker returns the newest of the RFC 3339 times path selects from
+// the JSON body, or the zero time if it selects none.
+func newestMarker(body []byte, path string) (time.Time, error) {
+	var data any
+	if err := json.Unmarshal(body, &data); err != nil {
+		return time.Time{}, fmt.Errorf("response body is not JSON: %w", err)
+	}
+	jp := jsonpath.New("marker").AllowMissingKeys(true)
+	if err := jp.Parse(bracePath(path)); err != nil {
+		return time.Time{}, fmt.Errorf("invalid JSONPath: %w", err)
+	}
+	var out bytes.Buffer
+	if err := jp.Execute(&out, data); err != nil {
+		return time.Time{}, err
+	}
+	var newest time.Time
+	for _, field := range strings.Fields(out.String()) {
+		t, err := time.Parse(time.RFC3339, field)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid marker %q: %w", field, err)
+		}
+		if t.After(newest) {
+			newest = t
+		}
+	}
+	return newest, nil
+}
+
+func (m *RPOMarkers) writeSQL(ctx context.Context, spec *chaosdrv1.RPOSQLTarget, t time.Time) error {
+	db, err := m.openSQL(ctx, spec, false)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	ctx, cancel := context.WithTimeout(ctx, databaseTimeout)
+	defer cancel()
+
+	table := rpoTable(spec)
+	create := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (test VARCHAR(253) NOT NULL, written_at BIGINT NOT NULL)", table)
+	if _, err := db.ExecContext(ctx, create); err != nil {
+		return fmt.Errorf("failed to create table %s: %w", table, err)
+	}
+	insert := fmt.Sprintf("INSERT INTO %s (test, written_at) VALUES (%s, %s)", table,
+		placeholder(spec.Engine, 1), placeholder(spec.Engine, 2))
+	if _, err := db.ExecContext(ctx, insert, m.Test.Name, t.Unix()); err != nil {
+		return fmt.Errorf("failed to insert marker: %w", err)
+	}
+	return nil
+}
+
+func (m *RPOMarkers) newestSQL(ctx context.Context, spec *chaosdrv1.RPOSQLTarget) (time.Time, error) {
+	db, err := m.openSQL(ctx, spec, true)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer db.Close()
+	ctx, cancel := context.WithTimeout(ctx, databaseTimeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT MAX(written_at) FROM %s WHERE test = %s", rpoTable(spec), placeholder(spec.Engine, 1))
+	var newest sql.NullInt64
+	if err := db.QueryRowContext(ctx, query, m.Test.Name).Scan(&newest); err != nil {
+		return time.Time{}, fmt.Errorf("failed to read markers: %w", err)
+	}
+	if !newest.Valid {
+		return time.Time{}, nil
+	}
+	return time.Unix(newest.Int64, 0).UTC(), nil
+}
+
+// openSQL opens the source database of spec, or the restored one in the
+// sandbox if sandbox is set.
+func (m *RPOMarkers) openSQL(ctx context.Context, spec *chaosdrv1.RPOSQLTarget, sandbox bool) (*sql.DB, error) {
+	engine := rpoEngine(spec)
+	dsn, err := m.connection(ctx, engine, &spec.ConnectionSecretRef, sandbox)
+	if err != nil {
+		return nil, err
+	}
+	return m.Open(engine, dsn)
+}
+
+func (m *RPOMarkers) writeRedis(ctx context.Context, spec *chaosdrv1.RPORedisTarget, t time.Time) error {
+	rdb, err := m.redis(ctx, spec, false)
+	if err != nil {
+		return err
+	}
+	defer rdb.Close()
+	ctx, cancel := context.WithTimeout(ctx, databaseTimeout)
+	defer cancel()
+	return rdb.Set(ctx, redisKey(m.Test, spec), t.Format(time.RFC3339), 0).Err()
+}
+
+func (m *RPOMarkers) newestRedis(ctx context.Context, spec *chaosdrv1.RPORedisTarget) (time.Time, error) {
+	rdb, err := m.redis(ctx, spec, true)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer rdb.Close()
+	ctx, cancel := context.WithTimeout(ctx, databaseTimeout)
+	defer cancel()
+
+	key := redisKey(m.Test, spec)
+	val, err := rdb.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("key %s holds no marker: %w", key, err)
+	}
+	return t, nil
+}
+
+// redis connects to the source Redis of spec, or the restored one in the
+// sandbox if sandbox is set.
+func (m *RPOMarkers) redis(ctx context.Context, spec *chaosdrv1.RPORedisTarget, sandbox bool) (*redis.Client, error) {
+	uri, err := m.connection(ctx, "", &spec.ConnectionSecretRef, sandbox)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := redis.ParseURL(uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis URL: %w", err)
+	}
+	return redis.NewClient(opts), nil
+}
+
+// connection returns the connection string ref holds, pointed at the
+// sandbox if sandbox is set.
+func (m *RPOMarkers) connection(ctx context.Context, engine string, ref *corev1.SecretKeySelector, sandbox bool) (string, error) {
+	if sandbox {
+		return sandboxConnection(ctx, m.Reader, m.Test, engine, ref)
+	}
+	conn, err := secretKey(ctx, m.Reader, m.Test.Namespace, ref.Name, ref.Key)
+	return string(conn), err
+}
+
+// ValidateRPOProbe checks the parts of spec the CRD schema cannot.
+func ValidateRPOProbe(spec *chaosdrv1.RPOProbeSpec) error {
+	switch {
+	case spec.HTTP != nil:
+		if err := jsonpath.New("marker").Parse(bracePath(rpoJSONPath(spec.HTTP))); err != nil {
+			return fmt.Errorf("invalid JSONPath %q: %w", spec.HTTP.JSONPath, err)
+		}
+	case spec.SQL != nil:
+		if !tablePattern.MatchString(rpoTable(spec.SQL)) {
+			return fmt.Errorf("invalid table name %q", spec.SQL.Table)
+		}
+		if _, ok := sqlDrivers[rpoEngine(spec.SQL)]; !ok {
+			return fmt.Errorf("unsupported engine %q", spec.SQL.Engine)
+		}
+	case spec.Redis != nil:
+	default:
+		return fmt.Errorf("the rpo probe needs one of http, sql or redis")
+	}
+	return nil
+}
+
+func rpoJSONPath(spec *chaosdrv1.RPOHTTPTarget) string {
+	if spec.JSONPath == "" {
+		return defaultRPOJSONPath
+	}
+	return spec.JSONPath
+}
+
+func rpoTable(spec *chaosdrv1.RPOSQLTarget) string {
+	if spec.Table == "" {
+		return defaultRPOTable
+	}
+	return spec.Table
+}
+
+func rpoEngine(spec *chaosdrv1.RPOSQLTarget) string {
+	if spec.Engine == "" {
+		return chaosdrv1.DatabaseEngineMySQL
+	}
+	return spec.Engine
+}
+
+// redisKey returns the key an RPO probe of cr sets in Redis.
+func redisKey(cr *chaosdrv1.ChaosDRTest, spec *chaosdrv1.RPORedisTarget) string {
+	if spec.Key == "" {
+		return "chaosdr:rpo:" + cr.Name
+	}
+	return spec.Key
+}
+
+// placeholder returns the n-th bind parameter in the syntax of engine.
+func placeholder(engine string, n int) string {
+	if engine == chaosdrv1.DatabaseEnginePostgres {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
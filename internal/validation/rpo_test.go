@@ -0,0 +1,179 @@
+package validation
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+func newRPOTestCR(spec chaosdrv1.RPOProbeSpec) *chaosdrv1.ChaosDRTest {
+	cr := newTestCR(chaosdrv1.ValidationConfig{})
+	cr.Spec.RPOProbe = &spec
+	cr.Status.SandboxNamespace = "sandbox"
+	return cr
+}
+
+func TestRPOMarkersHTTP(t *testing.T) {
+	var markers []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Method == http.MethodPost {
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["test"] != "redis-dr-test" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			markers = append(markers, body["marker"])
+			return
+		}
+		items := []map[string]string{}
+		for _, m := range markers {
+			items = append(items, map[string]string{"marker": m})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"items": items})
+	}))
+	defer srv.Close()
+
+	cr := newRPOTestCR(chaosdrv1.RPOProbeSpec{HTTP: &chaosdrv1.RPOHTTPTarget{
+		URL:      srv.URL + "/markers",
+		ReadURL:  srv.URL + "/markers",
+		JSONPath: "{.items[*].marker}",
+		Headers:  map[string]string{"Authorization": "Bearer token"},
+	}})
+	m := NewRPOMarkers(nil, cr)
+
+	newest, err := m.Newest(context.Background())
+	if err != nil || !newest.IsZero() {
+		t.Fatalf("Expected no marker before any was written, got %s, %v", newest, err)
+	}
+	first := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	for _, ts := range []time.Time{first.Add(5 * time.Second), first} {
+		if err := m.Write(context.Background(), ts.Add(300*time.Millisecond)); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+	newest, err = m.Newest(context.Background())
+	if err != nil {
+		t.Fatalf("Newest failed: %v", err)
+	}
+	if !newest.Equal(first.Add(5 * time.Second)) {
+		t.Errorf("Expected the newest marker %s, got %s", first.Add(5*time.Second), newest)
+	}
+}
+
+func TestRPOMarkersSQL(t *testing.T) {
+	marker := time.Date(2026, 10, 1, 12, 0, 5, 0, time.UTC)
+	tests := []struct {
+		engine string
+		insert string
+	}{
+		{chaosdrv1.DatabaseEngineMySQL, "INSERT INTO chaosdr_rpo_markers (test, written_at) VALUES (?, ?)"},
+		{chaosdrv1.DatabaseEnginePostgres, "INSERT INTO chaosdr_rpo_markers (test, written_at) VALUES ($1, $2)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.engine, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create sqlmock: %v", err)
+			}
+			mock.ExpectExec("CREATE TABLE IF NOT EXISTS chaosdr_rpo_markers").WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(tt.insert)).WithArgs("redis-dr-test", marker.Unix()).WillReturnResult(sqlmock.NewResult(1, 1))
+
+			conn := "postgres://app@db.default.svc:5432/shop"
+			if tt.engine == chaosdrv1.DatabaseEngineMySQL {
+				conn = "app@tcp(db:3306)/shop"
+			}
+			var dsns []string
+			cr := newRPOTestCR(chaosdrv1.RPOProbeSpec{SQL: &chaosdrv1.RPOSQLTarget{Engine: tt.engine, ConnectionSecretRef: connectionRef()}})
+			m := &RPOMarkers{Reader: newConnectionClient(conn), Test: cr, Open: func(_, dsn string) (*sql.DB, error) {
+				dsns = append(dsns, dsn)
+				return db, nil
+			}}
+			if err := m.Write(context.Background(), marker.Add(time.Millisecond)); err != nil {
+				t.Fatalf("Write failed: %v", err)
+			}
+
+			db, mock, err = sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create sqlmock: %v", err)
+			}
+			mock.ExpectQuery("SELECT MAX\\(written_at\\) FROM chaosdr_rpo_markers").WithArgs("redis-dr-test").
+				WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(marker.Unix()))
+			newest, err := m.Newest(context.Background())
+			if err != nil {
+				t.Fatalf("Newest failed: %v", err)
+			}
+			if !newest.Equal(marker) {
+				t.Errorf("Expected the newest marker %s, got %s", marker, newest)
+			}
+			if len(dsns) != 2 || dsns[0] == dsns[1] {
+				t.Errorf("Expected the markers to be read from the sandbox, got %q", dsns)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestRPOMarkersRedis(t *testing.T) {
+	mr := miniredis.RunT(t)
+	cr := newRPOTestCR(chaosdrv1.RPOProbeSpec{Redis: &chaosdrv1.RPORedisTarget{ConnectionSecretRef: connectionRef()}})
+	m := NewRPOMarkers(newConnectionClient("redis://"+mr.Addr()+"/0"), cr)
+
+	newest, err := m.Newest(context.Background())
+	if err != nil || !newest.IsZero() {
+		t.Fatalf("Expected no marker before any was written, got %s, %v", newest, err)
+	}
+	marker := time.Date(2026, 10, 1, 12, 0, 5, 0, time.UTC)
+	if err := m.Write(context.Background(), marker); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if got, _ := mr.Get("chaosdr:rpo:redis-dr-test"); got != "2026-10-01T12:00:05Z" {
+		t.Errorf("Expected the marker in chaosdr:rpo:redis-dr-test, got %q", got)
+	}
+	newest, err = m.Newest(context.Background())
+	if err != nil || !newest.Equal(marker) {
+		t.Errorf("Expected the newest marker %s, got %s, %v", marker, newest, err)
+	}
+	if err := m.Delete(context.Background()); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if mr.Exists("chaosdr:rpo:redis-dr-test") {
+		t.Error("Expected the marker to be deleted")
+	}
+}
+
+func TestValidateRPOProbe(t *testing.T) {
+	tests := []struct {
+		name  string
+		spec  chaosdrv1.RPOProbeSpec
+		valid bool
+	}{
+		{"http", chaosdrv1.RPOProbeSpec{HTTP: &chaosdrv1.RPOHTTPTarget{JSONPath: ".items[*].marker"}}, true},
+		{"invalid JSONPath", chaosdrv1.RPOProbeSpec{HTTP: &chaosdrv1.RPOHTTPTarget{JSONPath: "{.items["}}, false},
+		{"sql", chaosdrv1.RPOProbeSpec{SQL: &chaosdrv1.RPOSQLTarget{Table: "app.markers"}}, true},
+		{"invalid table", chaosdrv1.RPOProbeSpec{SQL: &chaosdrv1.RPOSQLTarget{Table: "markers; DROP TABLE users"}}, false},
+		{"no target", chaosdrv1.RPOProbeSpec{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateRPOProbe(&tt.spec); (err == nil) != tt.valid {
+				t.Errorf("Expected valid=%v, got %v", tt.valid, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package validation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+const (
+	// DefaultScriptImage runs script checks that set no image
+	DefaultScriptImage = "busybox:1.36"
+	// DefaultScriptTimeout bounds script checks that set no timeout
+	DefaultScriptTimeout = 5 * time.Minute
+	// testLabel marks the validation Jobs of a ChaosDRTest with its name
+	testLabel = "chaosdr.io/test"
+	// checkLabel marks a validation Job with the name of its check
+	checkLabel = "chaosdr.io/validation-check"
+	// logTailLines and maxOutput cap the script log kept in the status
+	logTailLines = 20
+	maxOutput    = 1024
+)
+
+// ScriptValidator runs script checks as Jobs in the sandbox namespace of a
+// test and reads the script's log from the Job's pod.
+type ScriptValidator struct {
+	Client client.Client
+	// Pods reads pod logs, which the controller-runtime client cannot
+	Pods corev1client.PodsGetter
+	// Test is the ChaosDRTest whose sandbox the Jobs run in
+	Test *chaosdrv1.ChaosDRTest
+}
+
+// NewScriptFactory returns a Factory for ScriptValidator.
+func NewScriptFactory(cl client.Client, pods corev1client.PodsGetter) Factory {
+	return func(cr *chaosdrv1.ChaosDRTest) (Validator, error) {
+		return &ScriptValidator{Client: cl, Pods: pods, Test: cr}, nil
+	}
+}
+
+// Validate starts the Job of the check on the first call and reports the
+// check running until the Job has finished. It passes when the script exited
+// with 0; a Job that failed or ran past its timeout fails the check.
+func (v *ScriptValidator) Validate(ctx context.Context, check *chaosdrv1.ValidationCheck) (*Result, error) {
+	namespace := v.Test.Status.SandboxNamespace
+	if namespace == "" {
+		return &Result{Expected: "exit code 0", Message: "the test has no sandbox namespace to run the script in"}, nil
+	}
+	name := objectName("validate", v.Test.Name, check.Name)
+
+	job := &batchv1.Job{}
+	err := v.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, job)
+	if apierrors.IsNotFound(err) {
+		job = newScriptJob(name, namespace, v.Test.Name, check)
+		if err := v.Client.Create(ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("failed to create job %s: %w", name, err)
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job %s: %w", name, err)
+	}
+
+	complete, failed := jobCondition(job, batchv1.JobComplete), jobCondition(job, batchv1.JobFailed)
+	if complete == nil && failed == nil {
+		return nil, nil
+	}
+
+	pod, err := jobPod(ctx, v.Client, job)
+	if err != nil {
+		return nil, err
+	}
+	status := &chaosdrv1.ValidationJobStatus{Name: name}
+	res := &Result{Passed: complete != nil, Expected: "exit code 0", Job: status}
+	if pod != nil {
+		status.ExitCode = exitCode(pod)
+		status.LogTail, err = v.logTail(ctx, pod)
+		if err != nil {
+			status.LogTail = fmt.Sprintf("failed to read the log of pod %s: %v", pod.Name, err)
+		}
+	}
+	switch {
+	case status.ExitCode != nil:
+		res.Observed = fmt.Sprintf("exit code %d", *status.ExitCode)
+	case failed != nil:
+		res.Observed = failed.Reason
+	}
+	if failed != nil {
+		res.Message = fmt.Sprintf("job %s failed: %s", name, failed.Message)
+	}
+	return res, nil
+}
+
+// jobPod returns the most recent pod of job, or nil if it has none.
+func jobPod(ctx context.Context, cl client.Client, job *batchv1.Job) (*corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	if err := cl.List(ctx, pods, client.InNamespace(job.Namespace), client.MatchingLabels{batchv1.JobNameLabel: job.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list pods of job %s: %w", job.Name, err)
+	}
+	var latest *corev1.Pod
+	for i := range pods.Items {
+		if pod := &pods.Items[i]; latest == nil || latest.CreationTimestamp.Before(&pod.CreationTimestamp) {
+			latest = pod
+		}
+	}
+	return latest, nil
+}
+
+// logTail reads the last lines of the script's log.
+func (v *ScriptValidator) logTail(ctx context.Context, pod *corev1.Pod) (string, error) {
+	tailLines := int64(logTailLines)
+	out, err := podLogs(ctx, v.Pods, pod, &corev1.PodLogOptions{Container: "script", TailLines: &tailLines})
+	if err != nil {
+		return "", err
+	}
+	tail := strings.TrimSpace(string(out))
+	if len(tail) > maxOutput {
+		tail = "..." + tail[len(tail)-maxOutput:]
+	}
+	return tail, nil
+}
+
+// podLogs reads the log of pod.
+func podLogs(ctx context.Context, pods corev1client.PodsGetter, pod *corev1.Pod, opts *corev1.PodLogOptions) ([]byte, error) {
+	stream, err := pods.Pods(pod.Namespace).GetLogs(pod.Name, opts).Stream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+	return io.ReadAll(stream)
+}
+
+// newScriptJob builds the Job running the script of check. It does not retry
+// the script and gets no service account token, as the script is user code.
+func newScriptJob(name, namespace, test string, check *chaosdrv1.ValidationCheck) *batchv1.Job {
+	spec := check.Script
+	image := spec.Image
+	if image == "" {
+		image = DefaultScriptImage
+	}
+	timeout := DefaultScriptTimeout
+	if spec.Timeout != nil && spec.Timeout.Duration > 0 {
+		timeout = spec.Timeout.Duration
+	}
+	backoffLimit := int32(0)
+	deadline := int64(timeout.Seconds())
+	automount := false
+	labels := map[string]string{testLabel: test, checkLabel: check.Name}
+
+	container := corev1.Container{
+		Name:                     "script",
+		Image:                    image,
+		Command:                  []string{"/bin/sh", "-c", spec.Script},
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+	if spec.Resources != nil {
+		container.Resources = *spec.Resources
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          &backoffLimit,
+			ActiveDeadlineSeconds: &deadline,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy:                corev1.RestartPolicyNever,
+					AutomountServiceAccountToken: &automount,
+					Containers:                   []corev1.Container{container},
+				},
+			},
+		},
+	}
+}
+
+// exitCode returns the exit code of the script container of pod, or nil if
+// it has not terminated.
+func exitCode(pod *corev1.Pod) *int32 {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name == "script" && cs.State.Terminated != nil {
+			code := cs.State.Terminated.ExitCode
+			return &code
+		}
+	}
+	return nil
+}
+
+// jobCondition returns the condition of condType if it is true on job.
+func jobCondition(job *batchv1.Job, condType batchv1.JobConditionType) *batchv1.JobCondition {
+	for i, cond := range job.Status.Conditions {
+		if cond.Type == condType && cond.Status == corev1.ConditionTrue {
+			return &job.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// objectName names an object created for a check, hashing the tail of names
+// too long for the 63 character job-name pod label.
+func objectName(prefix, test, check string) string {
+	name := prefix + "-" + test + "-" + check
+	if len(name) <= 63 {
+		return name
+	}
+	sum := sha256.Sum256([]byte(name))
+	return name[:54] + "-" + hex.EncodeToString(sum[:])[:8]
+}
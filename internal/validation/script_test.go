@@ -0,0 +1,133 @@
+package validation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+func newScriptValidator(t *testing.T) (*ScriptValidator, client.Client) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	cr := newTestCR(chaosdrv1.ValidationConfig{})
+	cr.Status.SandboxNamespace = "sandbox-redis-dr-test"
+	return &ScriptValidator{Client: cl, Pods: kubefake.NewClientset().CoreV1(), Test: cr}, cl
+}
+
+func newScriptCheck() *chaosdrv1.ValidationCheck {
+	return &chaosdrv1.ValidationCheck{Name: "ping", Type: chaosdrv1.ValidationTypeScript, Script: &chaosdrv1.ScriptCheck{
+		Script:    "redis-cli -h redis ping",
+		Image:     "redis:7",
+		Timeout:   &metav1.Duration{Duration: time.Minute},
+		Resources: &corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")}},
+	}}
+}
+
+// finishJob marks the Job of the check finished with condType and gives it a
+// pod whose script exited with code.
+func finishJob(t *testing.T, cl client.Client, condType batchv1.JobConditionType, reason string, code int32) {
+	t.Helper()
+	job := &batchv1.Job{}
+	key := client.ObjectKey{Namespace: "sandbox-redis-dr-test", Name: "validate-redis-dr-test-ping"}
+	if err := cl.Get(context.Background(), key, job); err != nil {
+		t.Fatalf("Failed to get job: %v", err)
+	}
+	job.Status.Conditions = []batchv1.JobCondition{{Type: condType, Status: corev1.ConditionTrue, Reason: reason, Message: reason}}
+	if err := cl.Status().Update(context.Background(), job); err != nil {
+		t.Fatalf("Failed to update job: %v", err)
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name + "-abcde", Namespace: key.Namespace, Labels: map[string]string{batchv1.JobNameLabel: key.Name}},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  "script",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: code}},
+		}}},
+	}
+	if err := cl.Create(context.Background(), pod); err != nil {
+		t.Fatalf("Failed to create pod: %v", err)
+	}
+}
+
+func TestScriptValidatorRunsJob(t *testing.T) {
+	v, cl := newScriptValidator(t)
+	check := newScriptCheck()
+
+	res, err := v.Validate(context.Background(), check)
+	if err != nil || res != nil {
+		t.Fatalf("Expected the check to be running, got %+v, %v", res, err)
+	}
+	job := &batchv1.Job{}
+	if err := cl.Get(context.Background(), client.ObjectKey{Namespace: "sandbox-redis-dr-test", Name: "validate-redis-dr-test-ping"}, job); err != nil {
+		t.Fatalf("Expected a job in the sandbox namespace: %v", err)
+	}
+	container := job.Spec.Template.Spec.Containers[0]
+	if container.Image != "redis:7" || container.Command[2] != "redis-cli -h redis ping" {
+		t.Errorf("Expected the script to run in image redis:7, got %s %v", container.Image, container.Command)
+	}
+	if container.Resources.Limits.Cpu().String() != "100m" {
+		t.Errorf("Expected a 100m CPU limit, got %v", container.Resources.Limits)
+	}
+	if job.Spec.ActiveDeadlineSeconds == nil || *job.Spec.ActiveDeadlineSeconds != 60 {
+		t.Errorf("Expected a 60s deadline, got %v", job.Spec.ActiveDeadlineSeconds)
+	}
+	if token := job.Spec.Template.Spec.AutomountServiceAccountToken; token == nil || *token {
+		t.Error("Expected no service account token to be mounted")
+	}
+
+	// Still running
+	if res, err := v.Validate(context.Background(), check); err != nil || res != nil {
+		t.Fatalf("Expected the check to be running, got %+v, %v", res, err)
+	}
+
+	finishJob(t, cl, batchv1.JobComplete, "", 0)
+	res, err = v.Validate(context.Background(), check)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if !res.Passed || res.Observed != "exit code 0" || res.Job == nil || *res.Job.ExitCode != 0 {
+		t.Errorf("Expected a passing check with exit code 0, got %+v", res)
+	}
+	if res.Job.LogTail != "fake logs" {
+		t.Errorf("Expected the log tail of the pod, got %q", res.Job.LogTail)
+	}
+}
+
+func TestScriptValidatorFailedJob(t *testing.T) {
+	v, cl := newScriptValidator(t)
+	check := newScriptCheck()
+	if _, err := v.Validate(context.Background(), check); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	finishJob(t, cl, batchv1.JobFailed, "BackoffLimitExceeded", 1)
+	res, err := v.Validate(context.Background(), check)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if res.Passed || res.Observed != "exit code 1" || res.Expected != "exit code 0" || res.Message == "" {
+		t.Errorf("Expected a failing check with exit code 1, got %+v", res)
+	}
+}
+
+func TestScriptValidatorNeedsSandbox(t *testing.T) {
+	v, _ := newScriptValidator(t)
+	v.Test.Status.SandboxNamespace = ""
+	res, err := v.Validate(context.Background(), newScriptCheck())
+	if err != nil || res == nil || res.Passed {
+		t.Errorf("Expected a failing check without a sandbox, got %+v, %v", res, err)
+	}
+}
@@ -0,0 +1,183 @@
+package validation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+// sqlDrivers maps the engines of sql checks to their database/sql driver
+var sqlDrivers = map[string]string{
+	chaosdrv1.DatabaseEngineMySQL:    "mysql",
+	chaosdrv1.DatabaseEnginePostgres: "pgx",
+}
+
+// SQLValidator runs sql checks against MySQL and PostgreSQL.
+type SQLValidator struct {
+	// Reader reads the connection Secrets of a check. It must not be the
+	// manager's cached client: the operator may get Secrets but not list or
+	// watch them.
+	Reader client.Reader
+	// Test is the ChaosDRTest the checks belong to
+	Test *chaosdrv1.ChaosDRTest
+	// Open opens the database; it defaults to sql.Open with the driver of the engine
+	Open func(engine, dsn string) (*sql.DB, error)
+}
+
+// NewSQLFactory returns a Factory for SQLValidator.
+func NewSQLFactory(reader client.Reader) Factory {
+	return func(cr *chaosdrv1.ChaosDRTest) (Validator, error) {
+		return &SQLValidator{Reader: reader, Test: cr, Open: openSQL}, nil
+	}
+}
+
+func openSQL(engine, dsn string) (*sql.DB, error) {
+	return sql.Open(sqlDrivers[engine], dsn)
+}
+
+// Validate runs the query and compares its result with the expected scalar,
+// row set or row count.
+func (v *SQLValidator) Validate(ctx context.Context, check *chaosdrv1.ValidationCheck) (*Result, error) {
+	spec := check.SQL
+	res := &Result{}
+	switch {
+	case spec.ExpectedScalar != nil:
+		res.Expected = *spec.ExpectedScalar
+	case spec.ExpectedRowSet != nil:
+		res.Expected = fmt.Sprintf("%d rows", len(spec.ExpectedRowSet))
+	default:
+		res.Expected = fmt.Sprintf("%d rows", spec.ExpectedRows)
+	}
+
+	engine := sqlEngine(spec)
+	dsn := spec.ConnectionString
+	if spec.ConnectionSecretRef != nil {
+		var err error
+		if dsn, err = sandboxConnection(ctx, v.Reader, v.Test, engine, spec.ConnectionSecretRef); err != nil {
+			res.Message = err.Error()
+			return res, nil
+		}
+	}
+	db, err := v.Open(engine, dsn)
+	if err != nil {
+		res.Message = err.Error()
+		return res, nil
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, databaseTimeout)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		if retryConnect(ctx, v.Test, check, err) {
+			return nil, nil
+		}
+		res.Message = err.Error()
+		return res, nil
+	}
+	rows, err := queryRows(ctx, db, spec.Query)
+	if err != nil {
+		res.Message = err.Error()
+		return res, nil
+	}
+
+	switch {
+	case spec.ExpectedScalar != nil:
+		if len(rows) != 1 || len(rows[0]) != 1 {
+			res.Observed = fmt.Sprintf("%d rows", len(rows))
+			res.Message = "query did not return a single value"
+			return res, nil
+		}
+		res.Observed = rows[0][0]
+		res.Passed = res.Observed == res.Expected
+		if !res.Passed {
+			res.Message = fmt.Sprintf("unexpected value: got %q, expected %q", res.Observed, res.Expected)
+		}
+	case spec.ExpectedRowSet != nil:
+		res.Observed = fmt.Sprintf("%d rows", len(rows))
+		res.Message = diffRows(rows, spec.ExpectedRowSet)
+		res.Passed = res.Message == ""
+	default:
+		res.Observed = fmt.Sprintf("%d rows", len(rows))
+		res.Passed = len(rows) == spec.ExpectedRows
+		if !res.Passed {
+			res.Message = fmt.Sprintf("unexpected row count: got %d, expected %d", len(rows), spec.ExpectedRows)
+		}
+	}
+	return res, nil
+}
+
+// sqlEngine returns the engine of spec, which defaults to MySQL.
+func sqlEngine(spec *chaosdrv1.DatabaseQuery) string {
+	if spec.Engine == "" {
+		return chaosdrv1.DatabaseEngineMySQL
+	}
+	return spec.Engine
+}
+
+// queryRows runs query and returns every row as its column values formatted as text.
+func queryRows(ctx context.Context, db *sql.DB, query string) ([][]string, error) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var result [][]string
+	values := make([]any, len(columns))
+	ptrs := make([]any, len(columns))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		row := make([]string, len(values))
+		for i, val := range values {
+			row[i] = formatValue(val)
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
+// formatValue formats a column value as it is written in expectedRowSet.
+func formatValue(val any) string {
+	switch val := val.(type) {
+	case nil:
+		return "NULL"
+	case []byte:
+		return string(val)
+	case time.Time:
+		return val.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// diffRows describes the first difference between the rows a query returned
+// and the expected ones, or returns "" if they are equal.
+func diffRows(got, expected [][]string) string {
+	for i := range min(len(got), len(expected)) {
+		if !slices.Equal(got[i], expected[i]) {
+			return fmt.Sprintf("row %d: got [%s], expected [%s]", i+1, strings.Join(got[i], ", "), strings.Join(expected[i], ", "))
+		}
+	}
+	if len(got) != len(expected) {
+		return fmt.Sprintf("unexpected row count: got %d, expected %d", len(got), len(expected))
+	}
+	return ""
+}
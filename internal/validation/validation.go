@@ -0,0 +1,141 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+// Validator runs one type of validation check against the restored app
+type Validator interface {
+	// Validate runs check and returns its outcome. A nil Result with a nil
+	// error means the check is still running and Validate is called again
+	// later. An error means the check could not be run; it fails the check
+	// with the error as its message.
+	Validate(ctx context.Context, check *chaosdrv1.ValidationCheck) (*Result, error)
+}
+
+// Capturer is implemented by validators that record the source app before
+// it is backed up, to compare the restore with
+type Capturer interface {
+	// Capture records what check needs from the source app. It returns false
+	// while the capture is still running and is called again later.
+	Capture(ctx context.Context, check *chaosdrv1.ValidationCheck) (bool, error)
+}
+
+// Result is the outcome of a finished validation check
+type Result struct {
+	Passed bool
+	// Observed and Expected describe what the check compared
+	Observed string
+	Expected string
+	Message  string
+	// Job reports the Job that ran a script check
+	Job *chaosdrv1.ValidationJobStatus
+	// Diff reports the entities a fingerprint check compared
+	Diff *chaosdrv1.FingerprintDiff
+}
+
+// Names of the checks converted from the deprecated ValidationConfig fields
+const (
+	legacyScriptCheck   = "script"
+	legacyHTTPCheck     = "api-endpoint"
+	legacyDatabaseCheck = "database-query"
+)
+
+// Checks returns the validation checks of cr. A test written against the
+// deprecated script, apiEndpoint and databaseQuery fields has them converted
+// to checks, in that order.
+func Checks(cr *chaosdrv1.ChaosDRTest) []chaosdrv1.ValidationCheck {
+	cfg := cr.Spec.ValidationConfig
+	if len(cfg.Checks) > 0 {
+		return cfg.Checks
+	}
+	var checks []chaosdrv1.ValidationCheck
+	if cfg.Script != "" {
+		checks = append(checks, chaosdrv1.ValidationCheck{
+			Name:   legacyScriptCheck,
+			Type:   chaosdrv1.ValidationTypeScript,
+			Script: &chaosdrv1.ScriptCheck{Script: cfg.Script},
+		})
+	}
+	if cfg.APIEndpoint != "" {
+		checks = append(checks, chaosdrv1.ValidationCheck{
+			Name: legacyHTTPCheck,
+			Type: chaosdrv1.ValidationTypeHTTP,
+			HTTP: &chaosdrv1.HTTPCheck{URL: cfg.APIEndpoint, ExpectedStatusCode: cfg.ExpectedStatusCode},
+		})
+	}
+	if cfg.DatabaseQuery != nil {
+		checks = append(checks, chaosdrv1.ValidationCheck{
+			Name: legacyDatabaseCheck,
+			Type: chaosdrv1.ValidationTypeSQL,
+			SQL:  cfg.DatabaseQuery,
+		})
+	}
+	return checks
+}
+
+// startTime returns when the check name of cr started, or now if it has not.
+func startTime(cr *chaosdrv1.ChaosDRTest, name string) time.Time {
+	for _, st := range cr.Status.Validation {
+		if st.Name == name && st.StartTime != nil {
+			return st.StartTime.Time
+		}
+	}
+	return time.Now()
+}
+
+// ValidateChecks checks that every validation check of cr has a unique name and a
+// registered validator that accepts it.
+func (r *Registry) ValidateChecks(cr *chaosdrv1.ChaosDRTest) error {
+	seen := map[string]bool{}
+	for _, check := range Checks(cr) {
+		if seen[check.Name] {
+			return fmt.Errorf("validation check name %s is used twice", check.Name)
+		}
+		seen[check.Name] = true
+		if _, err := r.New(check.Type, cr); err != nil {
+			return fmt.Errorf("validation check %s: %w", check.Name, err)
+		}
+		if err := checkMember(&check); err != nil {
+			return fmt.Errorf("validation check %s: %w", check.Name, err)
+		}
+	}
+	return nil
+}
+
+// checkMember reports an error when the member holding the parameters of a
+// built-in check type is missing or invalid.
+func checkMember(check *chaosdrv1.ValidationCheck) error {
+	missing := false
+	switch check.Type {
+	case chaosdrv1.ValidationTypeScript:
+		missing = check.Script == nil
+	case chaosdrv1.ValidationTypeHTTP:
+		missing = check.HTTP == nil
+	case chaosdrv1.ValidationTypeSQL:
+		missing = check.SQL == nil
+	case chaosdrv1.ValidationTypeRedis:
+		missing = check.Redis == nil
+	case chaosdrv1.ValidationTypeMongoDB:
+		missing = check.MongoDB == nil
+	case chaosdrv1.ValidationTypeFingerprint:
+		missing = check.Fingerprint == nil
+	}
+	if missing {
+		return fmt.Errorf("%s is required for type %s", check.Type, check.Type)
+	}
+	switch check.Type {
+	case chaosdrv1.ValidationTypeHTTP:
+		return validateHTTPCheck(check.HTTP)
+	case chaosdrv1.ValidationTypeMongoDB:
+		_, err := mongoFilter(check.MongoDB.Filter)
+		return err
+	case chaosdrv1.ValidationTypeFingerprint:
+		return validateFingerprintCheck(check.Fingerprint)
+	}
+	return nil
+}
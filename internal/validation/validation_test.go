@@ -0,0 +1,121 @@
+package validation
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	chaosdrv1 "github.com/harrisin2037/chaos-dr-validator/api/v1"
+)
+
+func newTestCR(cfg chaosdrv1.ValidationConfig) *chaosdrv1.ChaosDRTest {
+	return &chaosdrv1.ChaosDRTest{
+		ObjectMeta: metav1.ObjectMeta{Name: "redis-dr-test", Namespace: "default"},
+		Spec: chaosdrv1.ChaosDRTestSpec{
+			AppSelector:      map[string]string{"app": "redis"},
+			ValidationConfig: cfg,
+		},
+	}
+}
+
+func TestChecksConvertsLegacyFields(t *testing.T) {
+	cr := newTestCR(chaosdrv1.ValidationConfig{
+		Script:             "redis-cli ping",
+		APIEndpoint:        "http://redis-sandbox/healthz",
+		ExpectedStatusCode: 204,
+		DatabaseQuery:      &chaosdrv1.DatabaseQuery{Query: "SELECT 1", ExpectedRows: 1},
+	})
+
+	checks := Checks(cr)
+	if len(checks) != 3 {
+		t.Fatalf("Expected 3 checks, got %+v", checks)
+	}
+	if checks[0].Type != chaosdrv1.ValidationTypeScript || checks[0].Script.Script != "redis-cli ping" {
+		t.Errorf("Expected a script check, got %+v", checks[0])
+	}
+	if checks[1].Type != chaosdrv1.ValidationTypeHTTP || checks[1].HTTP.ExpectedStatusCode != 204 {
+		t.Errorf("Expected an http check expecting 204, got %+v", checks[1])
+	}
+	if checks[2].Type != chaosdrv1.ValidationTypeSQL || checks[2].SQL.Query != "SELECT 1" {
+		t.Errorf("Expected a sql check, got %+v", checks[2])
+	}
+
+	cr.Spec.ValidationConfig.Checks = []chaosdrv1.ValidationCheck{
+		{Name: "ping", Type: chaosdrv1.ValidationTypeScript, Script: &chaosdrv1.ScriptCheck{Script: "true"}},
+	}
+	if checks := Checks(cr); len(checks) != 1 || checks[0].Name != "ping" {
+		t.Errorf("Expected checks to replace the legacy fields, got %+v", checks)
+	}
+}
+
+func TestValidateChecks(t *testing.T) {
+	script := &chaosdrv1.ScriptCheck{Script: "true"}
+	tests := []struct {
+		name   string
+		checks []chaosdrv1.ValidationCheck
+		err    string
+	}{
+		{"valid", []chaosdrv1.ValidationCheck{{Name: "a", Type: "script", Script: script}}, ""},
+		{"duplicate name", []chaosdrv1.ValidationCheck{
+			{Name: "a", Type: "script", Script: script},
+			{Name: "a", Type: "script", Script: script},
+		}, "used twice"},
+		{"unknown type", []chaosdrv1.ValidationCheck{{Name: "a", Type: "grpc"}}, "unknown validation check type"},
+		{"missing member", []chaosdrv1.ValidationCheck{{Name: "a", Type: "http"}}, "http is required"},
+		{"invalid regex", []chaosdrv1.ValidationCheck{{Name: "a", Type: "http", HTTP: &chaosdrv1.HTTPCheck{
+			URL:        "http://redis-sandbox/healthz",
+			Assertions: []chaosdrv1.BodyAssertion{{Regex: "("}},
+		}}}, "invalid regex"},
+		{"invalid jsonpath", []chaosdrv1.ValidationCheck{{Name: "a", Type: "http", HTTP: &chaosdrv1.HTTPCheck{
+			URL:        "http://redis-sandbox/healthz",
+			Assertions: []chaosdrv1.BodyAssertion{{JSONPath: "{.items[}"}},
+		}}}, "invalid JSONPath"},
+		{"invalid filter", []chaosdrv1.ValidationCheck{{Name: "a", Type: "mongodb", MongoDB: &chaosdrv1.MongoDBCheck{
+			Database:   "shop",
+			Collection: "orders",
+			Filter:     "{status:",
+		}}}, "invalid filter"},
+		{"missing redis", []chaosdrv1.ValidationCheck{{Name: "a", Type: "redis"}}, "redis is required"},
+		{"invalid table", []chaosdrv1.ValidationCheck{{Name: "a", Type: "fingerprint", Fingerprint: &chaosdrv1.FingerprintCheck{
+			SQL: &chaosdrv1.SQLFingerprint{Tables: []string{"users; DROP TABLE users"}},
+		}}}, "invalid table name"},
+	}
+	r := NewDefaultRegistry(nil, nil, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := r.ValidateChecks(newTestCR(chaosdrv1.ValidationConfig{Checks: tt.checks}))
+			switch {
+			case tt.err == "" && err != nil:
+				t.Errorf("Expected no error, got %v", err)
+			case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+				t.Errorf("Expected an error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+type stubValidator struct{}
+
+func (stubValidator) Validate(context.Context, *chaosdrv1.ValidationCheck) (*Result, error) {
+	return &Result{Passed: true}, nil
+}
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	r.Register("stub", func(*chaosdrv1.ChaosDRTest) (Validator, error) { return stubValidator{}, nil })
+	if !r.Has("stub") || r.Has("script") {
+		t.Errorf("Expected only stub to be registered, got %v", r.Names())
+	}
+	v, err := r.New("stub", newTestCR(chaosdrv1.ValidationConfig{}))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if res, _ := v.Validate(context.Background(), &chaosdrv1.ValidationCheck{}); !res.Passed {
+		t.Error("Expected the stub validator to pass")
+	}
+	if _, err := r.New("script", nil); err == nil || !strings.Contains(err.Error(), "stub") {
+		t.Errorf("Expected an unknown type to list the registered ones, got %v", err)
+	}
+}
@@ -1,30 +1,235 @@
 package velero
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/harrisin2037/chaos-dr-validator/internal/backup"
+)
+
+// DefaultNamespace is where Velero is installed unless configured otherwise
+const DefaultNamespace = "velero"
+
+// backupNameLabel is set by Velero on objects that belong to a backup
+const backupNameLabel = "velero.io/backup-name"
+
+var (
+	backupGVK              = schema.GroupVersionKind{Group: "velero.io", Version: "v1", Kind: "Backup"}
+	restoreGVK             = schema.GroupVersionKind{Group: "velero.io", Version: "v1", Kind: "Restore"}
+	deleteBackupRequestGVK = schema.GroupVersionKind{Group: "velero.io", Version: "v1", Kind: "DeleteBackupRequest"}
+	podVolumeBackupListGVK = schema.GroupVersionKind{Group: "velero.io", Version: "v1", Kind: "PodVolumeBackupList"}
 )
 
-type VeleroClient struct{}
+// backupSpec mirrors the subset of velero.io/v1 BackupSpec the operator sets
+type backupSpec struct {
+	IncludedNamespaces []string              `json:"includedNamespaces,omitempty"`
+	LabelSelector      *metav1.LabelSelector `json:"labelSelector,omitempty"`
+}
+
+// restoreSpec mirrors the subset of velero.io/v1 RestoreSpec the operator sets
+type restoreSpec struct {
+	BackupName       string            `json:"backupName"`
+	NamespaceMapping map[string]string `json:"namespaceMapping,omitempty"`
+}
+
+// deleteBackupRequestSpec mirrors velero.io/v1 DeleteBackupRequestSpec
+type deleteBackupRequestSpec struct {
+	BackupName string `json:"backupName"`
+}
+
+// operationStatus mirrors the fields shared by velero.io/v1 BackupStatus and RestoreStatus
+type operationStatus struct {
+	Phase               string       `json:"phase,omitempty"`
+	ValidationErrors    []string     `json:"validationErrors,omitempty"`
+	FailureReason       string       `json:"failureReason,omitempty"`
+	Warnings            int          `json:"warnings,omitempty"`
+	Errors              int          `json:"errors,omitempty"`
+	StartTimestamp      *metav1.Time `json:"startTimestamp,omitempty"`
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+	Progress            *struct {
+		TotalItems    int `json:"totalItems,omitempty"`
+		ItemsBackedUp int `json:"itemsBackedUp,omitempty"`
+		ItemsRestored int `json:"itemsRestored,omitempty"`
+	} `json:"progress,omitempty"`
+}
+
+// VeleroClient drives Velero through its Backup and Restore custom resources.
+type VeleroClient struct {
+	Client client.Client
+	// Namespace is where Velero watches for Backup and Restore objects
+	Namespace string
+}
+
+// NewVeleroClient returns a VeleroClient that creates its objects in namespace.
+func NewVeleroClient(cl client.Client, namespace string) *VeleroClient {
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+	return &VeleroClient{Client: cl, Namespace: namespace}
+}
+
+// CreateBackup creates a Velero Backup of the objects in namespace matching selector.
+func (c *VeleroClient) CreateBackup(ctx context.Context, name, namespace string, selector map[string]string) (backup.OperationRef, error) {
+	spec := backupSpec{IncludedNamespaces: []string{namespace}}
+	if len(selector) > 0 {
+		spec.LabelSelector = &metav1.LabelSelector{MatchLabels: selector}
+	}
 
-func (c *VeleroClient) CreateBackup(name string, selector map[string]string) error {
-	selectorStr := ""
-	for k, v := range selector {
-		selectorStr += k + "=" + v
+	if err := c.create(ctx, backupGVK, name, &spec); err != nil {
+		return backup.OperationRef{}, fmt.Errorf("failed to create velero backup: %w", err)
 	}
-	cmd := exec.Command("velero", "backup", "create", name, "--selector", selectorStr)
-	output, err := cmd.CombinedOutput()
+	return backup.OperationRef{Name: name, Namespace: c.Namespace}, nil
+}
+
+// CreateRestore creates a Velero Restore of bkp, mapping the namespaces
+// included in the backup onto namespace.
+func (c *VeleroClient) CreateRestore(ctx context.Context, name string, bkp backup.OperationRef, namespace string) (backup.OperationRef, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(backupGVK)
+	if err := c.Client.Get(ctx, c.key(bkp), obj); err != nil {
+		return backup.OperationRef{}, fmt.Errorf("failed to get velero backup %s: %w", bkp.Name, err)
+	}
+	included, _, err := unstructured.NestedStringSlice(obj.Object, "spec", "includedNamespaces")
 	if err != nil {
-		return fmt.Errorf("velero backup failed: %v, output: %s", err, output)
+		return backup.OperationRef{}, fmt.Errorf("invalid velero backup %s: %w", bkp.Name, err)
 	}
-	return nil
+
+	spec := restoreSpec{BackupName: bkp.Name, NamespaceMapping: map[string]string{}}
+	for _, ns := range included {
+		spec.NamespaceMapping[ns] = namespace
+	}
+
+	if err := c.create(ctx, restoreGVK, name, &spec); err != nil {
+		return backup.OperationRef{}, fmt.Errorf("failed to create velero restore: %w", err)
+	}
+	return backup.OperationRef{Name: name, Namespace: c.Namespace}, nil
 }
 
-func (c *VeleroClient) CreateRestore(backupName, namespace string) error {
-	cmd := exec.Command("velero", "restore", "create", "restore-"+backupName, "--from-backup", backupName, "--namespace", namespace)
-	output, err := cmd.CombinedOutput()
+// GetBackupStatus reports the progress of a Backup, including the volume
+// data its PodVolumeBackups transferred.
+func (c *VeleroClient) GetBackupStatus(ctx context.Context, ref backup.OperationRef) (*backup.Status, error) {
+	st, err := c.status(ctx, backupGVK, ref)
 	if err != nil {
-		return fmt.Errorf("velero restore failed: %v, output: %s", err, output)
+		return nil, err
+	}
+
+	pvbs := &unstructured.UnstructuredList{}
+	pvbs.SetGroupVersionKind(podVolumeBackupListGVK)
+	if err := c.Client.List(ctx, pvbs, client.InNamespace(c.namespace(ref)), client.MatchingLabels{backupNameLabel: ref.Name}); err != nil {
+		// Size is informational, Velero without node agents has no PodVolumeBackup kind
+		return st, nil
+	}
+	for _, pvb := range pvbs.Items {
+		bytes, _, _ := unstructured.NestedInt64(pvb.Object, "status", "progress", "totalBytes")
+		st.SizeBytes += bytes
+	}
+	return st, nil
+}
+
+// GetRestoreStatus reports the progress of a Restore.
+func (c *VeleroClient) GetRestoreStatus(ctx context.Context, ref backup.OperationRef) (*backup.Status, error) {
+	return c.status(ctx, restoreGVK, ref)
+}
+
+// DeleteBackup asks Velero to delete the Backup together with its stored data.
+// Deleting the Backup object alone would let Velero sync it back from storage.
+func (c *VeleroClient) DeleteBackup(ctx context.Context, ref backup.OperationRef) error {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&deleteBackupRequestSpec{BackupName: ref.Name})
+	if err != nil {
+		return err
+	}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": content}}
+	obj.SetGroupVersionKind(deleteBackupRequestGVK)
+	obj.SetGenerateName(ref.Name + "-")
+	obj.SetNamespace(c.namespace(ref))
+	obj.SetLabels(map[string]string{backupNameLabel: ref.Name})
+	if err := c.Client.Create(ctx, obj); err != nil {
+		return fmt.Errorf("failed to request deletion of velero backup %s: %w", ref.Name, err)
+	}
+	return nil
+}
+
+// DeleteRestore deletes the Restore object. Objects it restored are not touched.
+func (c *VeleroClient) DeleteRestore(ctx context.Context, ref backup.OperationRef) error {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(restoreGVK)
+	obj.SetName(ref.Name)
+	obj.SetNamespace(c.namespace(ref))
+	if err := c.Client.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete velero restore %s: %w", ref.Name, err)
 	}
 	return nil
 }
+
+func (c *VeleroClient) create(ctx context.Context, gvk schema.GroupVersionKind, name string, spec interface{}) error {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(spec)
+	if err != nil {
+		return err
+	}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": content}}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(name)
+	obj.SetNamespace(c.Namespace)
+	return c.Client.Create(ctx, obj)
+}
+
+func (c *VeleroClient) status(ctx context.Context, gvk schema.GroupVersionKind, ref backup.OperationRef) (*backup.Status, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	if err := c.Client.Get(ctx, c.key(ref), obj); err != nil {
+		return nil, fmt.Errorf("failed to get velero %s %s: %w", gvk.Kind, ref.Name, err)
+	}
+
+	var st operationStatus
+	if raw, ok := obj.Object["status"].(map[string]interface{}); ok {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw, &st); err != nil {
+			return nil, fmt.Errorf("invalid velero %s %s status: %w", gvk.Kind, ref.Name, err)
+		}
+	}
+
+	status := &backup.Status{
+		Phase:    st.Phase,
+		Warnings: st.Warnings,
+		Errors:   st.Errors,
+		Messages: st.ValidationErrors,
+	}
+	if st.FailureReason != "" {
+		status.Messages = append(status.Messages, st.FailureReason)
+	}
+	if st.Progress != nil {
+		status.TotalItems = st.Progress.TotalItems
+		status.ItemsCompleted = st.Progress.ItemsBackedUp + st.Progress.ItemsRestored
+	}
+	if st.StartTimestamp != nil {
+		status.StartTime = &st.StartTimestamp.Time
+	}
+	if st.CompletionTimestamp != nil {
+		status.CompletionTime = &st.CompletionTimestamp.Time
+	}
+	switch st.Phase {
+	case "Completed":
+		status.Done = true
+	case "PartiallyFailed", "Failed", "FailedValidation":
+		status.Done = true
+		status.Failed = true
+	}
+	return status, nil
+}
+
+func (c *VeleroClient) key(ref backup.OperationRef) client.ObjectKey {
+	return client.ObjectKey{Namespace: c.namespace(ref), Name: ref.Name}
+}
+
+// namespace falls back to the client's namespace for refs recorded without one.
+func (c *VeleroClient) namespace(ref backup.OperationRef) string {
+	if ref.Namespace != "" {
+		return ref.Namespace
+	}
+	return c.Namespace
+}
@@ -0,0 +1,182 @@
+package velero
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/harrisin2037/chaos-dr-validator/internal/backup"
+)
+
+func getObject(t *testing.T, cl client.Client, kind, name string) *unstructured.Unstructured {
+	t.Helper()
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("velero.io/v1")
+	obj.SetKind(kind)
+	if err := cl.Get(context.Background(), client.ObjectKey{Namespace: DefaultNamespace, Name: name}, obj); err != nil {
+		t.Fatalf("Failed to get velero %s: %v", kind, err)
+	}
+	return obj
+}
+
+func TestCreateBackup(t *testing.T) {
+	cl := fake.NewClientBuilder().Build()
+	c := NewVeleroClient(cl, "")
+
+	selector := map[string]string{"app": "redis", "tier": "cache"}
+	ref, err := c.CreateBackup(context.Background(), "dr-backup", "default", selector)
+	if err != nil {
+		t.Fatalf("CreateBackup failed: %v", err)
+	}
+	if ref.Name != "dr-backup" || ref.Namespace != DefaultNamespace {
+		t.Errorf("Unexpected backup ref %+v", ref)
+	}
+
+	obj := getObject(t, cl, "Backup", "dr-backup")
+	labels, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "labelSelector", "matchLabels")
+	if len(labels) != 2 || labels["app"] != "redis" || labels["tier"] != "cache" {
+		t.Errorf("Expected both selector labels, got %v", labels)
+	}
+	namespaces, _, _ := unstructured.NestedStringSlice(obj.Object, "spec", "includedNamespaces")
+	if len(namespaces) != 1 || namespaces[0] != "default" {
+		t.Errorf("Expected includedNamespaces [default], got %v", namespaces)
+	}
+}
+
+func TestCreateRestore(t *testing.T) {
+	cl := fake.NewClientBuilder().Build()
+	c := NewVeleroClient(cl, "")
+
+	ref, err := c.CreateBackup(context.Background(), "dr-backup", "default", map[string]string{"app": "redis"})
+	if err != nil {
+		t.Fatalf("CreateBackup failed: %v", err)
+	}
+	if _, err := c.CreateRestore(context.Background(), "dr-restore", ref, "sandbox-test"); err != nil {
+		t.Fatalf("CreateRestore failed: %v", err)
+	}
+
+	obj := getObject(t, cl, "Restore", "dr-restore")
+	backupName, _, _ := unstructured.NestedString(obj.Object, "spec", "backupName")
+	if backupName != "dr-backup" {
+		t.Errorf("Expected backupName dr-backup, got %s", backupName)
+	}
+	mapping, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "namespaceMapping")
+	if mapping["default"] != "sandbox-test" {
+		t.Errorf("Expected default to map to sandbox-test, got %v", mapping)
+	}
+}
+
+func TestGetBackupStatus(t *testing.T) {
+	cl := fake.NewClientBuilder().Build()
+	c := NewVeleroClient(cl, "")
+
+	ref, err := c.CreateBackup(context.Background(), "dr-backup", "default", nil)
+	if err != nil {
+		t.Fatalf("CreateBackup failed: %v", err)
+	}
+
+	st, err := c.GetBackupStatus(context.Background(), ref)
+	if err != nil {
+		t.Fatalf("GetBackupStatus failed: %v", err)
+	}
+	if st.Done {
+		t.Error("Expected backup without a phase to be in progress")
+	}
+
+	obj := getObject(t, cl, "Backup", "dr-backup")
+	obj.Object["status"] = map[string]interface{}{
+		"phase":               "PartiallyFailed",
+		"warnings":            int64(2),
+		"errors":              int64(1),
+		"failureReason":       "volume snapshot failed",
+		"startTimestamp":      "2025-01-01T10:00:00Z",
+		"completionTimestamp": "2025-01-01T10:01:30Z",
+		"progress":            map[string]interface{}{"totalItems": int64(12), "itemsBackedUp": int64(10)},
+	}
+	if err := cl.Update(context.Background(), obj); err != nil {
+		t.Fatalf("Failed to update backup status: %v", err)
+	}
+
+	pvb := &unstructured.Unstructured{}
+	pvb.SetAPIVersion("velero.io/v1")
+	pvb.SetKind("PodVolumeBackup")
+	pvb.SetName("dr-backup-data")
+	pvb.SetNamespace(DefaultNamespace)
+	pvb.SetLabels(map[string]string{backupNameLabel: "dr-backup"})
+	pvb.Object["status"] = map[string]interface{}{"progress": map[string]interface{}{"totalBytes": int64(4096)}}
+	if err := cl.Create(context.Background(), pvb); err != nil {
+		t.Fatalf("Failed to create PodVolumeBackup: %v", err)
+	}
+
+	st, err = c.GetBackupStatus(context.Background(), ref)
+	if err != nil {
+		t.Fatalf("GetBackupStatus failed: %v", err)
+	}
+	if !st.Done || !st.Failed {
+		t.Errorf("Expected PartiallyFailed to be done and failed, got %+v", st)
+	}
+	if st.Warnings != 2 || st.Errors != 1 {
+		t.Errorf("Expected 2 warnings and 1 error, got %d and %d", st.Warnings, st.Errors)
+	}
+	if len(st.Messages) != 1 || st.Messages[0] != "volume snapshot failed" {
+		t.Errorf("Expected failure reason in messages, got %v", st.Messages)
+	}
+	if st.TotalItems != 12 || st.ItemsCompleted != 10 {
+		t.Errorf("Expected 10 of 12 items, got %d of %d", st.ItemsCompleted, st.TotalItems)
+	}
+	if st.SizeBytes != 4096 {
+		t.Errorf("Expected 4096 bytes, got %d", st.SizeBytes)
+	}
+	if st.Duration() != 90*time.Second {
+		t.Errorf("Expected duration 1m30s, got %s", st.Duration())
+	}
+}
+
+func TestDeleteBackup(t *testing.T) {
+	cl := fake.NewClientBuilder().Build()
+	c := NewVeleroClient(cl, "")
+
+	if err := c.DeleteBackup(context.Background(), backup.OperationRef{Name: "dr-backup"}); err != nil {
+		t.Fatalf("DeleteBackup failed: %v", err)
+	}
+
+	requests := &unstructured.UnstructuredList{}
+	requests.SetAPIVersion("velero.io/v1")
+	requests.SetKind("DeleteBackupRequestList")
+	if err := cl.List(context.Background(), requests, client.InNamespace(DefaultNamespace)); err != nil {
+		t.Fatalf("Failed to list DeleteBackupRequests: %v", err)
+	}
+	if len(requests.Items) != 1 {
+		t.Fatalf("Expected 1 DeleteBackupRequest, got %d", len(requests.Items))
+	}
+	backupName, _, _ := unstructured.NestedString(requests.Items[0].Object, "spec", "backupName")
+	if backupName != "dr-backup" {
+		t.Errorf("Expected backupName dr-backup, got %s", backupName)
+	}
+}
+
+func TestDeleteRestore(t *testing.T) {
+	cl := fake.NewClientBuilder().Build()
+	c := NewVeleroClient(cl, "")
+
+	ref, err := c.CreateBackup(context.Background(), "dr-backup", "default", nil)
+	if err != nil {
+		t.Fatalf("CreateBackup failed: %v", err)
+	}
+	restoreRef, err := c.CreateRestore(context.Background(), "dr-restore", ref, "sandbox-test")
+	if err != nil {
+		t.Fatalf("CreateRestore failed: %v", err)
+	}
+
+	if err := c.DeleteRestore(context.Background(), restoreRef); err != nil {
+		t.Fatalf("DeleteRestore failed: %v", err)
+	}
+	// Deleting again is not an error
+	if err := c.DeleteRestore(context.Background(), restoreRef); err != nil {
+		t.Fatalf("DeleteRestore of a missing restore failed: %v", err)
+	}
+}